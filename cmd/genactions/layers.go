@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// scopeLayer is declared by a `//jjui:layer scope=... outer=...` directive
+// next to the code that routes keys to the scope. Outer lists the scopes that
+// stay active under it, nearest first, separated by commas; without outer
+// the scope stops routing altogether.
+type scopeLayer struct {
+	Scope string
+	Outer []string
+}
+
+const layerDirective = "//jjui:layer"
+
+// collectScopeLayers reads the layer directives under dir.
+func collectScopeLayers(dir string) ([]scopeLayer, error) {
+	seen := map[string]token.Position{}
+	var layers []scopeLayer
+	var errs []string
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				if !strings.HasPrefix(comment.Text, layerDirective+" ") {
+					continue
+				}
+				pos := fset.Position(comment.Pos())
+				layer, err := parseLayerDirective(strings.TrimPrefix(comment.Text, layerDirective))
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("%s: %v", pos, err))
+					continue
+				}
+				for _, scope := range append([]string{layer.Scope}, layer.Outer...) {
+					if err := validateScopeFormat(scope); err != nil {
+						errs = append(errs, fmt.Sprintf("%s: %v", pos, err))
+					}
+				}
+				if previous, ok := seen[layer.Scope]; ok {
+					errs = append(errs, fmt.Sprintf("%s: scope %q is already declared at %s", pos, layer.Scope, previous))
+					continue
+				}
+				seen[layer.Scope] = pos
+				layers = append(layers, layer)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "\n"))
+	}
+	sort.Slice(layers, func(i, j int) bool { return layers[i].Scope < layers[j].Scope })
+	return layers, nil
+}
+
+func parseLayerDirective(text string) (scopeLayer, error) {
+	var layer scopeLayer
+	for _, field := range strings.Fields(text) {
+		key, value, ok := strings.Cut(field, "=")
+		switch {
+		case ok && key == "scope":
+			layer.Scope = value
+		case ok && key == "outer":
+			if value != "" {
+				layer.Outer = strings.Split(value, ",")
+			}
+		default:
+			return scopeLayer{}, fmt.Errorf("invalid layer directive field %q", field)
+		}
+	}
+	if layer.Scope == "" {
+		return scopeLayer{}, errors.New("layer directive has no scope")
+	}
+	return layer, nil
+}
+
+func generateScopeLayersSource(layers []scopeLayer) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by cmd/genactions; DO NOT EDIT.\n")
+	b.WriteString("package config\n\n")
+	b.WriteString("// layeredScopes lists the scopes that stay active under a scope layered on\n")
+	b.WriteString("// top of them, nearest first, as declared by the //jjui:layer directives.\n")
+	b.WriteString("var layeredScopes = map[string][]string{\n")
+	for _, layer := range layers {
+		if len(layer.Outer) == 0 {
+			b.WriteString(fmt.Sprintf("\t%q: nil,\n", layer.Scope))
+			continue
+		}
+		quoted := make([]string, len(layer.Outer))
+		for i, scope := range layer.Outer {
+			quoted[i] = fmt.Sprintf("%q", scope)
+		}
+		b.WriteString(fmt.Sprintf("\t%q: {%s},\n", layer.Scope, strings.Join(quoted, ", ")))
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
@@ -24,6 +24,9 @@ type bindRule struct {
 	Action string
 	Intent string
 	Set    map[string]string
+	// Hides lists the full ids of outer scope actions that this action
+	// replaces on the same key on purpose.
+	Hides []string
 }
 
 type intentTypeMeta struct {
@@ -90,6 +93,12 @@ func main() {
 		fmt.Fprintf(os.Stderr, "validate action metadata: %v\n", err)
 		os.Exit(1)
 	}
+	actionCommands := deriveActionCommands(rules)
+	actionHides := deriveActionHides(rules)
+	if err := validateActionHides(actionHides, actionScopes); err != nil {
+		fmt.Fprintf(os.Stderr, "validate action hides: %v\n", err)
+		os.Exit(1)
+	}
 
 	src, err := generateCatalogSource(rules, intents, enums)
 	if err != nil {
@@ -101,7 +110,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	meta, err := generateActionMetaSource(actionArgSchemas, actionRequiredArgs, actionScopes)
+	meta, err := generateActionMetaSource(actionArgSchemas, actionRequiredArgs, actionScopes, actionCommands, actionHides)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "generate action meta: %v\n", err)
 		os.Exit(1)
@@ -125,6 +134,36 @@ func main() {
 		fmt.Fprintf(os.Stderr, "write types.lua: %v\n", err)
 		os.Exit(1)
 	}
+
+	lookups, err := collectPaletteLookups(filepath.Join(repoRoot, "internal/ui"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "collect palette lookups: %v\n", err)
+		os.Exit(1)
+	}
+	paletteSrc, err := generatePaletteLookupsSource(lookups)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "generate palette lookups: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(filepath.Join(repoRoot, "internal/config/palette_keys_gen.go"), paletteSrc, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "write palette lookups: %v\n", err)
+		os.Exit(1)
+	}
+
+	layers, err := collectScopeLayers(filepath.Join(repoRoot, "internal/ui"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "collect scope layers: %v\n", err)
+		os.Exit(1)
+	}
+	layersSrc, err := generateScopeLayersSource(layers)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "generate scope layers: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(filepath.Join(repoRoot, "internal/config/layered_scopes_gen.go"), layersSrc, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "write scope layers: %v\n", err)
+		os.Exit(1)
+	}
 }
 
 func findRepoRoot() (string, error) {
@@ -201,14 +240,16 @@ func generateCatalogSource(rules []bindRule, intents map[string]intentTypeMeta,
 	return src, nil
 }
 
-func generateActionMetaSource(actionArgSchemas map[string]map[string]string, actionRequiredArgs map[string][]string, actionScopes map[string][]string) ([]byte, error) {
+func generateActionMetaSource(actionArgSchemas map[string]map[string]string, actionRequiredArgs map[string][]string, actionScopes map[string][]string, actionCommands map[string]string, actionHides map[string][]string) ([]byte, error) {
 	var b bytes.Buffer
 	b.WriteString("// Code generated by cmd/genactions; DO NOT EDIT.\n")
 	b.WriteString("package actionmeta\n\n")
 	b.WriteString("import (\n")
 	b.WriteString("\t\"fmt\"\n")
+	b.WriteString("\t\"slices\"\n")
 	b.WriteString("\t\"sort\"\n")
-	b.WriteString("\t\"strings\"\n")
+	b.WriteString("\t\"strings\"\n\n")
+	b.WriteString("\t\"github.com/idursun/jjui/internal/ui/actionargs\"\n")
 	b.WriteString(")\n\n")
 
 	b.WriteString("var builtInActionScopes = map[string][]string{\n")
@@ -268,6 +309,35 @@ func generateActionMetaSource(actionArgSchemas map[string]map[string]string, act
 	}
 	b.WriteString("}\n\n")
 
+	b.WriteString("var builtInActionCommands = map[string]string{\n")
+	commandActionNames := make([]string, 0, len(actionCommands))
+	for action := range actionCommands {
+		commandActionNames = append(commandActionNames, action)
+	}
+	sort.Strings(commandActionNames)
+	for _, action := range commandActionNames {
+		b.WriteString(fmt.Sprintf("\t%q: %q,\n", action, actionCommands[action]))
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString("var builtInActionHides = map[string][]string{\n")
+	hidingActionNames := make([]string, 0, len(actionHides))
+	for action := range actionHides {
+		hidingActionNames = append(hidingActionNames, action)
+	}
+	sort.Strings(hidingActionNames)
+	for _, action := range hidingActionNames {
+		b.WriteString(fmt.Sprintf("\t%q: {", action))
+		for i, hidden := range actionHides[action] {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(fmt.Sprintf("%q", hidden))
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("}\n\n")
+
 	b.WriteString("func ActionScopes(action string) []string {\n")
 	b.WriteString("\taction = strings.TrimSpace(action)\n")
 	b.WriteString("\tscopes, ok := builtInActionScopes[action]\n")
@@ -275,6 +345,18 @@ func generateActionMetaSource(actionArgSchemas map[string]map[string]string, act
 	b.WriteString("\treturn append([]string(nil), scopes...)\n")
 	b.WriteString("}\n\n")
 
+	b.WriteString("// ActionCommand returns the intent an action dispatches together with the\n")
+	b.WriteString("// fields it sets; actions with the same command do the same thing.\n")
+	b.WriteString("func ActionCommand(action string) string {\n")
+	b.WriteString("\treturn builtInActionCommands[strings.TrimSpace(action)]\n")
+	b.WriteString("}\n\n")
+
+	b.WriteString("// ActionHides reports whether action is declared to replace the outer scope\n")
+	b.WriteString("// action hidden on the same key.\n")
+	b.WriteString("func ActionHides(action string, hidden string) bool {\n")
+	b.WriteString("\treturn slices.Contains(builtInActionHides[strings.TrimSpace(action)], strings.TrimSpace(hidden))\n")
+	b.WriteString("}\n\n")
+
 	b.WriteString("func ActionArgSchema(action string) map[string]string {\n")
 	b.WriteString("\treturn builtInActionArgSchemas[action]\n")
 	b.WriteString("}\n\n")
@@ -319,6 +401,10 @@ func generateActionMetaSource(actionArgSchemas map[string]map[string]string, act
 	b.WriteString("\t\t\tif _, ok := value.(bool); !ok {\n")
 	b.WriteString("\t\t\t\treturn fmt.Errorf(\"action %q arg %q expects bool\", action, key)\n")
 	b.WriteString("\t\t\t}\n")
+	b.WriteString("\t\tcase \"int\":\n")
+	b.WriteString("\t\t\tif _, ok := actionargs.AsInt(value); !ok {\n")
+	b.WriteString("\t\t\t\treturn fmt.Errorf(\"action %q arg %q expects int\", action, key)\n")
+	b.WriteString("\t\t\t}\n")
 	b.WriteString("\t\tcase \"string\":\n")
 	b.WriteString("\t\t\tif _, ok := value.(string); !ok {\n")
 	b.WriteString("\t\t\t\treturn fmt.Errorf(\"action %q arg %q expects string\", action, key)\n")
@@ -507,6 +593,8 @@ func parseBindDirectives(doc *ast.CommentGroup, intentType string) []parsedRule
 					}
 					rule.Set[field] = value
 				}
+			case "hides":
+				rule.Hides = append(rule.Hides, strings.Split(v, ",")...)
 			default:
 				errs = append(errs, fmt.Errorf("unknown directive key %q in %q", k, line))
 			}
@@ -709,6 +797,54 @@ func deriveActionScopes(rules []bindRule) map[string][]string {
 	return out
 }
 
+// deriveActionCommands maps every action to the intent it dispatches and the
+// fields it sets.
+func deriveActionCommands(rules []bindRule) map[string]string {
+	out := map[string]string{}
+	for _, rule := range rules {
+		command := rule.Intent
+		if len(rule.Set) > 0 {
+			fields := make([]string, 0, len(rule.Set))
+			for field, value := range rule.Set {
+				fields = append(fields, field+":"+value)
+			}
+			sort.Strings(fields)
+			command += "{" + strings.Join(fields, ",") + "}"
+		}
+		out[canonicalActionID(rule.Scope, rule.Action)] = command
+	}
+	return out
+}
+
+func deriveActionHides(rules []bindRule) map[string][]string {
+	out := map[string][]string{}
+	for _, rule := range rules {
+		if len(rule.Hides) == 0 {
+			continue
+		}
+		fullID := canonicalActionID(rule.Scope, rule.Action)
+		out[fullID] = append(out[fullID], rule.Hides...)
+		sort.Strings(out[fullID])
+	}
+	return out
+}
+
+func validateActionHides(actionHides map[string][]string, actionScopes map[string][]string) error {
+	var errs []string
+	for action, hidden := range actionHides {
+		for _, outer := range hidden {
+			if _, ok := actionScopes[outer]; !ok {
+				errs = append(errs, fmt.Sprintf("action %q hides unknown action %q", action, outer))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
 func validateRules(rules []bindRule, intents map[string]intentTypeMeta, enums map[string][]enumValueMeta) error {
 	var errs []string
 	seenFullIDs := map[string]struct{}{}
@@ -801,10 +937,10 @@ func validateSetValueType(fieldType, value string, enums map[string][]enumValueM
 		}
 		return fmt.Errorf("expected bool or $bool(...), got %q", value)
 	case "int":
-		if _, err := strconv.Atoi(value); err == nil {
+		if _, err := strconv.Atoi(value); err == nil || isIntArg(value) {
 			return nil
 		}
-		return fmt.Errorf("expected int, got %q", value)
+		return fmt.Errorf("expected int or $int(...), got %q", value)
 	case "string":
 		if strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
 			return nil
@@ -848,6 +984,10 @@ func isBoolArg(value string) bool {
 	return strings.HasPrefix(value, "$bool(") && strings.HasSuffix(value, ")")
 }
 
+func isIntArg(value string) bool {
+	return strings.HasPrefix(value, "$int(") && strings.HasSuffix(value, ")")
+}
+
 func isStringArg(value string) bool {
 	return strings.HasPrefix(value, "$string(") && strings.HasSuffix(value, ")")
 }
@@ -864,6 +1004,13 @@ func parseArgRef(fieldType, value string, enums map[string][]enumValueMeta) (nam
 		}
 		return name, "bool", false, true
 	}
+	if isIntArg(value) {
+		name := strings.TrimSuffix(strings.TrimPrefix(value, "$int("), ")")
+		if name == "" {
+			return "", "", false, false
+		}
+		return name, "int", false, true
+	}
 	if argName, ok := parseEnumArg(value); ok {
 		if len(enums[fieldType]) == 0 || argName == "" {
 			return "", "", false, false
@@ -987,6 +1134,10 @@ func renderValue(fieldType, value string, enums map[string][]enumValueMeta) stri
 		name := strings.TrimSuffix(strings.TrimPrefix(value, "$bool("), ")")
 		return fmt.Sprintf("actionargs.BoolArg(args, %q, false)", name)
 	}
+	if isIntArg(value) {
+		name := strings.TrimSuffix(strings.TrimPrefix(value, "$int("), ")")
+		return fmt.Sprintf("actionargs.IntArg(args, %q, 0)", name)
+	}
 	if isStringArg(value) {
 		name := strings.TrimSuffix(strings.TrimPrefix(value, "$string("), ")")
 		return fmt.Sprintf("actionargs.StringArg(args, %q, \"\")", name)
@@ -1458,6 +1609,8 @@ func schemaTypeToLua(schemaType string) string {
 	switch schemaType {
 	case "bool":
 		return "boolean"
+	case "int":
+		return "integer"
 	case "string":
 		return "string"
 	default:
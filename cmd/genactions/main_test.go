@@ -92,7 +92,10 @@ func TestGeneratedCatalogIsUpToDate(t *testing.T) {
 	scopes := deriveActionScopes(rules)
 	err = validateActionMetadata(actionIDs, scopes)
 	require.NoError(t, err)
-	metaGenerated, err := generateActionMetaSource(schemas, requiredArgs, scopes)
+	hides := deriveActionHides(rules)
+	err = validateActionHides(hides, scopes)
+	require.NoError(t, err)
+	metaGenerated, err := generateActionMetaSource(schemas, requiredArgs, scopes, deriveActionCommands(rules), hides)
 	require.NoError(t, err)
 	metaCurrent, err := os.ReadFile(filepath.Join(root, "internal/ui/actionmeta/builtins_gen.go"))
 	require.NoError(t, err)
@@ -142,3 +145,68 @@ func repoRoot(t *testing.T) string {
 	require.True(t, ok)
 	return filepath.Clean(filepath.Join(filepath.Dir(file), "..", ".."))
 }
+
+func TestGeneratedPaletteLookupsIsUpToDate(t *testing.T) {
+	root := repoRoot(t)
+
+	lookups, err := collectPaletteLookups(filepath.Join(root, "internal/ui"))
+	require.NoError(t, err)
+	generated, err := generatePaletteLookupsSource(lookups)
+	require.NoError(t, err)
+
+	current, err := os.ReadFile(filepath.Join(root, "internal/config/palette_keys_gen.go"))
+	require.NoError(t, err)
+	require.Equal(t, string(current), string(generated), "generated palette lookups are stale; run `go run ./cmd/genactions`")
+}
+
+func TestParsePaletteDirective_ExpandsAlternatives(t *testing.T) {
+	lookups, err := parsePaletteDirective(" scope=revisions|undo component=confirmation selected=true|false")
+	require.NoError(t, err)
+	require.Equal(t, []paletteLookup{
+		{Scope: "revisions", Component: "confirmation", Selected: true},
+		{Scope: "revisions", Component: "confirmation", Selected: false},
+		{Scope: "undo", Component: "confirmation", Selected: true},
+		{Scope: "undo", Component: "confirmation", Selected: false},
+	}, lookups)
+
+	_, err = parsePaletteDirective(" scope=revisions colour=red")
+	require.ErrorContains(t, err, `invalid palette directive field "colour=red"`)
+}
+
+func TestGeneratedScopeLayersIsUpToDate(t *testing.T) {
+	root := repoRoot(t)
+
+	layers, err := collectScopeLayers(filepath.Join(root, "internal/ui"))
+	require.NoError(t, err)
+	generated, err := generateScopeLayersSource(layers)
+	require.NoError(t, err)
+
+	current, err := os.ReadFile(filepath.Join(root, "internal/config/layered_scopes_gen.go"))
+	require.NoError(t, err)
+	require.Equal(t, string(current), string(generated), "generated scope layers are stale; run `go run ./cmd/genactions`")
+}
+
+func TestParseLayerDirective(t *testing.T) {
+	layer, err := parseLayerDirective(" scope=revisions.visual outer=revisions,ui")
+	require.NoError(t, err)
+	require.Equal(t, scopeLayer{Scope: "revisions.visual", Outer: []string{"revisions", "ui"}}, layer)
+
+	layer, err = parseLayerDirective(" scope=input")
+	require.NoError(t, err)
+	require.Equal(t, scopeLayer{Scope: "input"}, layer)
+
+	_, err = parseLayerDirective(" outer=ui")
+	require.ErrorContains(t, err, "layer directive has no scope")
+}
+
+func TestValidateActionHides_RejectsUnknownActions(t *testing.T) {
+	doc := &ast.CommentGroup{List: []*ast.Comment{{Text: "//jjui:bind scope=oplog action=close hides=ui.cancel,ui.nope"}}}
+	parsed := parseBindDirectives(doc, "OpLogClose")
+	require.Len(t, parsed, 1)
+	require.Empty(t, parsed[0].Errs)
+
+	hides := deriveActionHides([]bindRule{parsed[0].Rule})
+	require.Equal(t, map[string][]string{"oplog.close": {"ui.cancel", "ui.nope"}}, hides)
+	err := validateActionHides(hides, map[string][]string{"oplog.close": {"oplog"}, "ui.cancel": {"ui"}})
+	require.ErrorContains(t, err, `action "oplog.close" hides unknown action "ui.nope"`)
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// paletteLookup is a call to common.DefaultPalette.Get, GetBlended or
+// GetBorder with literal arguments, or one declared by a
+// `//jjui:palette scope=... component=... role=... selected=...` directive
+// where the arguments are only known at runtime. Directive values can list
+// alternatives separated by |.
+type paletteLookup struct {
+	Scope     string
+	Component string
+	Role      string
+	Selected  bool
+}
+
+const paletteDirective = "//jjui:palette"
+
+var paletteMethods = map[string]bool{"Get": true, "GetBlended": true, "GetBorder": true}
+
+func collectPaletteLookups(dir string) ([]paletteLookup, error) {
+	seen := map[paletteLookup]struct{}{}
+	var errs []string
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				if !strings.HasPrefix(comment.Text, paletteDirective+" ") {
+					continue
+				}
+				lookups, err := parsePaletteDirective(strings.TrimPrefix(comment.Text, paletteDirective))
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("%s: %v", fset.Position(comment.Pos()), err))
+				}
+				for _, lookup := range lookups {
+					seen[lookup] = struct{}{}
+				}
+			}
+		}
+		// method values such as `getStyle := common.DefaultPalette.Get`
+		aliases := map[string]bool{}
+		ast.Inspect(file, func(n ast.Node) bool {
+			if assign, ok := n.(*ast.AssignStmt); ok && len(assign.Lhs) == len(assign.Rhs) {
+				for i, rhs := range assign.Rhs {
+					if ident, ok := assign.Lhs[i].(*ast.Ident); ok && isDefaultPaletteMethod(rhs) {
+						aliases[ident.Name] = true
+					}
+				}
+			}
+			return true
+		})
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) < 4 {
+				return true
+			}
+			if ident, ok := call.Fun.(*ast.Ident); !isDefaultPaletteMethod(call.Fun) && (!ok || !aliases[ident.Name]) {
+				return true
+			}
+			var args [3]string
+			for i := range args {
+				lit, ok := call.Args[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					// runtime arguments are declared with a directive
+					return true
+				}
+				args[i], _ = strconv.Unquote(lit.Value)
+			}
+			lookup := paletteLookup{Scope: args[0], Component: args[1], Role: args[2]}
+			// a selection flag computed at runtime can go either way
+			if ident, ok := call.Args[3].(*ast.Ident); !ok || ident.Name != "false" {
+				lookup.Selected = true
+				seen[lookup] = struct{}{}
+			}
+			if ident, ok := call.Args[3].(*ast.Ident); !ok || ident.Name != "true" {
+				lookup.Selected = false
+				seen[lookup] = struct{}{}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errors.New(strings.Join(errs, "\n"))
+	}
+
+	lookups := make([]paletteLookup, 0, len(seen))
+	for lookup := range seen {
+		lookups = append(lookups, lookup)
+	}
+	sort.Slice(lookups, func(i, j int) bool {
+		a, b := lookups[i], lookups[j]
+		if a.Scope != b.Scope {
+			return a.Scope < b.Scope
+		}
+		if a.Component != b.Component {
+			return a.Component < b.Component
+		}
+		if a.Role != b.Role {
+			return a.Role < b.Role
+		}
+		return !a.Selected && b.Selected
+	})
+	return lookups, nil
+}
+
+// parsePaletteDirective expands the alternatives of a //jjui:palette directive
+// into lookups.
+func parsePaletteDirective(text string) ([]paletteLookup, error) {
+	values := map[string][]string{"scope": {""}, "component": {""}, "role": {""}, "selected": {"false"}}
+	for _, field := range strings.Fields(text) {
+		key, value, ok := strings.Cut(field, "=")
+		if _, known := values[key]; !ok || !known {
+			return nil, fmt.Errorf("invalid palette directive field %q", field)
+		}
+		values[key] = strings.Split(value, "|")
+	}
+
+	var lookups []paletteLookup
+	for _, scope := range values["scope"] {
+		for _, component := range values["component"] {
+			for _, role := range values["role"] {
+				for _, selected := range values["selected"] {
+					isSelected, err := strconv.ParseBool(selected)
+					if err != nil {
+						return nil, fmt.Errorf("invalid palette directive selected=%q", selected)
+					}
+					lookups = append(lookups, paletteLookup{Scope: scope, Component: component, Role: role, Selected: isSelected})
+				}
+			}
+		}
+	}
+	return lookups, nil
+}
+
+func isDefaultPaletteMethod(fun ast.Expr) bool {
+	sel, ok := fun.(*ast.SelectorExpr)
+	if !ok || !paletteMethods[sel.Sel.Name] {
+		return false
+	}
+	switch x := sel.X.(type) {
+	case *ast.SelectorExpr:
+		return x.Sel.Name == "DefaultPalette"
+	case *ast.Ident:
+		return x.Name == "DefaultPalette"
+	}
+	return false
+}
+
+func generatePaletteLookupsSource(lookups []paletteLookup) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by cmd/genactions; DO NOT EDIT.\n")
+	b.WriteString("package config\n\n")
+	b.WriteString("var paletteLookups = []paletteLookup{\n")
+	for _, l := range lookups {
+		b.WriteString(fmt.Sprintf("\t{scope: %q, component: %q, role: %q, selected: %t},\n", l.Scope, l.Component, l.Role, l.Selected))
+	}
+	b.WriteString("}\n")
+	return format.Source(b.Bytes())
+}
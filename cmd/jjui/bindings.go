@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/idursun/jjui/internal/config"
+)
+
+func bindingsUsage(flags *flag.FlagSet) func() {
+	return func() {
+		fmt.Printf("Usage: jjui bindings list|check [flags] [location]\n")
+		fmt.Println("  list   print the effective bindings")
+		fmt.Println("  check  report invalid, shadowed, hidden and unreachable bindings")
+		fmt.Println("Flags:")
+		flags.PrintDefaults()
+	}
+}
+
+// runBindings implements `jjui bindings list|check`. Bindings are loaded from
+// the same config files as the UI, except for config.lua.
+func runBindings(args []string) int {
+	flags := flag.NewFlagSet("bindings", flag.ContinueOnError)
+	format := flags.String("format", "toml", "Output format of list: toml or json")
+	flags.Usage = bindingsUsage(flags)
+	if len(args) == 0 {
+		flags.Usage()
+		return 2
+	}
+	command := args[0]
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	location := flags.Arg(0)
+	if err := loadBindingsConfig(location); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		return 1
+	}
+
+	switch command {
+	case "list":
+		if err := listBindings(os.Stdout, *format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	case "check":
+		return checkBindings(os.Stdout)
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown bindings command %q\n", command)
+		flags.Usage()
+		return 2
+	}
+}
+
+// loadBindingsConfig loads the global config and, when location is inside a
+// jj repo, the repository config on top of the defaults.
+func loadBindingsConfig(location string) error {
+	if output, err := config.LoadConfigFile(); err == nil {
+		if err := config.Current.LoadUnchecked(string(output), config.GetConfigDir()); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if config.EnvConfigDir() != "" {
+		return nil
+	}
+	if location == "" {
+		location = "."
+	}
+	rootLocation, err := getJJRootDir(location)
+	if err != nil {
+		return nil
+	}
+	if output, err := config.LoadRepoConfigFile(rootLocation); err == nil {
+		return config.Current.LoadUnchecked(string(output), filepath.Join(rootLocation, ".jjui"))
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func listBindings(w io.Writer, format string) error {
+	bindings := config.EffectiveBindings(config.Current.Bindings)
+	switch format {
+	case "toml":
+		encoder := toml.NewEncoder(w)
+		encoder.Indent = ""
+		return encoder.Encode(map[string]any{"bindings": bindings})
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(bindings)
+	default:
+		return fmt.Errorf("unknown format %q, expected toml or json", format)
+	}
+}
+
+// checkBindings prints the problems found in the bindings and fails when any
+// of them is an error.
+func checkBindings(w io.Writer) int {
+	issues := config.CheckBindings(config.Current.Bindings, config.Current.Actions)
+	errorCount := 0
+	for _, issue := range issues {
+		fmt.Fprintln(w, issue)
+		if issue.Severity == config.BindingError {
+			errorCount++
+		}
+	}
+	if len(issues) == 0 {
+		fmt.Fprintf(w, "No problems found in %d bindings\n", len(config.Current.Bindings))
+		return 0
+	}
+	fmt.Fprintf(w, "%d errors, %d warnings\n", errorCount, len(issues)-errorCount)
+	if errorCount > 0 {
+		return 1
+	}
+	return 0
+}
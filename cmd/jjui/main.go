@@ -41,13 +41,15 @@ func getVersion() string {
 }
 
 var (
-	revset          string
-	period          int
-	limit           int
-	version         bool
-	editConfig      bool
-	installLuaTypes bool
-	help            bool
+	revset            string
+	period            int
+	limit             int
+	version           bool
+	editConfig        bool
+	installLuaTypes   bool
+	checkBindingsFlag bool
+	listPaletteKeys   bool
+	help              bool
 )
 
 func init() {
@@ -60,10 +62,13 @@ func init() {
 	flag.BoolVar(&version, "version", false, "Show version information")
 	flag.BoolVar(&editConfig, "config", false, "Open configuration file in $EDITOR")
 	flag.BoolVar(&installLuaTypes, "install-lua-types", false, "Write Lua type definitions to config directory for LuaLS autocomplete")
+	flag.BoolVar(&checkBindingsFlag, "check-bindings", false, "Check key bindings for errors and conflicts, then exit")
+	flag.BoolVar(&listPaletteKeys, "list-palette-keys", false, "List the color keys a theme can set, then exit")
 	flag.BoolVar(&help, "help", false, "Show help information")
 
 	flag.Usage = func() {
 		fmt.Printf("Usage: jjui [flags] [location]\n")
+		fmt.Printf("       jjui bindings list|check [flags] [location]\n")
 		fmt.Println("Flags:")
 		flag.PrintDefaults()
 	}
@@ -118,6 +123,16 @@ func run() int {
 		return 0
 	case editConfig:
 		return config.Edit()
+	case checkBindingsFlag:
+		return runBindings(append([]string{"check"}, flag.Args()...))
+	case listPaletteKeys:
+		for _, key := range config.PaletteKeys() {
+			fmt.Println(key)
+		}
+		return 0
+	}
+	if args := flag.Args(); len(args) > 0 && args[0] == "bindings" {
+		return runBindings(args[1:])
 	}
 
 	var location string
@@ -153,6 +168,7 @@ func run() int {
 
 	appContext := context.NewAppContext(rootLocation, askpassServer)
 	defer appContext.Histories.Flush()
+	defer appContext.MessageLog.Close()
 
 	if output, err := config.LoadConfigFile(); err == nil {
 		if err := config.Current.Load(string(output), config.GetConfigDir()); err != nil {
@@ -229,6 +245,9 @@ func run() int {
 		fmt.Fprintf(os.Stderr, "Error loading theme: %v\n", err)
 		return 1
 	}
+	for _, warning := range theme.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
 	appContext.ThemeBackgroundBlend = theme.BackgroundBlend
 	common.DefaultPalette.Update(theme.Colors)
 	common.DefaultPalette.ConfigureBackgroundBlend(
@@ -0,0 +1,215 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/idursun/jjui/internal/ui/actionmeta"
+	keybindings "github.com/idursun/jjui/internal/ui/bindings"
+)
+
+type BindingSeverity string
+
+const (
+	BindingError   BindingSeverity = "error"
+	BindingWarning BindingSeverity = "warning"
+)
+
+// BindingIssue is a problem found in the bindings by CheckBindings.
+type BindingIssue struct {
+	Severity BindingSeverity
+	Scope    string
+	Keys     string
+	Message  string
+}
+
+func (i BindingIssue) String() string {
+	return fmt.Sprintf("%s: [%s] %s: %s", i.Severity, i.Scope, i.Keys, i.Message)
+}
+
+// CheckBindings reports invalid bindings, unknown actions, missing or invalid
+// args, keys shadowed by a later binding in the same scope or hidden by a
+// binding in a scope on top of it, and keys that can never be pressed because
+// a sequence active in their scope starts with them.
+func CheckBindings(bindings []BindingConfig, actions []ActionConfig) []BindingIssue {
+	var issues []BindingIssue
+	report := func(severity BindingSeverity, binding BindingConfig, keys string, format string, args ...any) {
+		issues = append(issues, BindingIssue{
+			Severity: severity,
+			Scope:    strings.TrimSpace(binding.Scope),
+			Keys:     keys,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	custom := make(map[string]bool, len(actions))
+	for _, action := range actions {
+		custom[strings.TrimSpace(action.Name)] = true
+	}
+
+	for _, binding := range bindings {
+		keys := bindingKeys(binding)
+		action := strings.TrimSpace(binding.Action)
+		runtime := keybindings.Binding{
+			Action: keybindings.Action(action),
+			Scope:  keybindings.ScopeName(strings.TrimSpace(binding.Scope)),
+			Key:    binding.Key,
+			Seq:    binding.Seq,
+			When:   strings.TrimSpace(binding.When),
+		}
+		if err := keybindings.ValidateBindings([]keybindings.Binding{runtime}); err != nil {
+			if inner := errors.Unwrap(err); inner != nil {
+				err = inner
+			}
+			report(BindingError, binding, keys, "%v", err)
+			continue
+		}
+		if custom[action] {
+			continue
+		}
+		if !actionmeta.IsBuiltInAction(action) {
+			report(BindingError, binding, keys, "unknown action %q", action)
+			continue
+		}
+		if err := actionmeta.ValidateBuiltInActionArgs(action, binding.Args); err != nil {
+			report(BindingError, binding, keys, "%v", err)
+		}
+	}
+
+	type slot struct {
+		scope string
+		keys  string
+		when  string
+	}
+	last := map[slot]BindingConfig{}
+	for _, binding := range bindings {
+		scope := strings.TrimSpace(binding.Scope)
+		var slots []string
+		if len(binding.Seq) > 0 {
+			slots = []string{strings.Join(binding.Seq, " ")}
+		} else {
+			slots = binding.Key
+		}
+		when := strings.TrimSpace(binding.When)
+		for _, keys := range slots {
+			if previous, ok := last[slot{scope, keys, when}]; ok && !sameBinding(previous, binding) {
+				report(BindingWarning, previous, keys, "%s is shadowed by %s", previous.Action, binding.Action)
+			}
+			last[slot{scope, keys, when}] = binding
+		}
+	}
+
+	for _, inner := range bindings {
+		// a conditional binding leaves the key to the outer scopes when it does not apply
+		if len(inner.Key) == 0 || strings.TrimSpace(inner.When) != "" {
+			continue
+		}
+		innerScope := strings.TrimSpace(inner.Scope)
+		for _, outerScope := range outerScopes(innerScope) {
+			for _, outer := range bindings {
+				// a scope running its own version of the command is expected
+				if strings.TrimSpace(outer.Scope) != outerScope || sameCommand(inner, outer) {
+					continue
+				}
+				for _, key := range outer.Key {
+					if !slices.Contains(inner.Key, key) {
+						continue
+					}
+					report(BindingWarning, outer, key, "%s is hidden by %s in scope %s", outer.Action, inner.Action, innerScope)
+				}
+			}
+		}
+	}
+
+	for _, sequence := range bindings {
+		// a conditional sequence leaves its first key alone when it does not apply
+		if len(sequence.Seq) < 2 || strings.TrimSpace(sequence.When) != "" {
+			continue
+		}
+		for _, single := range bindings {
+			// sequences are matched before single keys in every active scope
+			if !slices.Contains(single.Key, sequence.Seq[0]) || !activeIn(sequence.Scope, single.Scope) {
+				continue
+			}
+			report(BindingWarning, single, sequence.Seq[0], "%s is never reached because sequence %q (%s) in scope %s starts with it",
+				single.Action, strings.Join(sequence.Seq, " "), sequence.Action, strings.TrimSpace(sequence.Scope))
+		}
+	}
+	return issues
+}
+
+// EffectiveBindings drops the keys shadowed by a later binding in the same
+// scope and condition, and the bindings left without any key.
+func EffectiveBindings(bindings []BindingConfig) []BindingConfig {
+	taken := map[string]bool{}
+	var effective []BindingConfig
+	for _, binding := range slices.Backward(bindings) {
+		scope := strings.TrimSpace(binding.Scope) + "\x00" + strings.TrimSpace(binding.When)
+		if len(binding.Seq) > 0 {
+			slot := scope + "\x00" + strings.Join(binding.Seq, " ")
+			if taken[slot] {
+				continue
+			}
+			taken[slot] = true
+			effective = append(effective, binding)
+			continue
+		}
+		var keys StringList
+		for _, key := range binding.Key {
+			if slot := scope + "\x00" + key; !taken[slot] {
+				taken[slot] = true
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) > 0 {
+			binding.Key = keys
+			effective = append(effective, binding)
+		}
+	}
+	slices.Reverse(effective)
+	return effective
+}
+
+func bindingKeys(binding BindingConfig) string {
+	if len(binding.Seq) > 0 {
+		return strings.Join(binding.Seq, " ")
+	}
+	return strings.Join(binding.Key, ",")
+}
+
+func sameBinding(a, b BindingConfig) bool {
+	return strings.TrimSpace(a.Action) == strings.TrimSpace(b.Action) && maps.EqualFunc(a.Args, b.Args, func(x, y any) bool {
+		return fmt.Sprint(x) == fmt.Sprint(y)
+	})
+}
+
+// sameCommand reports whether inner runs the command of outer: both run the
+// same action, or built-in actions dispatching the same intent with the same
+// args, or inner is declared to replace outer with a hides= bind directive.
+func sameCommand(inner, outer BindingConfig) bool {
+	if sameBinding(inner, outer) || actionmeta.ActionHides(inner.Action, outer.Action) {
+		return true
+	}
+	command := actionmeta.ActionCommand(inner.Action)
+	return command != "" && command == actionmeta.ActionCommand(outer.Action) && sameBinding(
+		BindingConfig{Args: inner.Args}, BindingConfig{Args: outer.Args})
+}
+
+// outerScopes returns the scopes a key falls through to when scope does not
+// bind it. Every scope without a //jjui:layer directive falls through to ui.
+func outerScopes(scope string) []string {
+	if outer, ok := layeredScopes[scope]; ok {
+		return outer
+	}
+	return []string{"ui"}
+}
+
+// activeIn reports whether the bindings of scope are active whenever the
+// bindings of other are: it is the same scope or one of its outer scopes.
+func activeIn(scope, other string) bool {
+	scope, other = strings.TrimSpace(scope), strings.TrimSpace(other)
+	return scope == other || slices.Contains(outerScopes(other), scope)
+}
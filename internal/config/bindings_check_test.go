@@ -0,0 +1,160 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckBindings_DefaultBindingsHaveNoProblems(t *testing.T) {
+	cfg := loadDefaultConfig()
+	assert.Empty(t, CheckBindings(cfg.Bindings, cfg.Actions))
+}
+
+func TestCheckBindings_ReportsEveryProblem(t *testing.T) {
+	content := `
+[[actions]]
+name = "my_action"
+lua = "print('hi')"
+
+[[bindings]]
+action = "revisions.abandn"
+scope = "revisions"
+key = "x"
+
+[[bindings]]
+action = "revisions.rebase.set_source"
+scope = "revisions.rebase"
+key = "X"
+
+[[bindings]]
+action = "revisions.open_abandon"
+scope = "revisions"
+key = ["a", "A"]
+
+[[bindings]]
+action = "my_action"
+scope = "revisions"
+key = "a"
+
+[[bindings]]
+action = "ui.open_git"
+scope = "ui"
+seq = ["n", "g"]
+
+[[bindings]]
+action = "revisions.new"
+scope = "revisions"
+key = "n"
+
+[[bindings]]
+action = "revisions.new"
+scope = "oplog"
+key = "n"
+`
+
+	cfg := &Config{}
+	require.Error(t, cfg.Load(content, ""), "Load stops at the first problem")
+	cfg = &Config{}
+	require.NoError(t, cfg.LoadUnchecked(content, ""))
+
+	issues := CheckBindings(cfg.Bindings, cfg.Actions)
+	assert.Equal(t, []BindingIssue{
+		{Severity: BindingError, Scope: "revisions", Keys: "x", Message: `unknown action "revisions.abandn"`},
+		{Severity: BindingError, Scope: "revisions.rebase", Keys: "X", Message: `action "revisions.rebase.set_source" requires arg "source"`},
+		{Severity: BindingWarning, Scope: "revisions", Keys: "a", Message: "revisions.open_abandon is shadowed by my_action"},
+		{Severity: BindingWarning, Scope: "revisions", Keys: "n", Message: `revisions.new is never reached because sequence "n g" (ui.open_git) in scope ui starts with it`},
+		{Severity: BindingWarning, Scope: "oplog", Keys: "n", Message: `revisions.new is never reached because sequence "n g" (ui.open_git) in scope ui starts with it`},
+	}, issues)
+}
+
+func TestCheckBindings_ReportsInvalidBindings(t *testing.T) {
+	issues := CheckBindings([]BindingConfig{
+		{Action: "ui.quit", Scope: "ui", Seq: StringList{"g"}},
+	}, nil)
+
+	require.Len(t, issues, 1)
+	assert.Equal(t, BindingError, issues[0].Severity)
+	assert.Contains(t, issues[0].Message, "has seq with only one key")
+}
+
+func TestEffectiveBindings_DropsShadowedKeys(t *testing.T) {
+	bindings := []BindingConfig{
+		{Action: "revisions.open_abandon", Scope: "revisions", Key: StringList{"a", "A"}},
+		{Action: "revisions.new", Scope: "revisions", Key: StringList{"n"}},
+		{Action: "ui.open_git", Scope: "ui", Seq: StringList{"g", "g"}},
+		{Action: "my_action", Scope: "revisions", Key: StringList{"a", "n"}},
+		{Action: "ui.quit", Scope: "ui", Seq: StringList{"g", "g"}},
+	}
+
+	assert.Equal(t, []BindingConfig{
+		{Action: "revisions.open_abandon", Scope: "revisions", Key: StringList{"A"}},
+		{Action: "my_action", Scope: "revisions", Key: StringList{"a", "n"}},
+		{Action: "ui.quit", Scope: "ui", Seq: StringList{"g", "g"}},
+	}, EffectiveBindings(bindings))
+}
+
+func TestCheckBindings_ConditionalBindingsDoNotShadow(t *testing.T) {
+	bindings := []BindingConfig{
+		{Action: "revisions.new", Scope: "revisions", Key: StringList{"n"}},
+		{Action: "revisions.commit", Scope: "revisions", Key: StringList{"n"}, When: "selection.is_working_copy"},
+		{Action: "ui.open_git", Scope: "revisions", Key: StringList{"g"}},
+		{Action: "revisions.go_to_top", Scope: "revisions", Seq: StringList{"g", "g"}, When: "checked.count == 0"},
+	}
+	assert.Empty(t, CheckBindings(bindings, nil))
+	assert.Equal(t, bindings, EffectiveBindings(bindings))
+
+	issues := CheckBindings([]BindingConfig{
+		{Action: "revisions.new", Scope: "revisions", Key: StringList{"n"}, When: "checked.count >"},
+	}, nil)
+	require.Len(t, issues, 1)
+	assert.Contains(t, issues[0].Message, "condition ends unexpectedly")
+}
+
+func TestCheckBindings_ReportsKeysHiddenByAnInnerScope(t *testing.T) {
+	issues := CheckBindings([]BindingConfig{
+		{Action: "ui.quit", Scope: "ui", Key: StringList{"q"}},
+		{Action: "ui.cancel", Scope: "ui", Key: StringList{"esc"}},
+		{Action: "revisions.new", Scope: "revisions", Key: StringList{"n"}},
+		{Action: "revisions.describe", Scope: "revisions", Key: StringList{"q", "esc"}},
+		{Action: "revisions.visual_mode", Scope: "revisions.visual", Key: StringList{"n"}},
+		{Action: "revisions.rebase.apply", Scope: "revisions.rebase", Key: StringList{"n"}},
+	}, nil)
+
+	assert.Equal(t, []BindingIssue{
+		{Severity: BindingWarning, Scope: "ui", Keys: "q", Message: "ui.quit is hidden by revisions.describe in scope revisions"},
+		{Severity: BindingWarning, Scope: "ui", Keys: "esc", Message: "ui.cancel is hidden by revisions.describe in scope revisions"},
+		{Severity: BindingWarning, Scope: "revisions", Keys: "n", Message: "revisions.new is hidden by revisions.visual_mode in scope revisions.visual"},
+	}, issues)
+}
+
+func TestCheckBindings_SequencesInInnerScopesDoNotHideKeys(t *testing.T) {
+	assert.Empty(t, CheckBindings([]BindingConfig{
+		{Action: "revisions.diff", Scope: "revisions", Key: StringList{"d"}},
+		{Action: "revisions.details.diff", Scope: "revisions.details", Seq: StringList{"d", "x"}},
+	}, nil))
+
+	issues := CheckBindings([]BindingConfig{
+		{Action: "revisions.diff", Scope: "revisions", Key: StringList{"d"}},
+		{Action: "revisions.diff", Scope: "revisions.visual", Seq: StringList{"d", "x"}},
+		{Action: "revisions.details.diff", Scope: "revisions.details", Key: StringList{"d"}},
+		{Action: "revisions.diff", Scope: "revisions", Seq: StringList{"d", "d"}},
+	}, nil)
+	assert.Equal(t, []BindingIssue{
+		{Severity: BindingWarning, Scope: "revisions", Keys: "d", Message: `revisions.diff is never reached because sequence "d d" (revisions.diff) in scope revisions starts with it`},
+	}, issues)
+}
+
+func TestCheckBindings_ComparesCommandsInsteadOfActionNames(t *testing.T) {
+	issues := CheckBindings([]BindingConfig{
+		{Action: "ui.cancel", Scope: "ui", Key: StringList{"esc"}},
+		{Action: "revisions.cancel", Scope: "revisions", Key: StringList{"esc"}},
+		{Action: "revisions.quick_search.clear", Scope: "revisions.quick_search", Key: StringList{"esc"}},
+		{Action: "help.close", Scope: "help", Key: StringList{"esc"}},
+	}, nil)
+
+	assert.Equal(t, []BindingIssue{
+		{Severity: BindingWarning, Scope: "ui", Keys: "esc", Message: "ui.cancel is hidden by help.close in scope help"},
+	}, issues)
+}
@@ -43,12 +43,13 @@ type ActionConfig struct {
 }
 
 type BindingConfig struct {
-	Action string         `toml:"action"`
-	Desc   string         `toml:"desc"`
-	Key    StringList     `toml:"key"`
-	Seq    StringList     `toml:"seq"`
-	Scope  string         `toml:"scope"`
-	Args   map[string]any `toml:"args"`
+	Action string         `toml:"action" json:"action"`
+	Desc   string         `toml:"desc,omitempty" json:"desc,omitempty"`
+	Key    StringList     `toml:"key,omitempty" json:"key,omitempty"`
+	Seq    StringList     `toml:"seq,omitempty" json:"seq,omitempty"`
+	Scope  string         `toml:"scope" json:"scope"`
+	Args   map[string]any `toml:"args,omitempty" json:"args,omitempty"`
+	When   string         `toml:"when,omitempty" json:"when,omitempty"`
 }
 
 func (c *Config) ValidateBindingsAndActions() error {
@@ -98,6 +99,7 @@ func BindingsToRuntime(bindings []BindingConfig) []keybindings.Binding {
 			Key:    append([]string(nil), binding.Key...),
 			Seq:    append([]string(nil), binding.Seq...),
 			Args:   keybindings.CloneArgs(binding.Args),
+			When:   strings.TrimSpace(binding.When),
 		})
 	}
 	return out
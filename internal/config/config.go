@@ -11,7 +11,7 @@ import (
 	"time"
 )
 
-//go:embed default/*.toml default/types.lua
+//go:embed default/*.toml default/profiles/*.toml default/types.lua
 var configFS embed.FS
 
 var Current = loadDefaultConfig()
@@ -23,10 +23,12 @@ type Config struct {
 	UI              UIConfig        `toml:"ui"`
 	Suggest         SuggestConfig   `toml:"suggest"`
 	Revisions       RevisionsConfig `toml:"revisions"`
+	Describe        DescribeConfig  `toml:"describe"`
 	Preview         PreviewConfig   `toml:"preview"`
 	OpLog           OpLogConfig     `toml:"oplog"`
 	Limit           int             `toml:"limit"`
 	Git             GitConfig       `toml:"git"`
+	Revset          RevsetConfig    `toml:"revset"`
 	Ssh             SshConfig       `toml:"ssh"`
 }
 
@@ -94,6 +96,9 @@ type ThemeVariant struct {
 type ResolvedTheme struct {
 	Colors          map[string]Color
 	BackgroundBlend float64
+	// Warnings lists the colour keys of the theme and [ui.colors] that no
+	// renderer looks up.
+	Warnings []string
 }
 
 type BackgroundBlendConfig struct {
@@ -188,9 +193,43 @@ type UIConfig struct {
 	SetWindowTitle  bool                  `toml:"set_window_title"`
 	// TODO(ilyagr): It might make sense to rename this to `auto_refresh_period` to match `--period` option
 	// once we have a mechanism to deprecate the old name softly.
-	AutoRefreshInterval        int  `toml:"auto_refresh_interval"`
-	FlashMessageDisplaySeconds int  `toml:"flash_message_display_seconds"`
-	MouseSupport               bool `toml:"mouse_support"`
+	AutoRefreshInterval        int              `toml:"auto_refresh_interval"`
+	FlashMessageDisplaySeconds int              `toml:"flash_message_display_seconds"`
+	MouseSupport               bool             `toml:"mouse_support"`
+	WhichKey                   WhichKeyConfig   `toml:"which_key"`
+	Statusline                 StatuslineConfig `toml:"statusline"`
+}
+
+type WhichKeyConfig struct {
+	// Enabled shows a popup listing the keys that continue a pending sequence.
+	Enabled bool `toml:"enabled"`
+	// DelayMilliseconds is how long a sequence is pending before the popup shows.
+	DelayMilliseconds int `toml:"delay_milliseconds"`
+}
+
+type StatuslineConfig struct {
+	Segments []StatuslineSegment `toml:"segments"`
+}
+
+const (
+	SegmentRevset            = "revset"
+	SegmentOpId              = "op_id"
+	SegmentWorkingCopy       = "working_copy"
+	SegmentConflicts         = "conflicts"
+	SegmentChecked           = "checked"
+	SegmentUnpushedBookmarks = "unpushed_bookmarks"
+	SegmentSpinner           = "spinner"
+)
+
+type StatuslineSegment struct {
+	// Name is one of the built-in segments, or any name for a Lua segment.
+	Name string `toml:"name"`
+	// Align places the segment at the "left" (after the mode) or "right" of the status bar.
+	Align string `toml:"align"`
+	// Priority decides which segments stay when the status bar is too narrow; lower priorities are dropped first.
+	Priority int `toml:"priority"`
+	// Lua is an expression whose string value is the segment text.
+	Lua string `toml:"lua"`
 }
 
 func GetExpiringFlashMessageTimeout(c *Config) time.Duration {
@@ -204,6 +243,32 @@ type RevisionsConfig struct {
 	Revset       string `toml:"revset"`
 }
 
+type RevsetConfig struct {
+	// LiveGraph re-renders the graph for the revset being typed; cancelling restores the previous revset.
+	LiveGraph bool `toml:"live_graph"`
+}
+
+type DescribeConfig struct {
+	// Template is the initial message used when a revision has no description.
+	Template             string          `toml:"template"`
+	Trailers             []TrailerConfig `toml:"trailers"`
+	ConventionalPrefixes []string        `toml:"conventional_prefixes"`
+	// SubjectWidth and BodyWidth drive the column guide; 0 disables the guide for that part.
+	SubjectWidth int `toml:"subject_width"`
+	BodyWidth    int `toml:"body_width"`
+}
+
+// TrailerConfig describes a trailer line such as `Signed-off-by: $user`.
+// The value may reference $user, $change_id and $commit_id.
+type TrailerConfig struct {
+	Key   string `toml:"key"`
+	Value string `toml:"value"`
+	// Lua is an expression whose result replaces Value, set by config.trailer.
+	Lua string `toml:"lua"`
+	// Auto inserts the trailer whenever inline describe is opened.
+	Auto bool `toml:"auto"`
+}
+
 type PreviewPosition int
 
 const (
@@ -325,6 +390,7 @@ func GetSuggestExecMode(c *Config) (SuggestMode, error) {
 
 type GitConfig struct {
 	DefaultRemote string `toml:"default_remote"`
+	FixBeforePush bool   `toml:"fix_before_push"`
 }
 
 func GetGitDefaultRemote(c *Config) string {
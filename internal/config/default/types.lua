@@ -78,6 +78,7 @@ function wait_close() end
 function wait_refresh() end
 
 ---@class jjui.bookmarks
+---@field ace_jump fun()
 ---@field apply fun()
 ---@field bookmark_delete fun()
 ---@field bookmark_forget fun()
@@ -109,7 +110,17 @@ function wait_refresh() end
 ---@field move_down fun()
 ---@field move_up fun()
 
+---@class jjui.content_search
+---@field apply fun()
+---@field cancel fun()
+---@field move_down fun()
+---@field move_up fun()
+---@field next_field fun()
+---@field prev_field fun()
+---@field close fun()
+
 ---@class jjui.diff
+---@field ace_jump fun()
 ---@field half_page_down fun()
 ---@field half_page_up fun()
 ---@field left fun()
@@ -140,6 +151,7 @@ function wait_refresh() end
 ---@field close fun()
 
 ---@class jjui.git
+---@field ace_jump fun()
 ---@field apply fun()
 ---@field cancel fun()
 ---@field cycle_remotes fun()
@@ -171,8 +183,22 @@ function wait_refresh() end
 ---@field cancel fun()
 ---@field close fun()
 
+---@class jjui.jump_labels
+---@field apply fun()
+---@field cancel fun()
+---@field close fun()
+
+---@class jjui.notifications
+---@field close fun()
+---@field copy fun()
+---@field cycle_severity fun()
+---@field move_down fun()
+---@field move_up fun()
+---@field rerun fun()
+
 ---@class jjui.oplog
 ---@field quick_search jjui.oplog.quick_search
+---@field ace_jump fun()
 ---@field close fun()
 ---@field diff fun()
 ---@field move_down fun()
@@ -209,14 +235,18 @@ function wait_refresh() end
 ---@field duplicate jjui.revisions.duplicate
 ---@field evolog jjui.revisions.evolog
 ---@field inline_describe jjui.revisions.inline_describe
+---@field metaedit jjui.revisions.metaedit
 ---@field new_between jjui.revisions.new_between
+---@field parallelize jjui.revisions.parallelize
 ---@field quick_search jjui.revisions.quick_search
 ---@field rebase jjui.revisions.rebase
 ---@field revert jjui.revisions.revert
 ---@field set_bookmark jjui.revisions.set_bookmark
 ---@field set_parents jjui.revisions.set_parents
+---@field simplify_parents jjui.revisions.simplify_parents
 ---@field squash jjui.revisions.squash
 ---@field target_picker jjui.revisions.target_picker
+---@field visual jjui.revisions.visual
 ---@field ace_jump fun()
 ---@field apply fun(args: {force?: boolean})
 ---@field cancel fun()
@@ -225,8 +255,10 @@ function wait_refresh() end
 ---@field diff fun()
 ---@field diff_edit fun()
 ---@field edit fun()
+---@field fix fun()
 ---@field force_apply fun()
 ---@field force_edit fun()
+---@field force_fix fun()
 ---@field go_to_bottom fun()
 ---@field go_to_top fun()
 ---@field jump_to_children fun()
@@ -234,7 +266,11 @@ function wait_refresh() end
 ---@field jump_to_working_copy fun()
 ---@field move_down fun()
 ---@field move_up fun()
+---@field narrow_to_selection fun()
 ---@field new fun()
+---@field next fun(args: {count?: integer})
+---@field next_conflict fun()
+---@field next_edit fun(args: {count?: integer})
 ---@field open_abandon fun()
 ---@field open_absorb fun()
 ---@field open_details fun()
@@ -242,18 +278,27 @@ function wait_refresh() end
 ---@field open_duplicate fun()
 ---@field open_evolog fun()
 ---@field open_inline_describe fun()
+---@field open_metaedit fun()
 ---@field open_new_between fun()
+---@field open_parallelize fun()
 ---@field open_rebase fun()
 ---@field open_revert fun()
 ---@field open_set_bookmark fun(args: {value?: string})
 ---@field open_set_parents fun()
+---@field open_simplify_parents fun()
 ---@field open_squash fun()
 ---@field page_down fun()
 ---@field page_up fun()
+---@field prev fun(args: {count?: integer})
+---@field prev_conflict fun()
+---@field prev_edit fun(args: {count?: integer})
 ---@field refresh fun()
+---@field repeat fun()
+---@field select_revset fun(args: {revset?: string})
 ---@field split fun()
 ---@field split_parallel fun()
 ---@field toggle_select fun()
+---@field visual_mode fun()
 ---@field close fun()
 
 ---@class jjui.revisions.abandon
@@ -282,8 +327,11 @@ function wait_refresh() end
 
 ---@class jjui.revisions.details
 ---@field confirmation jjui.revisions.details.confirmation
+---@field visual jjui.revisions.details.visual
 ---@field absorb fun()
+---@field ace_jump fun()
 ---@field cancel fun()
+---@field cycle_sort fun()
 ---@field diff fun()
 ---@field filter fun()
 ---@field filter_apply fun()
@@ -300,7 +348,10 @@ function wait_refresh() end
 ---@field split fun()
 ---@field split_parallel fun()
 ---@field squash fun()
+---@field toggle_collapsed fun()
 ---@field toggle_select fun()
+---@field toggle_tree fun()
+---@field visual_mode fun()
 ---@field close fun()
 
 ---@class jjui.revisions.details.confirmation
@@ -311,6 +362,11 @@ function wait_refresh() end
 ---@field prev fun()
 ---@field close fun()
 
+---@class jjui.revisions.details.visual
+---@field cancel fun()
+---@field visual_mode fun()
+---@field close fun()
+
 ---@class jjui.revisions.diff_range
 ---@field apply fun()
 ---@field cancel fun()
@@ -342,10 +398,21 @@ function wait_refresh() end
 
 ---@class jjui.revisions.inline_describe
 ---@field accept fun(args: {force?: boolean})
+---@field add_trailers fun()
 ---@field cancel fun()
 ---@field editor fun()
 ---@field force_accept fun()
 ---@field new_line fun()
+---@field prefix_picker fun()
+---@field close fun()
+
+---@class jjui.revisions.metaedit
+---@field apply fun(args: {force?: boolean})
+---@field cancel fun()
+---@field force_apply fun()
+---@field next_field fun()
+---@field prev_field fun()
+---@field reset_author fun(args: {force?: boolean})
 ---@field close fun()
 
 ---@class jjui.revisions.new_between
@@ -354,6 +421,15 @@ function wait_refresh() end
 ---@field toggle_insert_before fun()
 ---@field close fun()
 
+---@class jjui.revisions.parallelize
+---@field ace_jump fun()
+---@field apply fun(args: {force?: boolean})
+---@field cancel fun()
+---@field force_apply fun()
+---@field jump_to_working_copy fun()
+---@field toggle_select fun()
+---@field close fun()
+
 ---@class jjui.revisions.quick_search
 ---@field input jjui.revisions.quick_search.input
 ---@field clear fun()
@@ -400,6 +476,16 @@ function wait_refresh() end
 ---@field toggle_select fun()
 ---@field close fun()
 
+---@class jjui.revisions.simplify_parents
+---@field ace_jump fun()
+---@field apply fun(args: {force?: boolean})
+---@field cancel fun()
+---@field force_apply fun()
+---@field jump_to_working_copy fun()
+---@field toggle_descendants fun()
+---@field toggle_select fun()
+---@field close fun()
+
 ---@class jjui.revisions.squash
 ---@field ace_jump fun()
 ---@field apply fun(args: {force?: boolean})
@@ -422,12 +508,23 @@ function wait_refresh() end
 ---@field move_up fun()
 ---@field close fun()
 
+---@class jjui.revisions.visual
+---@field cancel fun()
+---@field visual_mode fun()
+---@field close fun()
+
 ---@class jjui.revset
 ---@field apply fun()
 ---@field autocomplete fun()
 ---@field autocomplete_back fun()
 ---@field cancel fun()
 ---@field edit fun(args: {clear?: boolean})
+---@field insert_ancestors fun()
+---@field insert_descendants fun()
+---@field insert_heads fun()
+---@field insert_range fun()
+---@field insert_roots fun()
+---@field insert_selection fun()
 ---@field move_down fun()
 ---@field move_up fun()
 ---@field reset fun()
@@ -447,21 +544,45 @@ function wait_refresh() end
 ---@field page_up fun()
 ---@field close fun()
 
+---@class jjui.theme_browser
+---@field apply fun()
+---@field cancel fun()
+---@field edit fun()
+---@field move_down fun()
+---@field move_up fun()
+---@field close fun()
+
+---@class jjui.theme_editor
+---@field apply fun()
+---@field cancel fun()
+---@field edit_bg fun()
+---@field edit_fg fun()
+---@field move_down fun()
+---@field move_up fun()
+---@field save fun()
+---@field toggle_bold fun()
+---@field close fun()
+
 ---@class jjui.ui
 ---@field preview jjui.ui.preview
 ---@field cancel fun()
 ---@field change_theme fun(value?: string|{name: string})
+---@field command_palette fun()
 ---@field exec_jj fun()
 ---@field exec_shell fun()
 ---@field expand_status fun()
 ---@field file_search_toggle fun()
 ---@field open_bookmarks fun()
 ---@field open_command_history fun()
+---@field open_content_search fun()
 ---@field open_git fun()
 ---@field open_help fun()
+---@field open_notifications fun()
 ---@field open_oplog fun()
 ---@field open_redo fun()
 ---@field open_revset fun()
+---@field open_theme_browser fun()
+---@field open_theme_editor fun()
 ---@field open_undo fun()
 ---@field preview_expand fun()
 ---@field preview_half_page_down fun()
@@ -473,6 +594,7 @@ function wait_refresh() end
 ---@field preview_toggle_bottom fun()
 ---@field quick_search fun()
 ---@field quit fun()
+---@field revision_finder fun()
 ---@field suspend fun()
 ---@field close fun()
 
@@ -493,15 +615,20 @@ function wait_refresh() end
 ---@field bookmarks jjui.bookmarks
 ---@field choose jjui.choose
 ---@field command_history jjui.command_history
+---@field content_search jjui.content_search
 ---@field diff jjui.diff
 ---@field file_search jjui.file_search
 ---@field git jjui.git
 ---@field help jjui.help
 ---@field input jjui.input
+---@field jump_labels jjui.jump_labels
+---@field notifications jjui.notifications
 ---@field oplog jjui.oplog
 ---@field password jjui.password
 ---@field redo jjui.redo
 ---@field status jjui.status
+---@field theme_browser jjui.theme_browser
+---@field theme_editor jjui.theme_editor
 ---@field ui jjui.ui
 ---@field undo jjui.undo
 ---@field builtin jjui.builtin
@@ -522,17 +649,22 @@ function wait_refresh() end
 ---@field bookmarks jjui.bookmarks
 ---@field choose jjui.choose
 ---@field command_history jjui.command_history
+---@field content_search jjui.content_search
 ---@field diff jjui.diff
 ---@field file_search jjui.file_search
 ---@field git jjui.git
 ---@field help jjui.help
 ---@field input jjui.input
+---@field jump_labels jjui.jump_labels
+---@field notifications jjui.notifications
 ---@field oplog jjui.oplog
 ---@field password jjui.password
 ---@field redo jjui.redo
 ---@field revisions jjui.revisions
 ---@field revset jjui.revset
 ---@field status jjui.status
+---@field theme_browser jjui.theme_browser
+---@field theme_editor jjui.theme_editor
 ---@field ui jjui.ui
 ---@field undo jjui.undo
 
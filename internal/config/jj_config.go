@@ -9,10 +9,15 @@ type JJConfig struct {
 	RevsetAliases map[string]string `toml:"revset-aliases"`
 	Revsets       struct {
 		Log string `toml:"log"`
+		Fix string `toml:"fix"`
 	} `toml:"revsets"`
 	Templates struct {
 		Log string `toml:"log"`
 	} `toml:"templates"`
+	User struct {
+		Name  string `toml:"name"`
+		Email string `toml:"email"`
+	} `toml:"user"`
 }
 
 func (c *JJConfig) GetApplicableColors() map[string]Color {
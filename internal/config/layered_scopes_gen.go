@@ -0,0 +1,30 @@
+// Code generated by cmd/genactions; DO NOT EDIT.
+package config
+
+// layeredScopes lists the scopes that stay active under a scope layered on
+// top of them, nearest first, as declared by the //jjui:layer directives.
+var layeredScopes = map[string][]string{
+	"bookmarks.filter":               nil,
+	"choose.filter":                  nil,
+	"content_search":                 nil,
+	"file_search":                    nil,
+	"git.filter":                     nil,
+	"help.filter":                    nil,
+	"input":                          nil,
+	"jump_labels":                    nil,
+	"oplog.quick_search":             nil,
+	"password":                       nil,
+	"redo":                           nil,
+	"revisions.ace_jump":             nil,
+	"revisions.details.confirmation": nil,
+	"revisions.details.filter":       {"revisions.details", "ui"},
+	"revisions.details.visual":       {"revisions.details", "ui"},
+	"revisions.quick_search":         {"revisions", "ui"},
+	"revisions.quick_search.input":   nil,
+	"revisions.visual":               {"revisions", "ui"},
+	"revset":                         nil,
+	"status.input":                   nil,
+	"theme_editor.input":             nil,
+	"ui":                             nil,
+	"undo":                           nil,
+}
@@ -11,11 +11,17 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/BurntSushi/toml"
 )
 
+// noneAction unbinds the keys of a binding set earlier in the same scope.
+const noneAction = "none"
+
 type mergeOverlay struct {
 	Actions  []ActionConfig  `toml:"actions"`
 	Bindings []BindingConfig `toml:"bindings"`
@@ -103,6 +109,20 @@ func loadDefaultConfig() *Config {
 // Load loads config data where relative bindings_profile paths are resolved
 // against baseDir.
 func (c *Config) Load(data, baseDir string) error {
+	if err := c.merge(data, baseDir); err != nil {
+		return err
+	}
+	return c.ValidateBindingsAndActions()
+}
+
+// LoadUnchecked loads config data like Load but skips validating the bindings
+// and actions, so that CheckBindings can report every problem instead of the
+// first one.
+func (c *Config) LoadUnchecked(data, baseDir string) error {
+	return c.merge(data, baseDir)
+}
+
+func (c *Config) merge(data, baseDir string) error {
 	baseActions := append([]ActionConfig(nil), c.Actions...)
 	baseBindings := append([]BindingConfig(nil), c.Bindings...)
 
@@ -175,24 +195,109 @@ func (c *Config) Load(data, baseDir string) error {
 		overlayBindings := append(actionBindings, overlay.Bindings...)
 		c.Bindings = append(baseBindings, overlayBindings...)
 	}
+	c.Bindings = removeBindings(c.Bindings)
+	return nil
+}
 
-	return c.ValidateBindingsAndActions()
+// profileOverlay is a bindings profile. extends names the profile whose
+// bindings come before its own.
+type profileOverlay struct {
+	Extends  string          `toml:"extends"`
+	Bindings []BindingConfig `toml:"bindings"`
 }
 
+// loadProfileBindings loads a bindings profile and the profiles it extends.
+// Names starting with a colon are embedded profiles; other names are paths
+// resolved against baseDir.
 func loadProfileBindings(profile, baseDir string) ([]BindingConfig, error) {
+	return loadExtendedProfile(profile, baseDir, nil)
+}
+
+func loadExtendedProfile(profile, baseDir string, extending []string) ([]BindingConfig, error) {
+	data, source, err := readProfile(profile, baseDir)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(extending, source) {
+		return nil, fmt.Errorf("bindings profile %q extends itself", profile)
+	}
+	var overlay profileOverlay
+	if _, err := toml.Decode(string(data), &overlay); err != nil {
+		return nil, fmt.Errorf("parsing bindings profile %q: %w", profile, err)
+	}
+	if overlay.Extends == "" {
+		return overlay.Bindings, nil
+	}
+	base, err := loadExtendedProfile(overlay.Extends, filepath.Dir(source), append(extending, source))
+	if err != nil {
+		return nil, err
+	}
+	return append(base, overlay.Bindings...), nil
+}
+
+// readProfile returns the content of a profile and where it was read from:
+// its name when embedded, its path otherwise.
+func readProfile(profile, baseDir string) ([]byte, string, error) {
+	if name, ok := strings.CutPrefix(profile, ":"); ok {
+		embeddedPath := "default/profiles/" + name + ".toml"
+		if name == "builtin" {
+			embeddedPath = "default/bindings.toml"
+		}
+		data, err := configFS.ReadFile(embeddedPath)
+		if err != nil {
+			return nil, "", fmt.Errorf("unknown bindings profile %q", profile)
+		}
+		return data, profile, nil
+	}
 	profilePath := profile
 	if !filepath.IsAbs(profilePath) {
 		profilePath = filepath.Join(baseDir, profilePath)
 	}
 	data, err := os.ReadFile(profilePath)
 	if err != nil {
-		return nil, fmt.Errorf("loading bindings profile %q: %w", profile, err)
+		return nil, "", fmt.Errorf("loading bindings profile %q: %w", profile, err)
 	}
-	var overlay mergeOverlay
-	if _, err := toml.Decode(string(data), &overlay); err != nil {
-		return nil, fmt.Errorf("parsing bindings profile %q: %w", profile, err)
+	return data, profilePath, nil
+}
+
+// removeBindings applies the bindings whose action is none: each one unbinds
+// its keys, or its sequence, from the bindings before it in the same scope
+// and is dropped itself.
+func removeBindings(bindings []BindingConfig) []BindingConfig {
+	result := make([]BindingConfig, 0, len(bindings))
+	for _, binding := range bindings {
+		if strings.TrimSpace(binding.Action) != noneAction {
+			result = append(result, binding)
+			continue
+		}
+		scope := strings.TrimSpace(binding.Scope)
+		kept := result[:0]
+		for _, existing := range result {
+			if strings.TrimSpace(existing.Scope) != scope {
+				kept = append(kept, existing)
+				continue
+			}
+			if len(binding.Seq) > 0 {
+				if !slices.Equal(existing.Seq, binding.Seq) {
+					kept = append(kept, existing)
+				}
+				continue
+			}
+			if len(existing.Key) == 0 {
+				kept = append(kept, existing)
+				continue
+			}
+			keys := slices.DeleteFunc(slices.Clone(existing.Key), func(key string) bool {
+				return slices.Contains(binding.Key, key)
+			})
+			if len(keys) > 0 {
+				existing.Key = keys
+				kept = append(kept, existing)
+			}
+		}
+		result = kept
 	}
-	return overlay.Bindings, nil
+	return result
 }
 
 func LoadLuaConfigFile() (string, error) {
@@ -335,17 +440,107 @@ func LoadEmbeddedTheme(name string, isDark bool) (ResolvedTheme, error) {
 	return loadTheme(data, isDark)
 }
 
+// LoadTheme loads a theme from the themes directory next to the config file,
+// falling back to the embedded theme of the same name.
 func LoadTheme(name string, isDark bool) (ResolvedTheme, error) {
-	configFilePath := getConfigFilePath()
-	themeFile := filepath.Join(filepath.Dir(configFilePath), "themes", name+".toml")
-
-	data, err := os.ReadFile(themeFile)
+	data, err := os.ReadFile(themeFilePath(name))
+	if errors.Is(err, fs.ErrNotExist) && slices.Contains(embeddedThemes, name) {
+		return LoadEmbeddedTheme(name, isDark)
+	}
 	if err != nil {
 		return ResolvedTheme{}, err
 	}
 	return loadTheme(data, isDark)
 }
 
+var embeddedThemes = []string{"default"}
+
+func themeFilePath(name string) string {
+	return filepath.Join(filepath.Dir(getConfigFilePath()), "themes", name+".toml")
+}
+
+// ListThemes returns the sorted names of the embedded themes and the themes in
+// the themes directory.
+func ListThemes() ([]string, error) {
+	names := slices.Clone(embeddedThemes)
+	entries, err := os.ReadDir(filepath.Dir(themeFilePath("")))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".toml"); ok && !entry.IsDir() && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names, nil
+}
+
+// SaveTheme writes colors as the [colors] table of the named theme in the
+// themes directory and returns the path of the written file.
+func SaveTheme(name string, colors map[string]Color) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid theme name %q", name)
+	}
+	var b strings.Builder
+	b.WriteString("[colors]\n")
+	for _, key := range slices.Sorted(maps.Keys(colors)) {
+		if value := formatColor(colors[key]); value != "" {
+			fmt.Fprintf(&b, "%s = %s\n", formatKey(key), value)
+		}
+	}
+	path := themeFilePath(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+func formatKey(key string) string {
+	for _, r := range key {
+		if !(r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return strconv.Quote(key)
+		}
+	}
+	return key
+}
+
+// formatColor writes a colour the way the embedded themes do: a plain string
+// when only the foreground is set, an inline table otherwise.
+func formatColor(color Color) string {
+	var fields []string
+	if color.Fg != "" {
+		fields = append(fields, "fg = "+strconv.Quote(color.Fg))
+	}
+	if color.Bg != "" {
+		fields = append(fields, "bg = "+strconv.Quote(color.Bg))
+	}
+	for _, attr := range []struct {
+		name  string
+		value *bool
+	}{
+		{"bold", color.Bold},
+		{"italic", color.Italic},
+		{"underline", color.Underline},
+		{"strikethrough", color.Strikethrough},
+		{"reverse", color.Reverse},
+	} {
+		if attr.value != nil {
+			fields = append(fields, fmt.Sprintf("%s = %t", attr.name, *attr.value))
+		}
+	}
+	switch {
+	case len(fields) == 0:
+		return ""
+	case len(fields) == 1 && color.Fg != "":
+		return strconv.Quote(color.Fg)
+	}
+	return "{ " + strings.Join(fields, ", ") + " }"
+}
+
 type LuaTypesInstallResult struct {
 	TypesPath    string
 	LuaRCPath    string
@@ -428,6 +623,12 @@ func ResolveTheme(isDark bool, jjColors map[string]Color) (ResolvedTheme, error)
 		if err != nil {
 			return ResolvedTheme{}, fmt.Errorf("loading user theme %q: %w", userThemeName, err)
 		}
+		for _, key := range UnknownColorKeys(theme.Colors) {
+			theme.Warnings = append(theme.Warnings, fmt.Sprintf("theme %q: unknown color key %q", userThemeName, key))
+		}
+	}
+	for _, key := range UnknownColorKeys(Current.UI.Colors) {
+		theme.Warnings = append(theme.Warnings, fmt.Sprintf("[ui.colors]: unknown color key %q", key))
 	}
 
 	// Layer jj VCS colors
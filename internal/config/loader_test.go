@@ -391,6 +391,90 @@ key = "k"
 	})
 }
 
+func TestLoad_EmbeddedBindingsProfilesExtendBuiltin(t *testing.T) {
+	for _, profile := range []string{":vim", ":emacs", ":helix"} {
+		t.Run(profile, func(t *testing.T) {
+			cfg := loadDefaultConfig()
+			err := cfg.Load(`bindings_profile = "`+profile+`"`, t.TempDir())
+			require.NoError(t, err)
+
+			assert.Contains(t, cfg.Bindings, BindingConfig{Key: StringList{"q"}, Action: "ui.quit", Scope: "ui", Desc: "quit"})
+			assert.Greater(t, len(cfg.Bindings), len(loadDefaultConfig().Bindings))
+			assert.Empty(t, CheckBindings(cfg.Bindings, cfg.Actions))
+		})
+	}
+}
+
+func TestLoad_BindingsProfileExtendsProfile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "base.toml"), []byte(`
+[[bindings]]
+scope = "revisions"
+action = "revisions.move_up"
+key = "k"
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "mine.toml"), []byte(`
+extends = "base.toml"
+
+[[bindings]]
+scope = "revisions"
+action = "revisions.move_down"
+key = "j"
+`), 0600))
+
+	cfg := &Config{}
+	err := cfg.Load(`bindings_profile = "mine.toml"`, dir)
+	require.NoError(t, err)
+
+	assert.Equal(t, []BindingConfig{
+		{Scope: "revisions", Action: "revisions.move_up", Key: StringList{"k"}},
+		{Scope: "revisions", Action: "revisions.move_down", Key: StringList{"j"}},
+	}, cfg.Bindings)
+}
+
+func TestLoad_BindingsProfileExtendsCycle(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.toml"), []byte(`extends = "b.toml"`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.toml"), []byte(`extends = "a.toml"`), 0600))
+
+	cfg := &Config{}
+	err := cfg.Load(`bindings_profile = "a.toml"`, dir)
+	assert.ErrorContains(t, err, "extends itself")
+}
+
+func TestLoad_UnknownEmbeddedBindingsProfile(t *testing.T) {
+	cfg := &Config{}
+	err := cfg.Load(`bindings_profile = ":nano"`, t.TempDir())
+	assert.ErrorContains(t, err, `unknown bindings profile ":nano"`)
+}
+
+func TestLoad_NoneActionRemovesBindings(t *testing.T) {
+	cfg := &Config{
+		Bindings: []BindingConfig{
+			{Scope: "revisions", Action: "revisions.move_up", Key: StringList{"up", "k"}},
+			{Scope: "revisions", Action: "ui.open_git", Seq: StringList{"g", "g"}},
+			{Scope: "oplog", Action: "oplog.move_up", Key: StringList{"k"}},
+		},
+	}
+	err := cfg.Load(`
+[[bindings]]
+scope = "revisions"
+action = "none"
+key = "k"
+
+[[bindings]]
+scope = "revisions"
+action = "none"
+seq = ["g", "g"]
+`, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []BindingConfig{
+		{Scope: "revisions", Action: "revisions.move_up", Key: StringList{"up"}},
+		{Scope: "oplog", Action: "oplog.move_up", Key: StringList{"k"}},
+	}, cfg.Bindings)
+}
+
 func TestEnvConfigDir_InvalidFallsBackToStandardConfig(t *testing.T) {
 	home := t.TempDir()
 	configHome := t.TempDir()
@@ -518,3 +602,56 @@ func TestSetupLuaTypes_PreservesExistingLuaRC(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, string(embeddedTypes), string(typesData))
 }
+
+func TestSaveThemeRoundTripsThroughLoadTheme(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("JJUI_CONFIG_DIR", configDir)
+
+	colors := map[string]Color{
+		"title":                      {Fg: "magenta"},
+		"revisions details:selected": {Bg: "bright black", Bold: boolPtr(true)},
+		"help title":                 {Fg: "green", Bold: boolPtr(false)},
+	}
+	path, err := SaveTheme("custom", colors)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(configDir, "themes", "custom.toml"), path)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, `[colors]
+"help title" = { fg = "green", bold = false }
+"revisions details:selected" = { bg = "bright black", bold = true }
+title = "magenta"
+`, string(data))
+
+	theme, err := LoadTheme("custom", true)
+	require.NoError(t, err)
+	assert.EqualExportedValues(t, colors, theme.Colors)
+}
+
+func TestSaveThemeRejectsPaths(t *testing.T) {
+	t.Setenv("JJUI_CONFIG_DIR", t.TempDir())
+	_, err := SaveTheme("../custom", nil)
+	assert.ErrorContains(t, err, "invalid theme name")
+}
+
+func TestListThemesIncludesEmbeddedAndUserThemes(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("JJUI_CONFIG_DIR", configDir)
+	require.NoError(t, os.MkdirAll(filepath.Join(configDir, "themes"), 0o755))
+	for _, name := range []string{"solarized.toml", "default.toml", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(configDir, "themes", name), nil, 0o600))
+	}
+
+	names, err := ListThemes()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "solarized"}, names)
+}
+
+func TestLoadThemeFallsBackToEmbeddedTheme(t *testing.T) {
+	t.Setenv("JJUI_CONFIG_DIR", t.TempDir())
+
+	theme, err := LoadTheme("default", true)
+	require.NoError(t, err)
+	assert.Contains(t, theme.Colors, "dimmed")
+}
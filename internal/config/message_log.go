@@ -0,0 +1,249 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MessageLogLimit is the number of entries kept in the message log.
+const MessageLogLimit = 1000
+
+type Severity string
+
+const (
+	SeverityInfo  Severity = "info"
+	SeverityError Severity = "error"
+)
+
+// Severities lists the severities from the least to the most severe.
+var Severities = []Severity{SeverityInfo, SeverityError}
+
+type MessageLogEntry struct {
+	Time     time.Time `json:"time"`
+	Severity Severity  `json:"severity"`
+	// Command is the command line shown for the message, and Args are the jj
+	// arguments to run it again.
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	// Location is the repository the command ran in.
+	Location string `json:"location,omitempty"`
+	// Stdin marks a command that was fed input, such as a description, which
+	// the log does not keep, so it can't be run again.
+	Stdin bool   `json:"stdin,omitempty"`
+	Text  string `json:"text,omitempty"`
+}
+
+// MessageLog keeps every flash message and command output across sessions in
+// a JSON lines file. Entries are available as soon as they are added and are
+// appended to the file in the background. Once the file holds twice
+// MessageLogLimit lines it is compacted to the last MessageLogLimit of them.
+// Other instances of jjui may share the file, so the writes happen under a
+// lock on a separate lock file.
+type MessageLog struct {
+	path      string
+	mu        sync.Mutex
+	entries   []MessageLogEntry
+	pending   []MessageLogEntry // entries waiting to be written
+	closed    bool
+	wake      chan struct{}
+	loaded    chan struct{}
+	done      chan struct{}
+	fileLines int
+}
+
+// NewMessageLog returns a log stored at path, or kept in memory when path is
+// empty. The file is read in the background.
+func NewMessageLog(path string) *MessageLog {
+	l := &MessageLog{
+		path:   path,
+		wake:   make(chan struct{}, 1),
+		loaded: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	if path == "" {
+		l.closed = true
+		close(l.loaded)
+		close(l.done)
+		return l
+	}
+	go l.run()
+	return l
+}
+
+// DefaultMessageLogPath is messages.jsonl in the config directory.
+func DefaultMessageLogPath() string {
+	configDir := GetConfigDir()
+	if configDir == "" {
+		return ""
+	}
+	return filepath.Join(configDir, "messages.jsonl")
+}
+
+// Entries returns the logged entries, oldest first.
+func (l *MessageLog) Entries() []MessageLogEntry {
+	<-l.loaded
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.entries)
+}
+
+// Append adds entry to the log and queues it to be written to the file.
+func (l *MessageLog) Append(entry MessageLogEntry) {
+	l.mu.Lock()
+	l.entries = append(l.entries, entry)
+	l.trim()
+	if !l.closed {
+		l.pending = append(l.pending, entry)
+	}
+	l.mu.Unlock()
+	l.signal()
+}
+
+// Close writes the pending entries and stops the background writer.
+func (l *MessageLog) Close() {
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+	l.signal()
+	<-l.done
+}
+
+func (l *MessageLog) signal() {
+	select {
+	case l.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (l *MessageLog) run() {
+	defer close(l.done)
+	entries, lines := readMessageLog(l.path)
+	l.mu.Lock()
+	// entries appended while the file was being read come after it
+	l.entries = append(entries, l.entries...)
+	l.trim()
+	l.mu.Unlock()
+	l.fileLines = lines
+	close(l.loaded)
+
+	for range l.wake {
+		l.mu.Lock()
+		pending, closed := l.pending, l.closed
+		l.pending = nil
+		l.mu.Unlock()
+		if len(pending) > 0 {
+			if err := l.write(pending); err != nil {
+				log.Printf("message log: %v", err)
+			}
+		}
+		if closed {
+			return
+		}
+	}
+}
+
+func (l *MessageLog) trim() {
+	if len(l.entries) > MessageLogLimit {
+		l.entries = slices.Clone(l.entries[len(l.entries)-MessageLogLimit:])
+	}
+}
+
+// write appends entries to the file, compacting it when it has grown too
+// long.
+func (l *MessageLog) write(entries []MessageLogEntry) error {
+	var b bytes.Buffer
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return err
+	}
+	unlock, err := lockMessageLog(l.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b.Bytes())
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	l.fileLines += len(entries)
+	if l.fileLines >= 2*MessageLogLimit {
+		return l.compact()
+	}
+	return nil
+}
+
+// compact keeps the last MessageLogLimit lines of the file. It reads the file
+// again rather than writing out l.entries so that the lines written by other
+// instances are kept, and replaces it with a rename so that readers never see
+// a partial file. The caller holds the lock.
+func (l *MessageLog) compact() error {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return err
+	}
+	lines := strings.SplitAfter(strings.TrimRight(string(data), "\n"), "\n")
+	if len(lines) > MessageLogLimit {
+		lines = lines[len(lines)-MessageLogLimit:]
+	}
+	content := strings.Join(lines, "")
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, l.path); err != nil {
+		return err
+	}
+	l.fileLines = len(lines)
+	return nil
+}
+
+// readMessageLog returns the entries of the file at path and its number of
+// lines.
+func readMessageLog(path string) ([]MessageLogEntry, int) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0
+	}
+	var entries []MessageLogEntry
+	lines := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		lines++
+		var entry MessageLogEntry
+		// skip lines that are not entries, such as a partially written one
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) > MessageLogLimit {
+		entries = entries[len(entries)-MessageLogLimit:]
+	}
+	return entries, lines
+}
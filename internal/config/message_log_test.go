@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageLog_PersistsAcrossInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jjui", "messages.jsonl")
+	at := time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)
+
+	log := NewMessageLog(path)
+	log.Append(MessageLogEntry{Time: at, Severity: SeverityInfo, Text: "theme saved"})
+	log.Append(MessageLogEntry{Time: at, Severity: SeverityError, Command: "jj git push", Args: []string{"git", "push"}, Text: "rejected"})
+	assert.Len(t, log.Entries(), 2)
+	log.Close()
+
+	entries := NewMessageLog(path).Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "theme saved", entries[0].Text)
+	assert.Equal(t, SeverityError, entries[1].Severity)
+	assert.Equal(t, []string{"git", "push"}, entries[1].Args)
+	assert.True(t, at.Equal(entries[1].Time))
+}
+
+func TestMessageLog_SkipsMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"severity":"info","text":"ok"}`+"\n"+`{"severity":"in`), 0644))
+
+	entries := NewMessageLog(path).Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "ok", entries[0].Text)
+}
+
+func TestMessageLog_CompactsToLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.jsonl")
+	log := NewMessageLog(path)
+	for i := range 2*MessageLogLimit + 1 {
+		log.Append(MessageLogEntry{Severity: SeverityInfo, Text: fmt.Sprint(i)})
+	}
+	log.Close()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, MessageLogLimit, strings.Count(string(data), "\n"))
+	entries := NewMessageLog(path).Entries()
+	require.Len(t, entries, MessageLogLimit)
+	assert.Equal(t, fmt.Sprint(2*MessageLogLimit), entries[len(entries)-1].Text)
+}
+
+func TestMessageLog_CompactionKeepsEntriesOfOtherInstances(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.jsonl")
+	first := NewMessageLog(path)
+	second := NewMessageLog(path)
+	first.Entries()
+	second.Entries()
+
+	for i := range 2*MessageLogLimit - 2 {
+		first.Append(MessageLogEntry{Severity: SeverityInfo, Text: fmt.Sprint(i)})
+	}
+	first.Close()
+	second.Append(MessageLogEntry{Severity: SeverityInfo, Text: "from second"})
+	second.Close()
+
+	third := NewMessageLog(path)
+	third.Append(MessageLogEntry{Severity: SeverityInfo, Text: "from third"})
+	third.Close()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, MessageLogLimit, strings.Count(string(data), "\n"), "the third append compacts the file")
+	entries := NewMessageLog(path).Entries()
+	require.Len(t, entries, MessageLogLimit)
+	assert.Equal(t, "from second", entries[len(entries)-2].Text)
+	assert.Equal(t, "from third", entries[len(entries)-1].Text)
+}
+
+func TestMessageLog_AppendBeforeLoadComesAfterFileEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"severity":"info","text":"old"}`+"\n"), 0644))
+
+	log := NewMessageLog(path)
+	log.Append(MessageLogEntry{Severity: SeverityInfo, Text: "new"})
+	entries := log.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "old", entries[0].Text)
+	assert.Equal(t, "new", entries[1].Text)
+	log.Close()
+
+	assert.Len(t, NewMessageLog(path).Entries(), 2, "the new entry is written once")
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// paletteLookup is a palette lookup made by a renderer, as collected by
+// cmd/genactions into paletteLookups.
+type paletteLookup struct {
+	scope     string
+	component string
+	role      string
+	selected  bool
+}
+
+// paletteAliases are the jj colour keys the palette maps onto its own roles.
+var paletteAliases = []string{"diff added", "diff renamed", "diff copied", "diff modified", "diff removed"}
+
+var paletteKeys = sync.OnceValue(func() []string {
+	seen := map[string]bool{":" + string(SelectedVariant): true}
+	for _, alias := range paletteAliases {
+		seen[alias] = true
+	}
+	for _, lookup := range paletteLookups {
+		for _, candidate := range PaletteCandidates(lookup.scope, lookup.component, lookup.role) {
+			seen[candidate] = true
+			if lookup.selected {
+				seen[candidate+":"+string(SelectedVariant)] = true
+			}
+		}
+	}
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+})
+
+// PaletteKeys returns the sorted selectors the renderers look up, which are
+// the keys a theme can set.
+func PaletteKeys() []string {
+	return slices.Clone(paletteKeys())
+}
+
+// UnknownColorKeys returns the sorted keys of colors that no renderer looks
+// up, such as misspelled selectors in a theme.
+func UnknownColorKeys(colors map[string]Color) []string {
+	var unknown []string
+	for key := range colors {
+		if !isKnownColorKey(key) {
+			unknown = append(unknown, key)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
+
+func isKnownColorKey(key string) bool {
+	_, found := slices.BinarySearch(paletteKeys(), ParseColorSelector(key).Key())
+	return found
+}
+
+// PaletteCandidates returns the selectors a lookup of scope, component and
+// role tries, from the most to the least specific.
+func PaletteCandidates(scope, component, role string) []string {
+	candidates := make([]string, 0, 7)
+	seen := make(map[string]struct{}, 7)
+	add := func(parts ...string) {
+		nonEmpty := parts[:0]
+		for _, part := range parts {
+			if part != "" {
+				nonEmpty = append(nonEmpty, part)
+			}
+		}
+		if len(nonEmpty) == 0 {
+			return
+		}
+		key := strings.Join(nonEmpty, " ")
+		if _, ok := seen[key]; ok {
+			return
+		}
+		seen[key] = struct{}{}
+		candidates = append(candidates, key)
+	}
+
+	add(scope, component, role)
+	add(scope, component)
+	add(scope, role)
+	add(scope)
+	add(component, role)
+	add(component)
+	add(role)
+	return candidates
+}
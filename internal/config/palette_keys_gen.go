@@ -0,0 +1,212 @@
+// Code generated by cmd/genactions; DO NOT EDIT.
+package config
+
+var paletteLookups = []paletteLookup{
+	{scope: "", component: "", role: "", selected: true},
+	{scope: "", component: "", role: "dimmed", selected: false},
+	{scope: "", component: "", role: "matched", selected: false},
+	{scope: "", component: "", role: "text", selected: false},
+	{scope: "abandon", component: "", role: "source_marker", selected: false},
+	{scope: "absorb", component: "", role: "source_marker", selected: false},
+	{scope: "absorb", component: "", role: "target_marker", selected: false},
+	{scope: "bookmarks", component: "", role: "border", selected: false},
+	{scope: "bookmarks", component: "", role: "dimmed", selected: false},
+	{scope: "bookmarks", component: "", role: "dimmed", selected: true},
+	{scope: "bookmarks", component: "", role: "matched", selected: false},
+	{scope: "bookmarks", component: "", role: "shortcut", selected: false},
+	{scope: "bookmarks", component: "", role: "shortcut", selected: true},
+	{scope: "bookmarks", component: "", role: "text", selected: false},
+	{scope: "bookmarks", component: "", role: "text", selected: true},
+	{scope: "bookmarks", component: "", role: "title", selected: false},
+	{scope: "bookmarks", component: "input", role: "matched", selected: false},
+	{scope: "bookmarks", component: "input", role: "text", selected: false},
+	{scope: "bookmarks", component: "remote", role: "", selected: true},
+	{scope: "bookmarks", component: "remote", role: "dimmed", selected: false},
+	{scope: "bookmarks", component: "remote", role: "error", selected: false},
+	{scope: "bookmarks", component: "remote", role: "text", selected: false},
+	{scope: "bookmarks", component: "remote", role: "title", selected: false},
+	{scope: "choose", component: "", role: "", selected: false},
+	{scope: "choose", component: "", role: "", selected: true},
+	{scope: "choose", component: "", role: "border", selected: false},
+	{scope: "choose", component: "", role: "dimmed", selected: false},
+	{scope: "choose", component: "", role: "input", selected: false},
+	{scope: "choose", component: "", role: "text", selected: false},
+	{scope: "choose", component: "", role: "title", selected: false},
+	{scope: "confirmation", component: "", role: "", selected: true},
+	{scope: "confirmation", component: "", role: "border", selected: false},
+	{scope: "confirmation", component: "", role: "dimmed", selected: false},
+	{scope: "confirmation", component: "", role: "text", selected: false},
+	{scope: "content_search", component: "", role: "", selected: true},
+	{scope: "content_search", component: "", role: "border", selected: false},
+	{scope: "content_search", component: "", role: "change_id", selected: false},
+	{scope: "content_search", component: "", role: "dimmed", selected: false},
+	{scope: "content_search", component: "", role: "error", selected: false},
+	{scope: "content_search", component: "", role: "matched", selected: false},
+	{scope: "content_search", component: "", role: "text", selected: false},
+	{scope: "content_search", component: "", role: "title", selected: false},
+	{scope: "diff", component: "", role: "", selected: false},
+	{scope: "diff_range", component: "", role: "change_id", selected: false},
+	{scope: "diff_range", component: "", role: "dimmed", selected: false},
+	{scope: "diff_range", component: "", role: "source_marker", selected: false},
+	{scope: "diff_range", component: "", role: "target_marker", selected: false},
+	{scope: "duplicate", component: "", role: "change_id", selected: false},
+	{scope: "duplicate", component: "", role: "dimmed", selected: false},
+	{scope: "duplicate", component: "", role: "source_marker", selected: false},
+	{scope: "duplicate", component: "", role: "target_marker", selected: false},
+	{scope: "evolog", component: "", role: "", selected: true},
+	{scope: "evolog", component: "", role: "change_id", selected: false},
+	{scope: "evolog", component: "", role: "commit_id", selected: false},
+	{scope: "evolog", component: "", role: "dimmed", selected: false},
+	{scope: "evolog", component: "", role: "target_marker", selected: false},
+	{scope: "evolog", component: "", role: "text", selected: false},
+	{scope: "flash", component: "", role: "", selected: true},
+	{scope: "flash", component: "", role: "error", selected: false},
+	{scope: "flash", component: "", role: "matched", selected: false},
+	{scope: "flash", component: "", role: "success", selected: false},
+	{scope: "flash", component: "", role: "text", selected: false},
+	{scope: "git", component: "", role: "border", selected: false},
+	{scope: "git", component: "", role: "dimmed", selected: false},
+	{scope: "git", component: "", role: "dimmed", selected: true},
+	{scope: "git", component: "", role: "matched", selected: false},
+	{scope: "git", component: "", role: "shortcut", selected: false},
+	{scope: "git", component: "", role: "shortcut", selected: true},
+	{scope: "git", component: "", role: "text", selected: false},
+	{scope: "git", component: "", role: "text", selected: true},
+	{scope: "git", component: "", role: "title", selected: false},
+	{scope: "git", component: "input", role: "matched", selected: false},
+	{scope: "git", component: "input", role: "text", selected: false},
+	{scope: "git", component: "remote", role: "", selected: true},
+	{scope: "git", component: "remote", role: "dimmed", selected: false},
+	{scope: "git", component: "remote", role: "error", selected: false},
+	{scope: "git", component: "remote", role: "text", selected: false},
+	{scope: "git", component: "remote", role: "title", selected: false},
+	{scope: "help", component: "", role: "border", selected: false},
+	{scope: "help", component: "", role: "desc", selected: false},
+	{scope: "help", component: "", role: "dimmed", selected: false},
+	{scope: "help", component: "", role: "shortcut", selected: false},
+	{scope: "help", component: "", role: "title", selected: false},
+	{scope: "inline_describe", component: "", role: "overflow", selected: false},
+	{scope: "input", component: "", role: "", selected: false},
+	{scope: "input", component: "", role: "border", selected: false},
+	{scope: "input", component: "", role: "text", selected: false},
+	{scope: "input", component: "", role: "title", selected: false},
+	{scope: "jump_labels", component: "", role: "label", selected: false},
+	{scope: "metaedit", component: "", role: "dimmed", selected: false},
+	{scope: "metaedit", component: "", role: "error", selected: false},
+	{scope: "metaedit", component: "", role: "source_marker", selected: false},
+	{scope: "metaedit", component: "", role: "text", selected: false},
+	{scope: "metaedit", component: "", role: "title", selected: false},
+	{scope: "new", component: "", role: "source_marker", selected: false},
+	{scope: "new", component: "", role: "target_marker", selected: false},
+	{scope: "notifications", component: "", role: "", selected: true},
+	{scope: "notifications", component: "", role: "border", selected: false},
+	{scope: "notifications", component: "", role: "dimmed", selected: false},
+	{scope: "notifications", component: "", role: "error", selected: false},
+	{scope: "notifications", component: "", role: "text", selected: false},
+	{scope: "notifications", component: "", role: "title", selected: false},
+	{scope: "oplog", component: "", role: "", selected: true},
+	{scope: "oplog", component: "", role: "matched", selected: false},
+	{scope: "oplog", component: "", role: "text", selected: false},
+	{scope: "parallelize", component: "", role: "change_id", selected: false},
+	{scope: "parallelize", component: "", role: "dimmed", selected: false},
+	{scope: "parallelize", component: "", role: "source_marker", selected: false},
+	{scope: "parallelize", component: "", role: "target_marker", selected: false},
+	{scope: "password", component: "", role: "", selected: false},
+	{scope: "password", component: "", role: "border", selected: false},
+	{scope: "password", component: "", role: "title", selected: false},
+	{scope: "picker", component: "", role: "", selected: true},
+	{scope: "picker", component: "", role: "bookmark", selected: false},
+	{scope: "picker", component: "", role: "border", selected: false},
+	{scope: "picker", component: "", role: "dimmed", selected: false},
+	{scope: "picker", component: "", role: "dimmed", selected: true},
+	{scope: "picker", component: "", role: "matched", selected: false},
+	{scope: "picker", component: "", role: "matched", selected: true},
+	{scope: "picker", component: "", role: "text", selected: false},
+	{scope: "picker", component: "", role: "text", selected: true},
+	{scope: "preview", component: "", role: "", selected: false},
+	{scope: "rebase", component: "", role: "change_id", selected: false},
+	{scope: "rebase", component: "", role: "dimmed", selected: false},
+	{scope: "rebase", component: "", role: "source_marker", selected: false},
+	{scope: "rebase", component: "", role: "target_marker", selected: false},
+	{scope: "redo", component: "", role: "border", selected: false},
+	{scope: "redo", component: "confirmation", role: "", selected: true},
+	{scope: "redo", component: "confirmation", role: "border", selected: false},
+	{scope: "redo", component: "confirmation", role: "dimmed", selected: false},
+	{scope: "redo", component: "confirmation", role: "text", selected: false},
+	{scope: "revert", component: "", role: "change_id", selected: false},
+	{scope: "revert", component: "", role: "dimmed", selected: false},
+	{scope: "revert", component: "", role: "source_marker", selected: false},
+	{scope: "revert", component: "", role: "target_marker", selected: false},
+	{scope: "revisions", component: "", role: "", selected: true},
+	{scope: "revisions", component: "", role: "dimmed", selected: false},
+	{scope: "revisions", component: "", role: "drag", selected: false},
+	{scope: "revisions", component: "", role: "matched", selected: false},
+	{scope: "revisions", component: "", role: "text", selected: false},
+	{scope: "revisions", component: "confirmation", role: "", selected: true},
+	{scope: "revisions", component: "confirmation", role: "border", selected: false},
+	{scope: "revisions", component: "confirmation", role: "dimmed", selected: false},
+	{scope: "revisions", component: "confirmation", role: "text", selected: false},
+	{scope: "revisions", component: "details", role: "added", selected: false},
+	{scope: "revisions", component: "details", role: "added", selected: true},
+	{scope: "revisions", component: "details", role: "conflict", selected: false},
+	{scope: "revisions", component: "details", role: "conflict", selected: true},
+	{scope: "revisions", component: "details", role: "copied", selected: false},
+	{scope: "revisions", component: "details", role: "copied", selected: true},
+	{scope: "revisions", component: "details", role: "deleted", selected: false},
+	{scope: "revisions", component: "details", role: "deleted", selected: true},
+	{scope: "revisions", component: "details", role: "dimmed", selected: false},
+	{scope: "revisions", component: "details", role: "dimmed", selected: true},
+	{scope: "revisions", component: "details", role: "matched", selected: false},
+	{scope: "revisions", component: "details", role: "matched", selected: true},
+	{scope: "revisions", component: "details", role: "modified", selected: false},
+	{scope: "revisions", component: "details", role: "modified", selected: true},
+	{scope: "revisions", component: "details", role: "renamed", selected: false},
+	{scope: "revisions", component: "details", role: "renamed", selected: true},
+	{scope: "revisions", component: "details", role: "text", selected: false},
+	{scope: "revisions", component: "details", role: "text", selected: true},
+	{scope: "revset", component: "", role: "", selected: true},
+	{scope: "revset", component: "", role: "dimmed", selected: false},
+	{scope: "revset", component: "", role: "error", selected: false},
+	{scope: "revset", component: "", role: "error_marker", selected: false},
+	{scope: "revset", component: "", role: "function", selected: false},
+	{scope: "revset", component: "", role: "invalid", selected: false},
+	{scope: "revset", component: "", role: "matched", selected: false},
+	{scope: "revset", component: "", role: "operator", selected: false},
+	{scope: "revset", component: "", role: "string", selected: false},
+	{scope: "revset", component: "", role: "symbol", selected: false},
+	{scope: "revset", component: "", role: "text", selected: false},
+	{scope: "revset", component: "", role: "title", selected: false},
+	{scope: "revset", component: "completion", role: "", selected: false},
+	{scope: "revset", component: "completion", role: "", selected: true},
+	{scope: "revset", component: "completion", role: "dimmed", selected: false},
+	{scope: "revset", component: "completion", role: "dimmed", selected: true},
+	{scope: "revset", component: "completion", role: "matched", selected: false},
+	{scope: "revset", component: "completion", role: "matched", selected: true},
+	{scope: "revset", component: "completion", role: "text", selected: false},
+	{scope: "revset", component: "completion", role: "text", selected: true},
+	{scope: "set_parents", component: "", role: "dimmed", selected: false},
+	{scope: "set_parents", component: "", role: "source_marker", selected: false},
+	{scope: "set_parents", component: "", role: "target_marker", selected: false},
+	{scope: "simplify_parents", component: "", role: "dimmed", selected: false},
+	{scope: "simplify_parents", component: "", role: "source_marker", selected: false},
+	{scope: "simplify_parents", component: "", role: "target_marker", selected: false},
+	{scope: "squash", component: "", role: "source_marker", selected: false},
+	{scope: "squash", component: "", role: "target_marker", selected: false},
+	{scope: "status", component: "", role: "dimmed", selected: false},
+	{scope: "status", component: "", role: "shortcut", selected: false},
+	{scope: "status", component: "", role: "text", selected: false},
+	{scope: "status", component: "", role: "title", selected: false},
+	{scope: "status", component: "segment", role: "checked", selected: false},
+	{scope: "status", component: "segment", role: "conflicts", selected: false},
+	{scope: "status", component: "segment", role: "lua", selected: false},
+	{scope: "status", component: "segment", role: "op_id", selected: false},
+	{scope: "status", component: "segment", role: "revset", selected: false},
+	{scope: "status", component: "segment", role: "spinner", selected: false},
+	{scope: "status", component: "segment", role: "unpushed_bookmarks", selected: false},
+	{scope: "status", component: "segment", role: "working_copy", selected: false},
+	{scope: "undo", component: "", role: "border", selected: false},
+	{scope: "undo", component: "confirmation", role: "", selected: true},
+	{scope: "undo", component: "confirmation", role: "border", selected: false},
+	{scope: "undo", component: "confirmation", role: "dimmed", selected: false},
+	{scope: "undo", component: "confirmation", role: "text", selected: false},
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownColorKeys(t *testing.T) {
+	unknown := UnknownColorKeys(map[string]Color{
+		"revisions details text": {},
+		"selected choose":        {},
+		"revisions:selected":     {},
+		"diff added":             {},
+		":selected":              {},
+		"revisons text":          {},
+		"help shortcut:selected": {},
+	})
+	assert.Equal(t, []string{"help shortcut:selected", "revisons text"}, unknown)
+}
+
+func TestEmbeddedDefaultThemeOnlyUsesKnownKeys(t *testing.T) {
+	for _, isDark := range []bool{true, false} {
+		theme, err := LoadEmbeddedTheme("default", isDark)
+		require.NoError(t, err)
+		assert.Empty(t, UnknownColorKeys(theme.Colors))
+	}
+}
+
+func TestResolveThemeWarnsAboutUnknownKeys(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("JJUI_CONFIG_DIR", configDir)
+	require.NoError(t, os.MkdirAll(filepath.Join(configDir, "themes"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "themes", "custom.toml"), []byte(`
+[colors]
+"revisions text" = "white"
+"revisons text" = "red"
+`), 0o600))
+
+	originalUI := Current.UI
+	t.Cleanup(func() { Current.UI = originalUI })
+	Current.UI.Theme = ThemeConfig{Dark: "custom"}
+	Current.UI.Colors = map[string]Color{"statsu text": {Fg: "blue"}}
+
+	theme, err := ResolveTheme(true, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`theme "custom": unknown color key "revisons text"`,
+		`[ui.colors]: unknown color key "statsu text"`,
+	}, theme.Warnings)
+}
@@ -41,6 +41,25 @@ func (b Bookmark) HasTrackedRemote(remote string) bool {
 	return false
 }
 
+// IsUnpushed reports whether the local bookmark has no tracked remote or
+// points somewhere other than one of them.
+func (b Bookmark) IsUnpushed() bool {
+	if b.Local == nil {
+		return false
+	}
+	tracked := false
+	for _, r := range b.Remotes {
+		if !r.Tracked {
+			continue
+		}
+		tracked = true
+		if r.CommitId != b.Local.CommitId {
+			return true
+		}
+	}
+	return !tracked
+}
+
 func ParseBookmarkListOutput(output string) []Bookmark {
 	lines := strings.Split(output, "\n")
 	bookmarkMap := make(map[string]*Bookmark)
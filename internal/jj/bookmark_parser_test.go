@@ -117,3 +117,17 @@ feature;origin;true;false;false;b`,
 		})
 	}
 }
+
+func TestBookmark_IsUnpushed(t *testing.T) {
+	output := `ahead;.;false;false;false;b
+ahead;origin;true;false;false;a
+new;.;false;false;false;c
+pushed;.;false;false;false;d
+pushed;origin;true;false;false;d
+remote-only;origin;false;false;false;e`
+	unpushed := map[string]bool{}
+	for _, b := range ParseBookmarkListOutput(output) {
+		unpushed[b.Name] = b.IsUnpushed()
+	}
+	assert.Equal(t, map[string]bool{"ahead": true, "new": true, "pushed": false, "remote-only": false}, unpushed)
+}
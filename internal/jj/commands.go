@@ -128,6 +128,46 @@ func GetDescription(revision string) CommandArgs {
 	return []string{"log", "-r", revision, "--template", "description", "--no-graph", "--ignore-working-copy", "--color", "never", "--quiet"}
 }
 
+func GetFullIds(revision string) CommandArgs {
+	const template = `change_id ++ "\n" ++ commit_id`
+	return []string{"log", "-r", revision, "-n", "1", "--template", template, "--no-graph", "--ignore-working-copy", "--color", "never", "--quiet"}
+}
+
+func GetAuthor(revision string) CommandArgs {
+	const template = `author.name() ++ "\n" ++ author.email() ++ "\n" ++ author.timestamp().format("%Y-%m-%dT%H:%M:%S%:z")`
+	return []string{"log", "-r", revision, "-n", "1", "--template", template, "--no-graph", "--ignore-working-copy", "--color", "never", "--quiet"}
+}
+
+// MetaEditOptions holds the metadata to rewrite; empty fields are left untouched.
+type MetaEditOptions struct {
+	Author          string
+	AuthorTimestamp string
+}
+
+func MetaEdit(revisions SelectedRevisions, options MetaEditOptions, ignoreImmutable bool) CommandArgs {
+	args := []string{"metaedit"}
+	args = append(args, revisions.GetIds()...)
+	if options.Author != "" {
+		args = append(args, "--author", options.Author)
+	}
+	if options.AuthorTimestamp != "" {
+		args = append(args, "--author-timestamp", options.AuthorTimestamp)
+	}
+	if ignoreImmutable {
+		args = append(args, "--ignore-immutable")
+	}
+	return args
+}
+
+func ResetAuthor(revisions SelectedRevisions, ignoreImmutable bool) CommandArgs {
+	args := []string{"describe", "--reset-author", "--no-edit"}
+	args = append(args, revisions.AsArgs()...)
+	if ignoreImmutable {
+		args = append(args, "--ignore-immutable")
+	}
+	return args
+}
+
 func Abandon(revision SelectedRevisions, ignoreImmutable bool) CommandArgs {
 	args := []string{"abandon", "--retain-bookmarks"}
 	args = append(args, revision.AsArgs()...)
@@ -148,10 +188,19 @@ func Diff(revision string, fileName string, extraArgs ...string) CommandArgs {
 	return args
 }
 
+// DiffDirectory shows the changes revision made to the files beneath dir.
+func DiffDirectory(revision string, dir string) CommandArgs {
+	return Diff(revision, "", EscapeDirName(dir))
+}
+
 func DiffRange(from string, to string) CommandArgs {
 	return []string{"diff", "--from", from, "--to", to, "--color", "always", "--ignore-working-copy"}
 }
 
+func DiffSummary(from string, to string) CommandArgs {
+	return []string{"diff", "--from", from, "--to", to, "--summary", "--color", "never", "--ignore-working-copy"}
+}
+
 func Restore(revision string, files []string, interactive bool) CommandArgs {
 	args := []string{"restore", "-c", revision}
 	if interactive {
@@ -170,6 +219,15 @@ func RestoreEvolog(from string, into string) CommandArgs {
 	return args
 }
 
+func Fix(sources SelectedRevisions, ignoreImmutable bool) CommandArgs {
+	args := []string{"fix"}
+	args = append(args, sources.AsPrefixedArgs("-s")...)
+	if ignoreImmutable {
+		args = append(args, "--ignore-immutable")
+	}
+	return args
+}
+
 func Undo() CommandArgs {
 	return []string{"undo"}
 }
@@ -182,9 +240,13 @@ func Snapshot() CommandArgs {
 	return []string{"debug", "snapshot"}
 }
 
+// Status lists the files changed in revision. The template prints a
+// "conflict:source executable:target executable" token per file and "$",
+// then an "added:removed" line count token per file and "$" on the same
+// line. The --summary lines and the --stat lines come after it.
 func Status(revision string) CommandArgs {
-	template := `separate(";", diff.files().map(|x| x.target().conflict())) ++ " $\n"`
-	return []string{"log", "-r", revision, "--summary", "--no-graph", "--color", "never", "--quiet", "--template", template, "--ignore-working-copy"}
+	template := `separate(";", diff.files().map(|x| x.target().conflict() ++ ":" ++ x.source().executable() ++ ":" ++ x.target().executable())) ++ " $ " ++ diff.stat().files().map(|x| x.lines_added() ++ ":" ++ x.lines_removed()) ++ " $\n"`
+	return []string{"log", "-r", revision, "--summary", "--stat", "--no-graph", "--color", "never", "--quiet", "--template", template, "--ignore-working-copy"}
 }
 
 func BookmarkSet(revision string, name string) CommandArgs {
@@ -367,6 +429,53 @@ func DuplicateInsert(from SelectedRevisions, insertAfter string, insertBefore st
 	return args
 }
 
+func Next(offset int, edit bool, conflict bool) CommandArgs {
+	return movement("next", offset, edit, conflict)
+}
+
+func Prev(offset int, edit bool, conflict bool) CommandArgs {
+	return movement("prev", offset, edit, conflict)
+}
+
+// movement always passes --edit or --no-edit so the outcome doesn't depend on
+// the user's ui.movement.edit setting.
+func movement(command string, offset int, edit bool, conflict bool) CommandArgs {
+	args := []string{command}
+	if conflict {
+		args = append(args, "--conflict")
+	} else if offset > 1 {
+		args = append(args, strconv.Itoa(offset))
+	}
+	if edit {
+		args = append(args, "--edit")
+	} else {
+		args = append(args, "--no-edit")
+	}
+	return args
+}
+
+func Parallelize(revisions SelectedRevisions, ignoreImmutable bool) CommandArgs {
+	args := []string{"parallelize"}
+	args = append(args, revisions.GetIds()...)
+	if ignoreImmutable {
+		args = append(args, "--ignore-immutable")
+	}
+	return args
+}
+
+func SimplifyParents(revisions SelectedRevisions, descendants bool, ignoreImmutable bool) CommandArgs {
+	prefix := "-r"
+	if descendants {
+		prefix = "-s"
+	}
+	args := []string{"simplify-parents"}
+	args = append(args, revisions.AsPrefixedArgs(prefix)...)
+	if ignoreImmutable {
+		args = append(args, "--ignore-immutable")
+	}
+	return args
+}
+
 func Evolog(revision string) CommandArgs {
 	prefix := fmt.Sprintf(
 		"stringify('%s' ++ separate('%s', commit.change_id().shortest(), commit.commit_id().shortest()))",
@@ -421,6 +530,13 @@ func OpLogId(snapshot bool) CommandArgs {
 	return args
 }
 
+// WorkingCopyAndConflicts lists the working copy change id prefixed with @
+// and a ! line for every conflicted revision.
+func WorkingCopyAndConflicts() CommandArgs {
+	const template = `if(current_working_copy, "@" ++ change_id.shortest() ++ "\n") ++ if(conflict, "!\n")`
+	return []string{"log", "-r", "@ | conflicts()", "--no-graph", "--color", "never", "--quiet", "--ignore-working-copy", "--template", template}
+}
+
 func OpLog(limit int) CommandArgs {
 	args := []string{"op", "log", "--color", "always", "--quiet", "--ignore-working-copy"}
 	if limit > 0 {
@@ -442,9 +558,19 @@ func OpRevert(operationID string) CommandArgs {
 }
 
 func GetParent(revisions SelectedRevisions) CommandArgs {
+	return GetAncestor(revisions, 1)
+}
+
+// GetAncestor returns the ancestor the given number of generations above the
+// parent of revisions.
+func GetAncestor(revisions SelectedRevisions, generations int) CommandArgs {
 	args := []string{"log", "-r"}
 	joined := strings.Join(revisions.GetIds(), "|")
-	args = append(args, fmt.Sprintf("heads(::fork_point(%s) & ~present(%s))", joined, joined))
+	revset := fmt.Sprintf("heads(::fork_point(%s) & ~present(%s))", joined, joined)
+	if generations > 1 {
+		revset = "(" + revset + ")" + strings.Repeat("-", generations-1)
+	}
+	args = append(args, revset)
 	args = append(args, "-n", "1", "--color", "never", "--no-graph", "--quiet", "--ignore-working-copy", "--template", "commit_id.shortest()")
 	return args
 }
@@ -455,9 +581,11 @@ func GetParents(revision string) CommandArgs {
 	return args
 }
 
-func GetFirstChild(revision *Commit) CommandArgs {
+// GetDescendant returns the first descendant the given number of generations
+// below revision.
+func GetDescendant(revision *Commit, generations int) CommandArgs {
 	args := []string{"log", "-r"}
-	args = append(args, fmt.Sprintf("%s+", revision.CommitId))
+	args = append(args, revision.CommitId+strings.Repeat("+", max(generations, 1)))
 	args = append(args, "-n", "1", "--color", "never", "--no-graph", "--quiet", "--ignore-working-copy", "--template", "commit_id.shortest()")
 	return args
 }
@@ -476,16 +604,108 @@ func GetIdsFromRevset(revset string) CommandArgs {
 	return []string{"log", "-r", revset, "--color", "never", "--no-graph", "--quiet", "--ignore-working-copy", "--template", template}
 }
 
+// GetCommitIds lists the change and commit id of each revision in revset.
+// It snapshots the working copy so that comparing two listings only reports
+// commits rewritten in between.
+func GetCommitIds(revset string) CommandArgs {
+	const template = `change_id.short() ++ " " ++ commit_id.short() ++ "\n"`
+	return []string{"log", "-r", revset, "--color", "never", "--no-graph", "--quiet", "--template", template}
+}
+
+// GetShortestCommitIds lists the commit ids of revset in the shortest form the
+// revision graph shows them in, so that they can be compared with its rows.
+func GetShortestCommitIds(revset string) CommandArgs {
+	const template = `commit_id.shortest() ++ "\n"`
+	return []string{"log", "-r", revset, "--color", "never", "--no-graph", "--quiet", "--ignore-working-copy", "--template", template}
+}
+
+func GetRevisionLabels(revset string) CommandArgs {
+	const template = `change_id.shortest() ++ " " ++ if(description, description.first_line(), "(no description set)") ++ "\n"`
+	return []string{"log", "-r", revset, "--color", "never", "--no-graph", "--quiet", "--ignore-working-copy", "--template", template}
+}
+
 func ResolveRevisionID(revision string) CommandArgs {
 	const template = `change_id.shortest() ++ ";" ++ commit_id.shortest() ++ "\n"`
 	return []string{"log", "-r", revision, "-n", "1", "--color", "never", "--no-graph", "--quiet", "--ignore-working-copy", "--template", template}
 }
 
+// finderTemplate prints the fields of the revision finder separated by
+// FinderFieldSeparator, one revision per line.
+func finderTemplate() string {
+	fields := []string{
+		"change_id.shortest()",
+		"commit_id.shortest()",
+		"description.first_line()",
+		"author.name()",
+		`bookmarks.join(" ")`,
+		`author.timestamp().format("%Y-%m-%d")`,
+	}
+	return strings.Join(fields, ` ++ "`+FinderFieldSeparator+`" ++ `) + ` ++ "\n"`
+}
+
+// FinderFieldSeparator separates the fields printed by FinderVisible and
+// FinderHidden.
+const FinderFieldSeparator = "\x1f"
+
+// FinderVisible lists every visible revision for the revision finder.
+func FinderVisible() CommandArgs {
+	return []string{"log", "-r", "all()", "--no-graph", "--color", "never", "--quiet", "--ignore-working-copy", "-T", finderTemplate()}
+}
+
+// FinderOperations lists the ids of the latest limit operations, whose
+// revisions FinderHidden looks through.
+func FinderOperations(limit int) CommandArgs {
+	return []string{"op", "log", "--no-graph", "--color", "never", "--quiet", "--ignore-working-copy", "--limit", strconv.Itoa(limit), "-T", `id.short() ++ "\n"`}
+}
+
+// FinderHidden lists the revisions that were mutable at any of operations
+// but aren't visible anymore, such as abandoned revisions and the previous
+// versions of rewritten ones, for the revision finder.
+func FinderHidden(operations []string) CommandArgs {
+	revset := "none()"
+	if len(operations) > 0 {
+		var atOperations []string
+		for _, operation := range operations {
+			atOperations = append(atOperations, fmt.Sprintf("at_operation(%s, mutable())", operation))
+		}
+		revset = "(" + strings.Join(atOperations, " | ") + ") ~ all()"
+	}
+	return []string{"log", "-r", revset, "--no-graph", "--color", "never", "--quiet", "--ignore-working-copy", "-T", finderTemplate()}
+}
+
+// ContentSearch lists the revisions of revset, each introduced by
+// ContentSearchRecordSeparator and followed by its git diff when withDiff is
+// set so that the matching lines can be shown.
+func ContentSearch(revset string, withDiff bool) CommandArgs {
+	template := `"` + ContentSearchRecordSeparator + `" ++ ` + finderTemplate()
+	if withDiff {
+		template += ` ++ self.diff().git(0)`
+	}
+	return []string{"log", "-r", revset, "--no-graph", "--color", "never", "--quiet", "--ignore-working-copy", "-T", template}
+}
+
+// ContentSearchRecordSeparator starts every revision printed by ContentSearch.
+const ContentSearchRecordSeparator = "\x1e"
+
+// RevsetCount prints one "x" per revision in revset, up to limit revisions.
+func RevsetCount(revset string, limit int) CommandArgs {
+	return []string{"log", "-r", revset, "-n", strconv.Itoa(limit), "--no-graph", "--color", "never", "--quiet", "--ignore-working-copy", "-T", `"x"`}
+}
+
 func RevsetValidate(revset string) CommandArgs {
 	return []string{"log", "-r", revset, "-n", "1", "--ignore-working-copy"}
 }
 
 func EscapeFileName(fileName string) string {
+	return "file:" + quoteFileset(fileName)
+}
+
+// EscapeDirName returns a fileset matching every file beneath dir.
+func EscapeDirName(dir string) string {
+	return "cwd:" + quoteFileset(dir)
+}
+
+func quoteFileset(fileName string) string {
 	// Escape backslashes and quotes in the file name for shell compatibility
 	if strings.Contains(fileName, "\\") {
 		fileName = strings.ReplaceAll(fileName, "\\", "\\\\")
@@ -493,5 +713,5 @@ func EscapeFileName(fileName string) string {
 	if strings.Contains(fileName, "\"") {
 		fileName = strings.ReplaceAll(fileName, "\"", "\\\"")
 	}
-	return fmt.Sprintf("file:\"%s\"", fileName)
+	return fmt.Sprintf("\"%s\"", fileName)
 }
@@ -16,3 +16,15 @@ func TestBookmarkPatternCommandsUseExactStringPatterns(t *testing.T) {
 	assert.Equal(t, CommandArgs{"bookmark", "track", `exact:"1.3.63-+-json-length-\"fix\"\\branch"`, "--remote", `exact:"origin+backup"`}, BookmarkTrack(name, remote))
 	assert.Equal(t, CommandArgs{"bookmark", "untrack", `exact:"1.3.63-+-json-length-\"fix\"\\branch"`, "--remote", `exact:"origin+backup"`}, BookmarkUntrack(name, remote))
 }
+
+func TestGetAncestorAndDescendant_RepeatGenerations(t *testing.T) {
+	revisions := NewSelectedRevisions(&Commit{ChangeId: "a", CommitId: "abc"})
+	assert.Equal(t, GetParent(revisions), GetAncestor(revisions, 1))
+	assert.Equal(t, "(heads(::fork_point(a) & ~present(a)))--", GetAncestor(revisions, 3)[2])
+	assert.Equal(t, "abc+++", GetDescendant(&Commit{CommitId: "abc"}, 3)[2])
+}
+
+func TestFinderHidden_LooksThroughOperations(t *testing.T) {
+	assert.Equal(t, "(at_operation(op2, mutable()) | at_operation(op1, mutable())) ~ all()", FinderHidden([]string{"op2", "op1"})[2])
+	assert.Equal(t, "none()", FinderHidden(nil)[2])
+}
@@ -8,6 +8,7 @@ type Commit struct {
 	ChangeId      string
 	IsWorkingCopy bool
 	Hidden        bool
+	Conflicted    bool
 	CommitId      string
 }
 
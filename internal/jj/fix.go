@@ -0,0 +1,61 @@
+package jj
+
+import (
+	"strings"
+)
+
+// DefaultFixRevset is jj's default for revsets.fix, the revset `jj fix` uses
+// when no source is given.
+const DefaultFixRevset = "reachable(@, mutable())"
+
+// FixRevset returns the revisions `jj fix` may rewrite: the given sources and
+// their descendants, or configured, the revsets.fix setting, when there are
+// no sources.
+func FixRevset(sources SelectedRevisions, configured string) string {
+	if len(sources.Revisions) == 0 {
+		if configured != "" {
+			return configured
+		}
+		return DefaultFixRevset
+	}
+	return "(" + strings.Join(sources.GetIds(), "|") + ")::"
+}
+
+// RewrittenCommit is a revision whose commit id changed between two listings.
+type RewrittenCommit struct {
+	ChangeId string
+	From     string
+	To       string
+}
+
+// ParseCommitIds parses the output of GetCommitIds into a change id to commit
+// id map. Divergent changes keep the last listed commit.
+func ParseCommitIds(output string) map[string]string {
+	ids := make(map[string]string)
+	for line := range strings.SplitSeq(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		ids[fields[0]] = fields[1]
+	}
+	return ids
+}
+
+// RewrittenCommits returns the changes listed in both before and after whose
+// commit id differs, in the order they appear in after.
+func RewrittenCommits(before string, after string) []RewrittenCommit {
+	previous := ParseCommitIds(before)
+	var rewritten []RewrittenCommit
+	for line := range strings.SplitSeq(after, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		changeId, commitId := fields[0], fields[1]
+		if from, ok := previous[changeId]; ok && from != commitId {
+			rewritten = append(rewritten, RewrittenCommit{ChangeId: changeId, From: from, To: commitId})
+		}
+	}
+	return rewritten
+}
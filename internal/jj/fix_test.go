@@ -0,0 +1,30 @@
+package jj
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixRevset(t *testing.T) {
+	assert.Equal(t, DefaultFixRevset, FixRevset(NewSelectedRevisions(), ""))
+	assert.Equal(t, "mine() & mutable()", FixRevset(NewSelectedRevisions(), "mine() & mutable()"))
+	sources := NewSelectedRevisions(&Commit{ChangeId: "abc"}, &Commit{ChangeId: "def"})
+	assert.Equal(t, "(abc|def)::", FixRevset(sources, "mine() & mutable()"))
+	assert.Equal(t, CommandArgs{"fix", "-s", "abc", "-s", "def", "--ignore-immutable"}, Fix(sources, true))
+}
+
+func TestRewrittenCommits(t *testing.T) {
+	before := "kkmpptxzrspx 1111\nqpvuntsmwlqt 2222\nzsuskulnrvyr 3333\n"
+	after := "kkmpptxzrspx 1111\nqpvuntsmwlqt 4444\nzsuskulnrvyr 5555\nnewchangeabc 6666\n"
+
+	rewritten := RewrittenCommits(before, after)
+	assert.Equal(t, []RewrittenCommit{
+		{ChangeId: "qpvuntsmwlqt", From: "2222", To: "4444"},
+		{ChangeId: "zsuskulnrvyr", From: "3333", To: "5555"},
+	}, rewritten)
+}
+
+func TestRewrittenCommits_Unchanged(t *testing.T) {
+	assert.Empty(t, RewrittenCommits("abc 1111\n", "abc 1111\n"))
+}
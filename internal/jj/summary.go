@@ -3,6 +3,7 @@ package jj
 import (
 	"path"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/x/ansi"
@@ -12,9 +13,28 @@ type SummaryFile struct {
 	Status   rune
 	Name     string
 	FileName string
+	// Source is the path the file was renamed or copied from, if any.
+	Source string
 }
 
-var braceRenameRe = regexp.MustCompile(`\{[^}]*? => \s*([^}]*?)\s*\}`)
+// DiffStat is a file line of `--stat` output. jj only prints the total number
+// of changed lines there, so ParseDiffStat leaves Added and Removed to be
+// filled in from the line counts printed by the Status template.
+type DiffStat struct {
+	FileName string
+	// Truncated is set when jj shortened the path to fit the line, in which
+	// case FileName is only the tail of the path.
+	Truncated bool
+	Changes   int
+	Added     int
+	Removed   int
+	Binary    bool
+}
+
+var (
+	braceRenameRe       = regexp.MustCompile(`\{[^}]*? => \s*([^}]*?)\s*\}`)
+	braceRenameSourceRe = regexp.MustCompile(`\{\s*([^}]*?) => [^}]*?\}`)
+)
 
 func ParseSummaryFile(line string) (SummaryFile, bool) {
 	line = strings.TrimSpace(ansi.Strip(line))
@@ -32,9 +52,67 @@ func ParseSummaryFile(line string) (SummaryFile, bool) {
 		Status:   status,
 		Name:     line,
 		FileName: NormalizeSummaryFileName(line),
+		Source:   summarySourceFileName(line),
 	}, true
 }
 
+// summarySourceFileName returns the left-hand side of a rename or copy, or ""
+// when fileName is not one.
+func summarySourceFileName(fileName string) string {
+	fileName = strings.TrimSpace(fileName)
+	if strings.Contains(fileName, "{") && braceRenameSourceRe.MatchString(fileName) {
+		return path.Clean(braceRenameSourceRe.ReplaceAllString(fileName, "$1"))
+	}
+	if before, _, ok := strings.Cut(fileName, " => "); ok {
+		return strings.TrimSpace(before)
+	}
+	return ""
+}
+
+// ParseDiffStat parses a file line of `--stat` output such as
+// "src/main.go | 12 ++++++++----". The closing "N files changed" line and
+// anything else without a "|" separator is rejected.
+func ParseDiffStat(line string) (DiffStat, bool) {
+	line = ansi.Strip(line)
+	index := strings.LastIndex(line, " | ")
+	if index < 0 {
+		return DiffStat{}, false
+	}
+	name := strings.TrimSpace(line[:index])
+	if name == "" {
+		return DiffStat{}, false
+	}
+	stat := DiffStat{FileName: NormalizeSummaryFileName(name)}
+	if trimmed, ok := strings.CutPrefix(stat.FileName, "..."); ok {
+		stat.FileName = trimmed
+		stat.Truncated = true
+	}
+
+	rest := strings.TrimSpace(line[index+3:])
+	if strings.Contains(rest, "(binary)") || strings.HasPrefix(rest, "Bin") {
+		stat.Binary = true
+		return stat, true
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return stat, true
+	}
+	changes, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return DiffStat{}, false
+	}
+	stat.Changes = changes
+	return stat, true
+}
+
+// Matches reports whether the stat line is about fileName.
+func (s DiffStat) Matches(fileName string) bool {
+	if s.Truncated {
+		return strings.HasSuffix(fileName, s.FileName)
+	}
+	return s.FileName == fileName
+}
+
 func NormalizeSummaryFileName(fileName string) string {
 	fileName = strings.TrimSpace(fileName)
 	if fileName == "" {
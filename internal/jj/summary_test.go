@@ -68,3 +68,70 @@ func TestParseSummaryFile(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSummaryFile_Source(t *testing.T) {
+	got, ok := ParseSummaryFile("R internal/ui/{revisions => }/file.go")
+	require.True(t, ok)
+	assert.Equal(t, "internal/ui/revisions/file.go", got.Source)
+
+	got, ok = ParseSummaryFile("C {a.txt => b.txt}")
+	require.True(t, ok)
+	assert.Equal(t, "a.txt", got.Source)
+
+	got, ok = ParseSummaryFile("M file{with}braces.txt")
+	require.True(t, ok)
+	assert.Empty(t, got.Source)
+}
+
+func TestParseDiffStat(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want DiffStat
+		ok   bool
+	}{
+		{
+			name: "added and removed",
+			line: "src/main.go    | 12 ++++++++----",
+			want: DiffStat{FileName: "src/main.go", Changes: 12},
+			ok:   true,
+		},
+		{
+			name: "scaled bar",
+			line: "big.go | 300 +++++++---",
+			want: DiffStat{FileName: "big.go", Changes: 300},
+			ok:   true,
+		},
+		{
+			name: "rename without changes",
+			line: "src/{old => new}.go | 0",
+			want: DiffStat{FileName: "src/new.go"},
+			ok:   true,
+		},
+		{
+			name: "truncated path",
+			line: ".../very/long/path.go | 1 +",
+			want: DiffStat{FileName: "/very/long/path.go", Truncated: true, Changes: 1},
+			ok:   true,
+		},
+		{
+			name: "binary",
+			line: "image.png | (binary) +1234 bytes",
+			want: DiffStat{FileName: "image.png", Binary: true},
+			ok:   true,
+		},
+		{
+			name: "totals",
+			line: "2 files changed, 3 insertions(+), 1 deletion(-)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseDiffStat(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.True(t, DiffStat{FileName: "/long/path.go", Truncated: true}.Matches("a/very/long/path.go"))
+}
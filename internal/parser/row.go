@@ -163,8 +163,11 @@ func (row *Row) AddLine(line *GraphRowLine) {
 		line.Flags = Revision | Highlightable
 		row.Commit.IsWorkingCopy = line.containsRune('@')
 		for _, segment := range line.Segments {
-			if strings.TrimSpace(segment.Text) == "hidden" {
+			switch strings.TrimSpace(segment.Text) {
+			case "hidden":
 				row.Commit.Hidden = true
+			case "conflict":
+				row.Commit.Conflicted = true
 			}
 		}
 	default:
@@ -47,3 +47,21 @@ func TestParseRowsStreaming_Close(t *testing.T) {
 	_, received := <-receiver
 	assert.False(t, received, "expected channel to be closed")
 }
+
+func TestParseRowsStreaming_MarksConflictedRevisions(t *testing.T) {
+	var lb test.LogBuilder
+	lb.Write("×   _PREFIX:abcde_PREFIX:xyrq author=some@author \x1b[1;31mconflict\x1b[0m")
+	lb.Write("│   commit with conflicts")
+	lb.Write("○   _PREFIX:fghij_PREFIX:klmn author=some@author")
+	lb.Write("│   commit without conflicts")
+
+	reader := strings.NewReader(lb.String())
+	controlChannel := make(chan ControlMsg)
+	receiver := ParseRowsStreaming(reader, controlChannel, 50, nil)
+	controlChannel <- RequestMore
+	batch := <-receiver
+	if assert.Len(t, batch.Rows, 2) {
+		assert.True(t, batch.Rows[0].Commit.Conflicted)
+		assert.False(t, batch.Rows[1].Commit.Conflicted)
+	}
+}
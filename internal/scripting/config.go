@@ -11,8 +11,10 @@ import (
 )
 
 const (
-	actionRegistryName = "__jjui_actions"
-	actionCounterName  = "__jjui_action_counter"
+	actionRegistryName  = "__jjui_actions"
+	actionCounterName   = "__jjui_action_counter"
+	segmentRegistryName = "__jjui_segments"
+	trailerRegistryName = "__jjui_trailers"
 )
 
 func InitVM(ctx *uicontext.MainContext) error {
@@ -169,6 +171,7 @@ func RunSetup(ctx *uicontext.MainContext, current *config.Config, source string)
 			Action: stringFieldFromTable(tbl, "action"),
 			Desc:   stringFieldFromTable(tbl, "desc"),
 			Scope:  stringFieldFromTable(tbl, "scope"),
+			When:   stringFieldFromTable(tbl, "when"),
 		}
 		if key := stringListFieldFromTable(tbl, "key"); len(key) > 0 {
 			binding.Key = key
@@ -181,6 +184,45 @@ func RunSetup(ctx *uicontext.MainContext, current *config.Config, source string)
 		return 0
 	}))
 
+	configTable.RawSetString("segment", L.NewFunction(func(L *lua.LState) int {
+		name := L.CheckString(1)
+		fn := L.CheckFunction(2)
+		opts := L.OptTable(3, L.NewTable())
+
+		segments := ensureGlobalTable(L, segmentRegistryName)
+		segments.Append(fn)
+		segment := config.StatuslineSegment{
+			Name:  name,
+			Align: stringFieldFromTable(opts, "align"),
+			Lua:   fmt.Sprintf("%s[%d]()", segmentRegistryName, segments.Len()),
+		}
+		if priority, ok := opts.RawGetString("priority").(lua.LNumber); ok {
+			segment.Priority = int(priority)
+		}
+		segmentsTable := nestedTable(L, configTable, "config.segment", "ui", "statusline", "segments")
+		segmentsTable.Append(toLuaTable(L, segment))
+		return 0
+	}))
+
+	configTable.RawSetString("trailer", L.NewFunction(func(L *lua.LState) int {
+		key := L.CheckString(1)
+		fn := L.CheckFunction(2)
+		opts := L.OptTable(3, L.NewTable())
+
+		trailers := ensureGlobalTable(L, trailerRegistryName)
+		trailers.Append(fn)
+		trailer := config.TrailerConfig{
+			Key: key,
+			Lua: fmt.Sprintf("%s[%d]()", trailerRegistryName, trailers.Len()),
+		}
+		if auto, ok := opts.RawGetString("auto").(lua.LBool); ok {
+			trailer.Auto = bool(auto)
+		}
+		trailersTable := nestedTable(L, configTable, "config.trailer", "describe", "trailers")
+		trailersTable.Append(toLuaTable(L, trailer))
+		return 0
+	}))
+
 	if err := L.DoString(source); err != nil {
 		return fmt.Errorf("config.lua: %w", err)
 	}
@@ -218,12 +260,41 @@ func vmFromContext(ctx *uicontext.MainContext) (*lua.LState, error) {
 	return ctx.ScriptVM, nil
 }
 
+// EvalSegment evaluates the Lua expression of a status bar segment and
+// returns its value as text.
+func EvalSegment(ctx *uicontext.MainContext, expr string) (string, error) {
+	if _, err := vmFromContext(ctx); err != nil {
+		return "", err
+	}
+	return ctx.EvalLua(expr)
+}
+
 func ensureActionRegistry(L *lua.LState) *lua.LTable {
-	if existing, ok := L.GetGlobal(actionRegistryName).(*lua.LTable); ok {
+	return ensureGlobalTable(L, actionRegistryName)
+}
+
+func ensureGlobalTable(L *lua.LState, name string) *lua.LTable {
+	if existing, ok := L.GetGlobal(name).(*lua.LTable); ok {
 		return existing
 	}
 	tbl := L.NewTable()
-	L.SetGlobal(actionRegistryName, tbl)
+	L.SetGlobal(name, tbl)
+	return tbl
+}
+
+// nestedTable follows keys from tbl, raising a Lua error on behalf of fn when
+// a value on the way isn't a table.
+func nestedTable(L *lua.LState, tbl *lua.LTable, fn string, keys ...string) *lua.LTable {
+	path := "config"
+	for _, key := range keys {
+		path += "." + key
+		next, ok := tbl.RawGetString(key).(*lua.LTable)
+		if !ok {
+			L.RaiseError("%s: %s is not a table", fn, path)
+			return nil
+		}
+		tbl = next
+	}
 	return tbl
 }
 
@@ -355,3 +355,75 @@ func findBinding(bindings []config.BindingConfig, action, scope string) (config.
 	}
 	return config.BindingConfig{}, false
 }
+
+func TestRunSetupRegistersStatuslineSegment(t *testing.T) {
+	ctx := setupVM(t)
+	cfg := *config.Current
+
+	source := `
+function setup(config)
+  config.segment("greeting", function()
+    return "hello " .. revset.current()
+  end, { align = "right", priority = 5 })
+end
+`
+
+	err := RunSetup(ctx, &cfg, source)
+	require.NoError(t, err)
+
+	require.Len(t, cfg.UI.Statusline.Segments, 1)
+	segment := cfg.UI.Statusline.Segments[0]
+	assert.Equal(t, "greeting", segment.Name)
+	assert.Equal(t, "right", segment.Align)
+	assert.Equal(t, 5, segment.Priority)
+
+	ctx.CurrentRevset = "trunk()"
+	text, err := EvalSegment(ctx, segment.Lua)
+	require.NoError(t, err)
+	assert.Equal(t, "hello trunk()", text)
+
+	_, err = EvalSegment(ctx, "nil .. 1")
+	assert.Error(t, err)
+}
+
+func TestRunSetupSegmentRejectsNonTableStatusline(t *testing.T) {
+	ctx := setupVM(t)
+	cfg := *config.Current
+
+	source := `
+function setup(config)
+  config.ui.statusline = "compact"
+  config.segment("greeting", function() return "hello" end)
+end
+`
+
+	err := RunSetup(ctx, &cfg, source)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "config.ui.statusline is not a table")
+}
+
+func TestRunSetupRegistersTrailer(t *testing.T) {
+	ctx := setupVM(t)
+	cfg := *config.Current
+
+	source := `
+function setup(config)
+  config.trailer("Reviewed-on", function()
+    return "review/" .. revset.current()
+  end, { auto = true })
+end
+`
+
+	err := RunSetup(ctx, &cfg, source)
+	require.NoError(t, err)
+
+	require.Len(t, cfg.Describe.Trailers, 1)
+	trailer := cfg.Describe.Trailers[0]
+	assert.Equal(t, "Reviewed-on", trailer.Key)
+	assert.True(t, trailer.Auto)
+
+	ctx.CurrentRevset = "42"
+	text, err := ctx.EvalLua(trailer.Lua)
+	require.NoError(t, err)
+	assert.Equal(t, "review/42", text)
+}
@@ -0,0 +1,35 @@
+package actionargs
+
+import "math"
+
+func IntArg(args map[string]any, name string, fallback int) int {
+	if args == nil {
+		return fallback
+	}
+	raw, ok := args[name]
+	if !ok {
+		return fallback
+	}
+	v, ok := AsInt(raw)
+	if !ok {
+		return fallback
+	}
+	return v
+}
+
+// AsInt accepts the numeric types produced by the TOML and Lua decoders and
+// rejects values with a fractional part.
+func AsInt(raw any) (int, bool) {
+	switch v := raw.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, false
+		}
+		return int(v), true
+	}
+	return 0, false
+}
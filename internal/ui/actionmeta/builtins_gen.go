@@ -3,296 +3,387 @@ package actionmeta
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
+
+	"github.com/idursun/jjui/internal/ui/actionargs"
 )
 
 var builtInActionScopes = map[string][]string{
-	"bookmarks.apply":                            {"bookmarks"},
-	"bookmarks.bookmark_delete":                  {"bookmarks"},
-	"bookmarks.bookmark_forget":                  {"bookmarks"},
-	"bookmarks.bookmark_move":                    {"bookmarks"},
-	"bookmarks.bookmark_track":                   {"bookmarks"},
-	"bookmarks.bookmark_untrack":                 {"bookmarks"},
-	"bookmarks.cancel":                           {"bookmarks"},
-	"bookmarks.cycle_remotes":                    {"bookmarks"},
-	"bookmarks.cycle_remotes_back":               {"bookmarks"},
-	"bookmarks.filter":                           {"bookmarks"},
-	"bookmarks.move_down":                        {"bookmarks"},
-	"bookmarks.move_up":                          {"bookmarks"},
-	"bookmarks.page_down":                        {"bookmarks"},
-	"bookmarks.page_up":                          {"bookmarks"},
-	"bookmarks.quit":                             {"bookmarks"},
-	"choose.apply":                               {"choose"},
-	"choose.cancel":                              {"choose"},
-	"choose.filter":                              {"choose"},
-	"choose.move_down":                           {"choose"},
-	"choose.move_up":                             {"choose"},
-	"command_history.close":                      {"command_history"},
-	"command_history.delete_selected":            {"command_history"},
-	"command_history.move_down":                  {"command_history"},
-	"command_history.move_up":                    {"command_history"},
-	"diff.half_page_down":                        {"diff"},
-	"diff.half_page_up":                          {"diff"},
-	"diff.left":                                  {"diff"},
-	"diff.move_bottom":                           {"diff"},
-	"diff.move_top":                              {"diff"},
-	"diff.next_file":                             {"diff"},
-	"diff.page_down":                             {"diff"},
-	"diff.page_up":                               {"diff"},
-	"diff.prev_file":                             {"diff"},
-	"diff.right":                                 {"diff"},
-	"diff.scroll_down":                           {"diff"},
-	"diff.scroll_up":                             {"diff"},
-	"diff.show":                                  {"diff"},
-	"diff.target_picker":                         {"diff"},
-	"diff.toggle_wrap":                           {"diff"},
-	"file_search.apply":                          {"file_search"},
-	"file_search.cancel":                         {"file_search"},
-	"file_search.edit":                           {"file_search"},
-	"file_search.move_down":                      {"file_search"},
-	"file_search.move_up":                        {"file_search"},
-	"file_search.page_down":                      {"file_search"},
-	"file_search.page_up":                        {"file_search"},
-	"file_search.preview_half_page_down":         {"file_search"},
-	"file_search.preview_half_page_up":           {"file_search"},
-	"file_search.toggle":                         {"file_search"},
-	"git.apply":                                  {"git"},
-	"git.cancel":                                 {"git"},
-	"git.cycle_remotes":                          {"git"},
-	"git.cycle_remotes_back":                     {"git"},
-	"git.fetch":                                  {"git"},
-	"git.filter":                                 {"git"},
-	"git.move_down":                              {"git"},
-	"git.move_up":                                {"git"},
-	"git.page_down":                              {"git"},
-	"git.page_up":                                {"git"},
-	"git.push":                                   {"git"},
-	"git.quit":                                   {"git"},
-	"help.apply":                                 {"help"},
-	"help.cancel":                                {"help"},
-	"help.close":                                 {"help"},
-	"help.filter":                                {"help"},
-	"help.move_bottom":                           {"help"},
-	"help.move_top":                              {"help"},
-	"help.page_down":                             {"help"},
-	"help.page_up":                               {"help"},
-	"help.scroll_down":                           {"help"},
-	"help.scroll_up":                             {"help"},
-	"input.apply":                                {"input"},
-	"input.cancel":                               {"input"},
-	"oplog.close":                                {"oplog"},
-	"oplog.diff":                                 {"oplog"},
-	"oplog.move_down":                            {"oplog"},
-	"oplog.move_up":                              {"oplog"},
-	"oplog.page_down":                            {"oplog"},
-	"oplog.page_up":                              {"oplog"},
-	"oplog.quick_search.clear":                   {"oplog.quick_search"},
-	"oplog.quick_search.next":                    {"oplog.quick_search"},
-	"oplog.quick_search.prev":                    {"oplog.quick_search"},
-	"oplog.quit":                                 {"oplog"},
-	"oplog.restore":                              {"oplog"},
-	"oplog.revert":                               {"oplog"},
-	"password.apply":                             {"password"},
-	"password.cancel":                            {"password"},
-	"redo.apply":                                 {"redo"},
-	"redo.cancel":                                {"redo"},
-	"redo.next":                                  {"redo"},
-	"redo.prev":                                  {"redo"},
-	"revisions.abandon.ace_jump":                 {"revisions.abandon"},
-	"revisions.abandon.apply":                    {"revisions.abandon"},
-	"revisions.abandon.cancel":                   {"revisions.abandon"},
-	"revisions.abandon.force_apply":              {"revisions.abandon"},
-	"revisions.abandon.jump_to_working_copy":     {"revisions.abandon"},
-	"revisions.abandon.select_descendants":       {"revisions.abandon"},
-	"revisions.abandon.toggle_select":            {"revisions.abandon"},
-	"revisions.absorb.ace_jump":                  {"revisions.absorb"},
-	"revisions.absorb.apply":                     {"revisions.absorb"},
-	"revisions.absorb.cancel":                    {"revisions.absorb"},
-	"revisions.absorb.jump_to_working_copy":      {"revisions.absorb"},
-	"revisions.absorb.select_descendants":        {"revisions.absorb"},
-	"revisions.absorb.toggle_select":             {"revisions.absorb"},
-	"revisions.ace_jump":                         {"revisions"},
-	"revisions.ace_jump.apply":                   {"revisions.ace_jump"},
-	"revisions.ace_jump.cancel":                  {"revisions.ace_jump"},
-	"revisions.apply":                            {"revisions"},
-	"revisions.cancel":                           {"revisions"},
-	"revisions.commit":                           {"revisions"},
-	"revisions.describe":                         {"revisions"},
-	"revisions.details.absorb":                   {"revisions.details"},
-	"revisions.details.cancel":                   {"revisions.details"},
-	"revisions.details.confirmation.apply":       {"revisions.details.confirmation"},
-	"revisions.details.confirmation.cancel":      {"revisions.details.confirmation"},
-	"revisions.details.confirmation.force_apply": {"revisions.details.confirmation"},
-	"revisions.details.confirmation.next":        {"revisions.details.confirmation"},
-	"revisions.details.confirmation.prev":        {"revisions.details.confirmation"},
-	"revisions.details.diff":                     {"revisions.details"},
-	"revisions.details.filter":                   {"revisions.details"},
-	"revisions.details.filter_apply":             {"revisions.details"},
-	"revisions.details.filter_cancel":            {"revisions.details"},
-	"revisions.details.move_down":                {"revisions.details"},
-	"revisions.details.move_up":                  {"revisions.details"},
-	"revisions.details.page_down":                {"revisions.details"},
-	"revisions.details.page_up":                  {"revisions.details"},
-	"revisions.details.quit":                     {"revisions.details"},
-	"revisions.details.refresh":                  {"revisions.details"},
-	"revisions.details.restore":                  {"revisions.details"},
-	"revisions.details.revisions_changing_file":  {"revisions.details"},
-	"revisions.details.select_file":              {"revisions.details"},
-	"revisions.details.split":                    {"revisions.details"},
-	"revisions.details.split_parallel":           {"revisions.details"},
-	"revisions.details.squash":                   {"revisions.details"},
-	"revisions.details.toggle_select":            {"revisions.details"},
-	"revisions.diff":                             {"revisions"},
-	"revisions.diff_edit":                        {"revisions"},
-	"revisions.diff_range.apply":                 {"revisions.diff_range"},
-	"revisions.diff_range.cancel":                {"revisions.diff_range"},
-	"revisions.diff_range.swap":                  {"revisions.diff_range"},
-	"revisions.diff_range.target_picker":         {"revisions.diff_range"},
-	"revisions.duplicate.ace_jump":               {"revisions.duplicate"},
-	"revisions.duplicate.apply":                  {"revisions.duplicate"},
-	"revisions.duplicate.cancel":                 {"revisions.duplicate"},
-	"revisions.duplicate.force_apply":            {"revisions.duplicate"},
-	"revisions.duplicate.jump_to_working_copy":   {"revisions.duplicate"},
-	"revisions.duplicate.set_target":             {"revisions.duplicate"},
-	"revisions.duplicate.target_picker":          {"revisions.duplicate"},
-	"revisions.edit":                             {"revisions"},
-	"revisions.evolog.apply":                     {"revisions.evolog"},
-	"revisions.evolog.cancel":                    {"revisions.evolog"},
-	"revisions.evolog.diff":                      {"revisions.evolog"},
-	"revisions.evolog.move_down":                 {"revisions.evolog"},
-	"revisions.evolog.move_up":                   {"revisions.evolog"},
-	"revisions.evolog.page_down":                 {"revisions.evolog"},
-	"revisions.evolog.page_up":                   {"revisions.evolog"},
-	"revisions.evolog.quit":                      {"revisions.evolog"},
-	"revisions.evolog.restore":                   {"revisions.evolog"},
-	"revisions.force_apply":                      {"revisions"},
-	"revisions.force_edit":                       {"revisions"},
-	"revisions.go_to_bottom":                     {"revisions"},
-	"revisions.go_to_top":                        {"revisions"},
-	"revisions.inline_describe.accept":           {"revisions.inline_describe"},
-	"revisions.inline_describe.cancel":           {"revisions.inline_describe"},
-	"revisions.inline_describe.editor":           {"revisions.inline_describe"},
-	"revisions.inline_describe.force_accept":     {"revisions.inline_describe"},
-	"revisions.inline_describe.new_line":         {"revisions.inline_describe"},
-	"revisions.jump_to_children":                 {"revisions"},
-	"revisions.jump_to_parent":                   {"revisions"},
-	"revisions.jump_to_working_copy":             {"revisions"},
-	"revisions.move_down":                        {"revisions"},
-	"revisions.move_up":                          {"revisions"},
-	"revisions.new":                              {"revisions"},
-	"revisions.new_between.apply":                {"revisions.new_between"},
-	"revisions.new_between.cancel":               {"revisions.new_between"},
-	"revisions.new_between.toggle_insert_before": {"revisions.new_between"},
-	"revisions.open_abandon":                     {"revisions"},
-	"revisions.open_absorb":                      {"revisions"},
-	"revisions.open_details":                     {"revisions"},
-	"revisions.open_diff_range":                  {"revisions"},
-	"revisions.open_duplicate":                   {"revisions"},
-	"revisions.open_evolog":                      {"revisions"},
-	"revisions.open_inline_describe":             {"revisions"},
-	"revisions.open_new_between":                 {"revisions"},
-	"revisions.open_rebase":                      {"revisions"},
-	"revisions.open_revert":                      {"revisions"},
-	"revisions.open_set_bookmark":                {"revisions"},
-	"revisions.open_set_parents":                 {"revisions"},
-	"revisions.open_squash":                      {"revisions"},
-	"revisions.page_down":                        {"revisions"},
-	"revisions.page_up":                          {"revisions"},
-	"revisions.quick_search.clear":               {"revisions.quick_search"},
-	"revisions.quick_search.input.apply":         {"revisions.quick_search.input"},
-	"revisions.quick_search.input.cancel":        {"revisions.quick_search.input"},
-	"revisions.quick_search.next":                {"revisions.quick_search"},
-	"revisions.quick_search.prev":                {"revisions.quick_search"},
-	"revisions.rebase.ace_jump":                  {"revisions.rebase"},
-	"revisions.rebase.apply":                     {"revisions.rebase"},
-	"revisions.rebase.cancel":                    {"revisions.rebase"},
-	"revisions.rebase.force_apply":               {"revisions.rebase"},
-	"revisions.rebase.jump_to_working_copy":      {"revisions.rebase"},
-	"revisions.rebase.set_source":                {"revisions.rebase"},
-	"revisions.rebase.set_target":                {"revisions.rebase"},
-	"revisions.rebase.skip_emptied":              {"revisions.rebase"},
-	"revisions.rebase.target_picker":             {"revisions.rebase"},
-	"revisions.refresh":                          {"revisions"},
-	"revisions.revert.apply":                     {"revisions.revert"},
-	"revisions.revert.cancel":                    {"revisions.revert"},
-	"revisions.revert.force_apply":               {"revisions.revert"},
-	"revisions.revert.set_target":                {"revisions.revert"},
-	"revisions.revert.target_picker":             {"revisions.revert"},
-	"revisions.set_bookmark.apply":               {"revisions.set_bookmark"},
-	"revisions.set_bookmark.autocomplete":        {"revisions.set_bookmark"},
-	"revisions.set_bookmark.autocomplete_back":   {"revisions.set_bookmark"},
-	"revisions.set_bookmark.cancel":              {"revisions.set_bookmark"},
-	"revisions.set_parents.ace_jump":             {"revisions.set_parents"},
-	"revisions.set_parents.apply":                {"revisions.set_parents"},
-	"revisions.set_parents.cancel":               {"revisions.set_parents"},
-	"revisions.set_parents.jump_to_working_copy": {"revisions.set_parents"},
-	"revisions.set_parents.toggle_select":        {"revisions.set_parents"},
-	"revisions.split":                            {"revisions"},
-	"revisions.split_parallel":                   {"revisions"},
-	"revisions.squash.ace_jump":                  {"revisions.squash"},
-	"revisions.squash.apply":                     {"revisions.squash"},
-	"revisions.squash.cancel":                    {"revisions.squash"},
-	"revisions.squash.force_apply":               {"revisions.squash"},
-	"revisions.squash.interactive":               {"revisions.squash"},
-	"revisions.squash.jump_to_working_copy":      {"revisions.squash"},
-	"revisions.squash.keep_emptied":              {"revisions.squash"},
-	"revisions.squash.target_picker":             {"revisions.squash"},
-	"revisions.squash.use_destination_msg":       {"revisions.squash"},
-	"revisions.target_picker.apply":              {"revisions.target_picker"},
-	"revisions.target_picker.autocomplete":       {"revisions.target_picker"},
-	"revisions.target_picker.autocomplete_back":  {"revisions.target_picker"},
-	"revisions.target_picker.cancel":             {"revisions.target_picker"},
-	"revisions.target_picker.force_apply":        {"revisions.target_picker"},
-	"revisions.target_picker.move_down":          {"revisions.target_picker"},
-	"revisions.target_picker.move_up":            {"revisions.target_picker"},
-	"revisions.toggle_select":                    {"revisions"},
-	"revset.apply":                               {"revset"},
-	"revset.autocomplete":                        {"revset"},
-	"revset.autocomplete_back":                   {"revset"},
-	"revset.cancel":                              {"revset"},
-	"revset.edit":                                {"revset"},
-	"revset.move_down":                           {"revset"},
-	"revset.move_up":                             {"revset"},
-	"revset.reset":                               {"revset"},
-	"revset.set":                                 {"revset"},
-	"status.input.apply":                         {"status.input"},
-	"status.input.autocomplete":                  {"status.input"},
-	"status.input.cancel":                        {"status.input"},
-	"status.input.move_down":                     {"status.input"},
-	"status.input.move_up":                       {"status.input"},
-	"status.input.page_down":                     {"status.input"},
-	"status.input.page_up":                       {"status.input"},
-	"ui.cancel":                                  {"ui"},
-	"ui.change_theme":                            {"ui"},
-	"ui.exec_jj":                                 {"ui"},
-	"ui.exec_shell":                              {"ui"},
-	"ui.expand_status":                           {"ui"},
-	"ui.file_search_toggle":                      {"ui"},
-	"ui.open_bookmarks":                          {"ui"},
-	"ui.open_command_history":                    {"ui"},
-	"ui.open_git":                                {"ui"},
-	"ui.open_help":                               {"ui"},
-	"ui.open_oplog":                              {"ui"},
-	"ui.open_redo":                               {"ui"},
-	"ui.open_revset":                             {"ui"},
-	"ui.open_undo":                               {"ui"},
-	"ui.preview.show":                            {"ui.preview"},
-	"ui.preview_expand":                          {"ui"},
-	"ui.preview_half_page_down":                  {"ui"},
-	"ui.preview_half_page_up":                    {"ui"},
-	"ui.preview_scroll_down":                     {"ui"},
-	"ui.preview_scroll_up":                       {"ui"},
-	"ui.preview_shrink":                          {"ui"},
-	"ui.preview_toggle":                          {"ui"},
-	"ui.preview_toggle_bottom":                   {"ui"},
-	"ui.quick_search":                            {"ui"},
-	"ui.quit":                                    {"ui"},
-	"ui.suspend":                                 {"ui"},
-	"undo.apply":                                 {"undo"},
-	"undo.cancel":                                {"undo"},
-	"undo.next":                                  {"undo"},
-	"undo.prev":                                  {"undo"},
+	"bookmarks.ace_jump":                              {"bookmarks"},
+	"bookmarks.apply":                                 {"bookmarks"},
+	"bookmarks.bookmark_delete":                       {"bookmarks"},
+	"bookmarks.bookmark_forget":                       {"bookmarks"},
+	"bookmarks.bookmark_move":                         {"bookmarks"},
+	"bookmarks.bookmark_track":                        {"bookmarks"},
+	"bookmarks.bookmark_untrack":                      {"bookmarks"},
+	"bookmarks.cancel":                                {"bookmarks"},
+	"bookmarks.cycle_remotes":                         {"bookmarks"},
+	"bookmarks.cycle_remotes_back":                    {"bookmarks"},
+	"bookmarks.filter":                                {"bookmarks"},
+	"bookmarks.move_down":                             {"bookmarks"},
+	"bookmarks.move_up":                               {"bookmarks"},
+	"bookmarks.page_down":                             {"bookmarks"},
+	"bookmarks.page_up":                               {"bookmarks"},
+	"bookmarks.quit":                                  {"bookmarks"},
+	"choose.apply":                                    {"choose"},
+	"choose.cancel":                                   {"choose"},
+	"choose.filter":                                   {"choose"},
+	"choose.move_down":                                {"choose"},
+	"choose.move_up":                                  {"choose"},
+	"command_history.close":                           {"command_history"},
+	"command_history.delete_selected":                 {"command_history"},
+	"command_history.move_down":                       {"command_history"},
+	"command_history.move_up":                         {"command_history"},
+	"content_search.apply":                            {"content_search"},
+	"content_search.cancel":                           {"content_search"},
+	"content_search.move_down":                        {"content_search"},
+	"content_search.move_up":                          {"content_search"},
+	"content_search.next_field":                       {"content_search"},
+	"content_search.prev_field":                       {"content_search"},
+	"diff.ace_jump":                                   {"diff"},
+	"diff.half_page_down":                             {"diff"},
+	"diff.half_page_up":                               {"diff"},
+	"diff.left":                                       {"diff"},
+	"diff.move_bottom":                                {"diff"},
+	"diff.move_top":                                   {"diff"},
+	"diff.next_file":                                  {"diff"},
+	"diff.page_down":                                  {"diff"},
+	"diff.page_up":                                    {"diff"},
+	"diff.prev_file":                                  {"diff"},
+	"diff.right":                                      {"diff"},
+	"diff.scroll_down":                                {"diff"},
+	"diff.scroll_up":                                  {"diff"},
+	"diff.show":                                       {"diff"},
+	"diff.target_picker":                              {"diff"},
+	"diff.toggle_wrap":                                {"diff"},
+	"file_search.apply":                               {"file_search"},
+	"file_search.cancel":                              {"file_search"},
+	"file_search.edit":                                {"file_search"},
+	"file_search.move_down":                           {"file_search"},
+	"file_search.move_up":                             {"file_search"},
+	"file_search.page_down":                           {"file_search"},
+	"file_search.page_up":                             {"file_search"},
+	"file_search.preview_half_page_down":              {"file_search"},
+	"file_search.preview_half_page_up":                {"file_search"},
+	"file_search.toggle":                              {"file_search"},
+	"git.ace_jump":                                    {"git"},
+	"git.apply":                                       {"git"},
+	"git.cancel":                                      {"git"},
+	"git.cycle_remotes":                               {"git"},
+	"git.cycle_remotes_back":                          {"git"},
+	"git.fetch":                                       {"git"},
+	"git.filter":                                      {"git"},
+	"git.move_down":                                   {"git"},
+	"git.move_up":                                     {"git"},
+	"git.page_down":                                   {"git"},
+	"git.page_up":                                     {"git"},
+	"git.push":                                        {"git"},
+	"git.quit":                                        {"git"},
+	"help.apply":                                      {"help"},
+	"help.cancel":                                     {"help"},
+	"help.close":                                      {"help"},
+	"help.filter":                                     {"help"},
+	"help.move_bottom":                                {"help"},
+	"help.move_top":                                   {"help"},
+	"help.page_down":                                  {"help"},
+	"help.page_up":                                    {"help"},
+	"help.scroll_down":                                {"help"},
+	"help.scroll_up":                                  {"help"},
+	"input.apply":                                     {"input"},
+	"input.cancel":                                    {"input"},
+	"jump_labels.apply":                               {"jump_labels"},
+	"jump_labels.cancel":                              {"jump_labels"},
+	"notifications.close":                             {"notifications"},
+	"notifications.copy":                              {"notifications"},
+	"notifications.cycle_severity":                    {"notifications"},
+	"notifications.move_down":                         {"notifications"},
+	"notifications.move_up":                           {"notifications"},
+	"notifications.rerun":                             {"notifications"},
+	"oplog.ace_jump":                                  {"oplog"},
+	"oplog.close":                                     {"oplog"},
+	"oplog.diff":                                      {"oplog"},
+	"oplog.move_down":                                 {"oplog"},
+	"oplog.move_up":                                   {"oplog"},
+	"oplog.page_down":                                 {"oplog"},
+	"oplog.page_up":                                   {"oplog"},
+	"oplog.quick_search.clear":                        {"oplog.quick_search"},
+	"oplog.quick_search.next":                         {"oplog.quick_search"},
+	"oplog.quick_search.prev":                         {"oplog.quick_search"},
+	"oplog.quit":                                      {"oplog"},
+	"oplog.restore":                                   {"oplog"},
+	"oplog.revert":                                    {"oplog"},
+	"password.apply":                                  {"password"},
+	"password.cancel":                                 {"password"},
+	"redo.apply":                                      {"redo"},
+	"redo.cancel":                                     {"redo"},
+	"redo.next":                                       {"redo"},
+	"redo.prev":                                       {"redo"},
+	"revisions.abandon.ace_jump":                      {"revisions.abandon"},
+	"revisions.abandon.apply":                         {"revisions.abandon"},
+	"revisions.abandon.cancel":                        {"revisions.abandon"},
+	"revisions.abandon.force_apply":                   {"revisions.abandon"},
+	"revisions.abandon.jump_to_working_copy":          {"revisions.abandon"},
+	"revisions.abandon.select_descendants":            {"revisions.abandon"},
+	"revisions.abandon.toggle_select":                 {"revisions.abandon"},
+	"revisions.absorb.ace_jump":                       {"revisions.absorb"},
+	"revisions.absorb.apply":                          {"revisions.absorb"},
+	"revisions.absorb.cancel":                         {"revisions.absorb"},
+	"revisions.absorb.jump_to_working_copy":           {"revisions.absorb"},
+	"revisions.absorb.select_descendants":             {"revisions.absorb"},
+	"revisions.absorb.toggle_select":                  {"revisions.absorb"},
+	"revisions.ace_jump":                              {"revisions"},
+	"revisions.ace_jump.apply":                        {"revisions.ace_jump"},
+	"revisions.ace_jump.cancel":                       {"revisions.ace_jump"},
+	"revisions.apply":                                 {"revisions"},
+	"revisions.cancel":                                {"revisions"},
+	"revisions.commit":                                {"revisions"},
+	"revisions.describe":                              {"revisions"},
+	"revisions.details.absorb":                        {"revisions.details"},
+	"revisions.details.ace_jump":                      {"revisions.details"},
+	"revisions.details.cancel":                        {"revisions.details"},
+	"revisions.details.confirmation.apply":            {"revisions.details.confirmation"},
+	"revisions.details.confirmation.cancel":           {"revisions.details.confirmation"},
+	"revisions.details.confirmation.force_apply":      {"revisions.details.confirmation"},
+	"revisions.details.confirmation.next":             {"revisions.details.confirmation"},
+	"revisions.details.confirmation.prev":             {"revisions.details.confirmation"},
+	"revisions.details.cycle_sort":                    {"revisions.details"},
+	"revisions.details.diff":                          {"revisions.details"},
+	"revisions.details.filter":                        {"revisions.details"},
+	"revisions.details.filter_apply":                  {"revisions.details"},
+	"revisions.details.filter_cancel":                 {"revisions.details"},
+	"revisions.details.move_down":                     {"revisions.details"},
+	"revisions.details.move_up":                       {"revisions.details"},
+	"revisions.details.page_down":                     {"revisions.details"},
+	"revisions.details.page_up":                       {"revisions.details"},
+	"revisions.details.quit":                          {"revisions.details"},
+	"revisions.details.refresh":                       {"revisions.details"},
+	"revisions.details.restore":                       {"revisions.details"},
+	"revisions.details.revisions_changing_file":       {"revisions.details"},
+	"revisions.details.select_file":                   {"revisions.details"},
+	"revisions.details.split":                         {"revisions.details"},
+	"revisions.details.split_parallel":                {"revisions.details"},
+	"revisions.details.squash":                        {"revisions.details"},
+	"revisions.details.toggle_collapsed":              {"revisions.details"},
+	"revisions.details.toggle_select":                 {"revisions.details"},
+	"revisions.details.toggle_tree":                   {"revisions.details"},
+	"revisions.details.visual.cancel":                 {"revisions.details.visual"},
+	"revisions.details.visual.visual_mode":            {"revisions.details.visual"},
+	"revisions.details.visual_mode":                   {"revisions.details"},
+	"revisions.diff":                                  {"revisions"},
+	"revisions.diff_edit":                             {"revisions"},
+	"revisions.diff_range.apply":                      {"revisions.diff_range"},
+	"revisions.diff_range.cancel":                     {"revisions.diff_range"},
+	"revisions.diff_range.swap":                       {"revisions.diff_range"},
+	"revisions.diff_range.target_picker":              {"revisions.diff_range"},
+	"revisions.duplicate.ace_jump":                    {"revisions.duplicate"},
+	"revisions.duplicate.apply":                       {"revisions.duplicate"},
+	"revisions.duplicate.cancel":                      {"revisions.duplicate"},
+	"revisions.duplicate.force_apply":                 {"revisions.duplicate"},
+	"revisions.duplicate.jump_to_working_copy":        {"revisions.duplicate"},
+	"revisions.duplicate.set_target":                  {"revisions.duplicate"},
+	"revisions.duplicate.target_picker":               {"revisions.duplicate"},
+	"revisions.edit":                                  {"revisions"},
+	"revisions.evolog.apply":                          {"revisions.evolog"},
+	"revisions.evolog.cancel":                         {"revisions.evolog"},
+	"revisions.evolog.diff":                           {"revisions.evolog"},
+	"revisions.evolog.move_down":                      {"revisions.evolog"},
+	"revisions.evolog.move_up":                        {"revisions.evolog"},
+	"revisions.evolog.page_down":                      {"revisions.evolog"},
+	"revisions.evolog.page_up":                        {"revisions.evolog"},
+	"revisions.evolog.quit":                           {"revisions.evolog"},
+	"revisions.evolog.restore":                        {"revisions.evolog"},
+	"revisions.fix":                                   {"revisions"},
+	"revisions.force_apply":                           {"revisions"},
+	"revisions.force_edit":                            {"revisions"},
+	"revisions.force_fix":                             {"revisions"},
+	"revisions.go_to_bottom":                          {"revisions"},
+	"revisions.go_to_top":                             {"revisions"},
+	"revisions.inline_describe.accept":                {"revisions.inline_describe"},
+	"revisions.inline_describe.add_trailers":          {"revisions.inline_describe"},
+	"revisions.inline_describe.cancel":                {"revisions.inline_describe"},
+	"revisions.inline_describe.editor":                {"revisions.inline_describe"},
+	"revisions.inline_describe.force_accept":          {"revisions.inline_describe"},
+	"revisions.inline_describe.new_line":              {"revisions.inline_describe"},
+	"revisions.inline_describe.prefix_picker":         {"revisions.inline_describe"},
+	"revisions.jump_to_children":                      {"revisions"},
+	"revisions.jump_to_parent":                        {"revisions"},
+	"revisions.jump_to_working_copy":                  {"revisions"},
+	"revisions.metaedit.apply":                        {"revisions.metaedit"},
+	"revisions.metaedit.cancel":                       {"revisions.metaedit"},
+	"revisions.metaedit.force_apply":                  {"revisions.metaedit"},
+	"revisions.metaedit.next_field":                   {"revisions.metaedit"},
+	"revisions.metaedit.prev_field":                   {"revisions.metaedit"},
+	"revisions.metaedit.reset_author":                 {"revisions.metaedit"},
+	"revisions.move_down":                             {"revisions"},
+	"revisions.move_up":                               {"revisions"},
+	"revisions.narrow_to_selection":                   {"revisions"},
+	"revisions.new":                                   {"revisions"},
+	"revisions.new_between.apply":                     {"revisions.new_between"},
+	"revisions.new_between.cancel":                    {"revisions.new_between"},
+	"revisions.new_between.toggle_insert_before":      {"revisions.new_between"},
+	"revisions.next":                                  {"revisions"},
+	"revisions.next_conflict":                         {"revisions"},
+	"revisions.next_edit":                             {"revisions"},
+	"revisions.open_abandon":                          {"revisions"},
+	"revisions.open_absorb":                           {"revisions"},
+	"revisions.open_details":                          {"revisions"},
+	"revisions.open_diff_range":                       {"revisions"},
+	"revisions.open_duplicate":                        {"revisions"},
+	"revisions.open_evolog":                           {"revisions"},
+	"revisions.open_inline_describe":                  {"revisions"},
+	"revisions.open_metaedit":                         {"revisions"},
+	"revisions.open_new_between":                      {"revisions"},
+	"revisions.open_parallelize":                      {"revisions"},
+	"revisions.open_rebase":                           {"revisions"},
+	"revisions.open_revert":                           {"revisions"},
+	"revisions.open_set_bookmark":                     {"revisions"},
+	"revisions.open_set_parents":                      {"revisions"},
+	"revisions.open_simplify_parents":                 {"revisions"},
+	"revisions.open_squash":                           {"revisions"},
+	"revisions.page_down":                             {"revisions"},
+	"revisions.page_up":                               {"revisions"},
+	"revisions.parallelize.ace_jump":                  {"revisions.parallelize"},
+	"revisions.parallelize.apply":                     {"revisions.parallelize"},
+	"revisions.parallelize.cancel":                    {"revisions.parallelize"},
+	"revisions.parallelize.force_apply":               {"revisions.parallelize"},
+	"revisions.parallelize.jump_to_working_copy":      {"revisions.parallelize"},
+	"revisions.parallelize.toggle_select":             {"revisions.parallelize"},
+	"revisions.prev":                                  {"revisions"},
+	"revisions.prev_conflict":                         {"revisions"},
+	"revisions.prev_edit":                             {"revisions"},
+	"revisions.quick_search.clear":                    {"revisions.quick_search"},
+	"revisions.quick_search.input.apply":              {"revisions.quick_search.input"},
+	"revisions.quick_search.input.cancel":             {"revisions.quick_search.input"},
+	"revisions.quick_search.next":                     {"revisions.quick_search"},
+	"revisions.quick_search.prev":                     {"revisions.quick_search"},
+	"revisions.rebase.ace_jump":                       {"revisions.rebase"},
+	"revisions.rebase.apply":                          {"revisions.rebase"},
+	"revisions.rebase.cancel":                         {"revisions.rebase"},
+	"revisions.rebase.force_apply":                    {"revisions.rebase"},
+	"revisions.rebase.jump_to_working_copy":           {"revisions.rebase"},
+	"revisions.rebase.set_source":                     {"revisions.rebase"},
+	"revisions.rebase.set_target":                     {"revisions.rebase"},
+	"revisions.rebase.skip_emptied":                   {"revisions.rebase"},
+	"revisions.rebase.target_picker":                  {"revisions.rebase"},
+	"revisions.refresh":                               {"revisions"},
+	"revisions.repeat":                                {"revisions"},
+	"revisions.revert.apply":                          {"revisions.revert"},
+	"revisions.revert.cancel":                         {"revisions.revert"},
+	"revisions.revert.force_apply":                    {"revisions.revert"},
+	"revisions.revert.set_target":                     {"revisions.revert"},
+	"revisions.revert.target_picker":                  {"revisions.revert"},
+	"revisions.select_revset":                         {"revisions"},
+	"revisions.set_bookmark.apply":                    {"revisions.set_bookmark"},
+	"revisions.set_bookmark.autocomplete":             {"revisions.set_bookmark"},
+	"revisions.set_bookmark.autocomplete_back":        {"revisions.set_bookmark"},
+	"revisions.set_bookmark.cancel":                   {"revisions.set_bookmark"},
+	"revisions.set_parents.ace_jump":                  {"revisions.set_parents"},
+	"revisions.set_parents.apply":                     {"revisions.set_parents"},
+	"revisions.set_parents.cancel":                    {"revisions.set_parents"},
+	"revisions.set_parents.jump_to_working_copy":      {"revisions.set_parents"},
+	"revisions.set_parents.toggle_select":             {"revisions.set_parents"},
+	"revisions.simplify_parents.ace_jump":             {"revisions.simplify_parents"},
+	"revisions.simplify_parents.apply":                {"revisions.simplify_parents"},
+	"revisions.simplify_parents.cancel":               {"revisions.simplify_parents"},
+	"revisions.simplify_parents.force_apply":          {"revisions.simplify_parents"},
+	"revisions.simplify_parents.jump_to_working_copy": {"revisions.simplify_parents"},
+	"revisions.simplify_parents.toggle_descendants":   {"revisions.simplify_parents"},
+	"revisions.simplify_parents.toggle_select":        {"revisions.simplify_parents"},
+	"revisions.split":                                 {"revisions"},
+	"revisions.split_parallel":                        {"revisions"},
+	"revisions.squash.ace_jump":                       {"revisions.squash"},
+	"revisions.squash.apply":                          {"revisions.squash"},
+	"revisions.squash.cancel":                         {"revisions.squash"},
+	"revisions.squash.force_apply":                    {"revisions.squash"},
+	"revisions.squash.interactive":                    {"revisions.squash"},
+	"revisions.squash.jump_to_working_copy":           {"revisions.squash"},
+	"revisions.squash.keep_emptied":                   {"revisions.squash"},
+	"revisions.squash.target_picker":                  {"revisions.squash"},
+	"revisions.squash.use_destination_msg":            {"revisions.squash"},
+	"revisions.target_picker.apply":                   {"revisions.target_picker"},
+	"revisions.target_picker.autocomplete":            {"revisions.target_picker"},
+	"revisions.target_picker.autocomplete_back":       {"revisions.target_picker"},
+	"revisions.target_picker.cancel":                  {"revisions.target_picker"},
+	"revisions.target_picker.force_apply":             {"revisions.target_picker"},
+	"revisions.target_picker.move_down":               {"revisions.target_picker"},
+	"revisions.target_picker.move_up":                 {"revisions.target_picker"},
+	"revisions.toggle_select":                         {"revisions"},
+	"revisions.visual.cancel":                         {"revisions.visual"},
+	"revisions.visual.visual_mode":                    {"revisions.visual"},
+	"revisions.visual_mode":                           {"revisions"},
+	"revset.apply":                                    {"revset"},
+	"revset.autocomplete":                             {"revset"},
+	"revset.autocomplete_back":                        {"revset"},
+	"revset.cancel":                                   {"revset"},
+	"revset.edit":                                     {"revset"},
+	"revset.insert_ancestors":                         {"revset"},
+	"revset.insert_descendants":                       {"revset"},
+	"revset.insert_heads":                             {"revset"},
+	"revset.insert_range":                             {"revset"},
+	"revset.insert_roots":                             {"revset"},
+	"revset.insert_selection":                         {"revset"},
+	"revset.move_down":                                {"revset"},
+	"revset.move_up":                                  {"revset"},
+	"revset.reset":                                    {"revset"},
+	"revset.set":                                      {"revset"},
+	"status.input.apply":                              {"status.input"},
+	"status.input.autocomplete":                       {"status.input"},
+	"status.input.cancel":                             {"status.input"},
+	"status.input.move_down":                          {"status.input"},
+	"status.input.move_up":                            {"status.input"},
+	"status.input.page_down":                          {"status.input"},
+	"status.input.page_up":                            {"status.input"},
+	"theme_browser.apply":                             {"theme_browser"},
+	"theme_browser.cancel":                            {"theme_browser"},
+	"theme_browser.edit":                              {"theme_browser"},
+	"theme_browser.move_down":                         {"theme_browser"},
+	"theme_browser.move_up":                           {"theme_browser"},
+	"theme_editor.apply":                              {"theme_editor"},
+	"theme_editor.cancel":                             {"theme_editor"},
+	"theme_editor.edit_bg":                            {"theme_editor"},
+	"theme_editor.edit_fg":                            {"theme_editor"},
+	"theme_editor.move_down":                          {"theme_editor"},
+	"theme_editor.move_up":                            {"theme_editor"},
+	"theme_editor.save":                               {"theme_editor"},
+	"theme_editor.toggle_bold":                        {"theme_editor"},
+	"ui.cancel":                                       {"ui"},
+	"ui.change_theme":                                 {"ui"},
+	"ui.command_palette":                              {"ui"},
+	"ui.exec_jj":                                      {"ui"},
+	"ui.exec_shell":                                   {"ui"},
+	"ui.expand_status":                                {"ui"},
+	"ui.file_search_toggle":                           {"ui"},
+	"ui.open_bookmarks":                               {"ui"},
+	"ui.open_command_history":                         {"ui"},
+	"ui.open_content_search":                          {"ui"},
+	"ui.open_git":                                     {"ui"},
+	"ui.open_help":                                    {"ui"},
+	"ui.open_notifications":                           {"ui"},
+	"ui.open_oplog":                                   {"ui"},
+	"ui.open_redo":                                    {"ui"},
+	"ui.open_revset":                                  {"ui"},
+	"ui.open_theme_browser":                           {"ui"},
+	"ui.open_theme_editor":                            {"ui"},
+	"ui.open_undo":                                    {"ui"},
+	"ui.preview.show":                                 {"ui.preview"},
+	"ui.preview_expand":                               {"ui"},
+	"ui.preview_half_page_down":                       {"ui"},
+	"ui.preview_half_page_up":                         {"ui"},
+	"ui.preview_scroll_down":                          {"ui"},
+	"ui.preview_scroll_up":                            {"ui"},
+	"ui.preview_shrink":                               {"ui"},
+	"ui.preview_toggle":                               {"ui"},
+	"ui.preview_toggle_bottom":                        {"ui"},
+	"ui.quick_search":                                 {"ui"},
+	"ui.quit":                                         {"ui"},
+	"ui.revision_finder":                              {"ui"},
+	"ui.suspend":                                      {"ui"},
+	"undo.apply":                                      {"undo"},
+	"undo.cancel":                                     {"undo"},
+	"undo.next":                                       {"undo"},
+	"undo.prev":                                       {"undo"},
 }
 
 var builtInActionArgSchemas = map[string]map[string]string{
@@ -326,9 +417,30 @@ var builtInActionArgSchemas = map[string]map[string]string{
 	"revisions.inline_describe.accept": {
 		"force": "bool",
 	},
+	"revisions.metaedit.apply": {
+		"force": "bool",
+	},
+	"revisions.metaedit.reset_author": {
+		"force": "bool",
+	},
+	"revisions.next": {
+		"count": "int",
+	},
+	"revisions.next_edit": {
+		"count": "int",
+	},
 	"revisions.open_set_bookmark": {
 		"value": "string",
 	},
+	"revisions.parallelize.apply": {
+		"force": "bool",
+	},
+	"revisions.prev": {
+		"count": "int",
+	},
+	"revisions.prev_edit": {
+		"count": "int",
+	},
 	"revisions.rebase.apply": {
 		"force": "bool",
 	},
@@ -344,6 +456,12 @@ var builtInActionArgSchemas = map[string]map[string]string{
 	"revisions.revert.set_target": {
 		"target": "enum:onto|after|before|insert",
 	},
+	"revisions.select_revset": {
+		"revset": "string",
+	},
+	"revisions.simplify_parents.apply": {
+		"force": "bool",
+	},
 	"revisions.squash.apply": {
 		"force": "bool",
 	},
@@ -377,6 +495,393 @@ var builtInActionRequiredArgs = map[string][]string{
 	"ui.preview.show":                    {"content"},
 }
 
+var builtInActionCommands = map[string]string{
+	"bookmarks.ace_jump":                              "StartAceJump",
+	"bookmarks.apply":                                 "Apply",
+	"bookmarks.bookmark_delete":                       "BookmarksFilter{Kind:BookmarksFilterDelete}",
+	"bookmarks.bookmark_forget":                       "BookmarksFilter{Kind:BookmarksFilterForget}",
+	"bookmarks.bookmark_move":                         "BookmarksFilter{Kind:BookmarksFilterMove}",
+	"bookmarks.bookmark_track":                        "BookmarksFilter{Kind:BookmarksFilterTrack}",
+	"bookmarks.bookmark_untrack":                      "BookmarksFilter{Kind:BookmarksFilterUntrack}",
+	"bookmarks.cancel":                                "Cancel",
+	"bookmarks.cycle_remotes":                         "BookmarksCycleRemotes{Delta:1}",
+	"bookmarks.cycle_remotes_back":                    "BookmarksCycleRemotes{Delta:-1}",
+	"bookmarks.filter":                                "BookmarksOpenFilter",
+	"bookmarks.move_down":                             "BookmarksNavigate{Delta:1}",
+	"bookmarks.move_up":                               "BookmarksNavigate{Delta:-1}",
+	"bookmarks.page_down":                             "BookmarksNavigate{Delta:1,IsPage:true}",
+	"bookmarks.page_up":                               "BookmarksNavigate{Delta:-1,IsPage:true}",
+	"bookmarks.quit":                                  "Quit",
+	"choose.apply":                                    "ChooseApply",
+	"choose.cancel":                                   "ChooseCancel",
+	"choose.filter":                                   "ChooseOpenFilter",
+	"choose.move_down":                                "ChooseNavigate{Delta:1}",
+	"choose.move_up":                                  "ChooseNavigate{Delta:-1}",
+	"command_history.close":                           "CommandHistoryClose",
+	"command_history.delete_selected":                 "CommandHistoryDeleteSelected",
+	"command_history.move_down":                       "CommandHistoryNavigate{Delta:1}",
+	"command_history.move_up":                         "CommandHistoryNavigate{Delta:-1}",
+	"content_search.apply":                            "Apply",
+	"content_search.cancel":                           "Cancel",
+	"content_search.move_down":                        "ContentSearchNavigate{Delta:1}",
+	"content_search.move_up":                          "ContentSearchNavigate{Delta:-1}",
+	"content_search.next_field":                       "ContentSearchFocusField{Delta:1}",
+	"content_search.prev_field":                       "ContentSearchFocusField{Delta:-1}",
+	"diff.ace_jump":                                   "StartAceJump",
+	"diff.half_page_down":                             "DiffScroll{Kind:DiffHalfPageDown}",
+	"diff.half_page_up":                               "DiffScroll{Kind:DiffHalfPageUp}",
+	"diff.left":                                       "DiffScrollHorizontal{Kind:DiffScrollLeft}",
+	"diff.move_bottom":                                "DiffScroll{Kind:DiffMoveBottom}",
+	"diff.move_top":                                   "DiffScroll{Kind:DiffMoveTop}",
+	"diff.next_file":                                  "DiffFileNavigate{Delta:1}",
+	"diff.page_down":                                  "DiffScroll{Kind:DiffPageDown}",
+	"diff.page_up":                                    "DiffScroll{Kind:DiffPageUp}",
+	"diff.prev_file":                                  "DiffFileNavigate{Delta:-1}",
+	"diff.right":                                      "DiffScrollHorizontal{Kind:DiffScrollRight}",
+	"diff.scroll_down":                                "DiffScroll{Kind:DiffScrollDown}",
+	"diff.scroll_up":                                  "DiffScroll{Kind:DiffScrollUp}",
+	"diff.show":                                       "DiffShow{Content:$string(content)}",
+	"diff.target_picker":                              "DiffOpenTargetPicker",
+	"diff.toggle_wrap":                                "DiffToggleWrap",
+	"file_search.apply":                               "Apply",
+	"file_search.cancel":                              "Cancel",
+	"file_search.edit":                                "FileSearchEdit",
+	"file_search.move_down":                           "FileSearchNavigate{Delta:-1}",
+	"file_search.move_up":                             "FileSearchNavigate{Delta:1}",
+	"file_search.page_down":                           "FileSearchPreviewScroll{Kind:PreviewPageDown}",
+	"file_search.page_up":                             "FileSearchPreviewScroll{Kind:PreviewPageUp}",
+	"file_search.preview_half_page_down":              "FileSearchPreviewScroll{Kind:PreviewHalfPageDown}",
+	"file_search.preview_half_page_up":                "FileSearchPreviewScroll{Kind:PreviewHalfPageUp}",
+	"file_search.toggle":                              "FileSearchTogglePreview",
+	"git.ace_jump":                                    "StartAceJump",
+	"git.apply":                                       "Apply",
+	"git.cancel":                                      "Cancel",
+	"git.cycle_remotes":                               "GitCycleRemotes{Delta:1}",
+	"git.cycle_remotes_back":                          "GitCycleRemotes{Delta:-1}",
+	"git.fetch":                                       "GitFilter{Kind:GitFilterFetch}",
+	"git.filter":                                      "GitOpenFilter",
+	"git.move_down":                                   "GitNavigate{Delta:1}",
+	"git.move_up":                                     "GitNavigate{Delta:-1}",
+	"git.page_down":                                   "GitNavigate{Delta:1,IsPage:true}",
+	"git.page_up":                                     "GitNavigate{Delta:-1,IsPage:true}",
+	"git.push":                                        "GitFilter{Kind:GitFilterPush}",
+	"git.quit":                                        "Quit",
+	"help.apply":                                      "Apply",
+	"help.cancel":                                     "Cancel",
+	"help.close":                                      "HelpClose",
+	"help.filter":                                     "HelpFilter",
+	"help.move_bottom":                                "HelpScroll{Delta:999999}",
+	"help.move_top":                                   "HelpScroll{Delta:0}",
+	"help.page_down":                                  "HelpScroll{Delta:10}",
+	"help.page_up":                                    "HelpScroll{Delta:-10}",
+	"help.scroll_down":                                "HelpScroll{Delta:1}",
+	"help.scroll_up":                                  "HelpScroll{Delta:-1}",
+	"input.apply":                                     "Apply",
+	"input.cancel":                                    "Cancel",
+	"jump_labels.apply":                               "Apply",
+	"jump_labels.cancel":                              "Cancel",
+	"notifications.close":                             "Cancel",
+	"notifications.copy":                              "NotificationsCopy",
+	"notifications.cycle_severity":                    "NotificationsCycleSeverity",
+	"notifications.move_down":                         "NotificationsNavigate{Delta:1}",
+	"notifications.move_up":                           "NotificationsNavigate{Delta:-1}",
+	"notifications.rerun":                             "NotificationsRerun",
+	"oplog.ace_jump":                                  "StartAceJump",
+	"oplog.close":                                     "OpLogClose",
+	"oplog.diff":                                      "OpLogShowDiff",
+	"oplog.move_down":                                 "OpLogNavigate{Delta:1}",
+	"oplog.move_up":                                   "OpLogNavigate{Delta:-1}",
+	"oplog.page_down":                                 "OpLogNavigate{Delta:1,IsPage:true}",
+	"oplog.page_up":                                   "OpLogNavigate{Delta:-1,IsPage:true}",
+	"oplog.quick_search.clear":                        "OpLogQuickSearchClear",
+	"oplog.quick_search.next":                         "QuickSearchCycle",
+	"oplog.quick_search.prev":                         "QuickSearchCycle{Reverse:true}",
+	"oplog.quit":                                      "Quit",
+	"oplog.restore":                                   "OpLogRestore",
+	"oplog.revert":                                    "OpLogRevert",
+	"password.apply":                                  "Apply",
+	"password.cancel":                                 "Cancel",
+	"redo.apply":                                      "Apply",
+	"redo.cancel":                                     "Cancel",
+	"redo.next":                                       "OptionSelect{Delta:1}",
+	"redo.prev":                                       "OptionSelect{Delta:-1}",
+	"revisions.abandon.ace_jump":                      "StartAceJump",
+	"revisions.abandon.apply":                         "Apply{Force:$bool(force)}",
+	"revisions.abandon.cancel":                        "Cancel",
+	"revisions.abandon.force_apply":                   "Apply{Force:true}",
+	"revisions.abandon.jump_to_working_copy":          "Navigate{Target:TargetWorkingCopy}",
+	"revisions.abandon.select_descendants":            "AbandonSelectDescendants",
+	"revisions.abandon.toggle_select":                 "AbandonToggleSelect",
+	"revisions.absorb.ace_jump":                       "StartAceJump",
+	"revisions.absorb.apply":                          "Apply",
+	"revisions.absorb.cancel":                         "Cancel",
+	"revisions.absorb.jump_to_working_copy":           "Navigate{Target:TargetWorkingCopy}",
+	"revisions.absorb.select_descendants":             "AbsorbSelectDescendants",
+	"revisions.absorb.toggle_select":                  "AbsorbToggleSelect",
+	"revisions.ace_jump":                              "StartAceJump",
+	"revisions.ace_jump.apply":                        "Apply",
+	"revisions.ace_jump.cancel":                       "Cancel",
+	"revisions.apply":                                 "Apply{Force:$bool(force)}",
+	"revisions.cancel":                                "Cancel",
+	"revisions.commit":                                "CommitWorkingCopy",
+	"revisions.describe":                              "Describe",
+	"revisions.details.absorb":                        "DetailsAbsorb",
+	"revisions.details.ace_jump":                      "StartAceJump",
+	"revisions.details.cancel":                        "DetailsClose",
+	"revisions.details.confirmation.apply":            "Apply{Force:$bool(force)}",
+	"revisions.details.confirmation.cancel":           "Cancel",
+	"revisions.details.confirmation.force_apply":      "Apply{Force:true}",
+	"revisions.details.confirmation.next":             "OptionSelect{Delta:1}",
+	"revisions.details.confirmation.prev":             "OptionSelect{Delta:-1}",
+	"revisions.details.cycle_sort":                    "DetailsCycleSort",
+	"revisions.details.diff":                          "DetailsDiff",
+	"revisions.details.filter":                        "DetailsOpenFilter",
+	"revisions.details.filter_apply":                  "DetailsApplyFilter",
+	"revisions.details.filter_cancel":                 "DetailsCancelFilter",
+	"revisions.details.move_down":                     "DetailsNavigate{Delta:1}",
+	"revisions.details.move_up":                       "DetailsNavigate{Delta:-1}",
+	"revisions.details.page_down":                     "DetailsNavigate{Delta:1,IsPage:true}",
+	"revisions.details.page_up":                       "DetailsNavigate{Delta:-1,IsPage:true}",
+	"revisions.details.quit":                          "Quit",
+	"revisions.details.refresh":                       "Refresh",
+	"revisions.details.restore":                       "DetailsRestore",
+	"revisions.details.revisions_changing_file":       "DetailsRevisionsChangingFile",
+	"revisions.details.select_file":                   "DetailsSelectFile{File:$string(file)}",
+	"revisions.details.split":                         "DetailsSplit",
+	"revisions.details.split_parallel":                "DetailsSplit{IsParallel:true}",
+	"revisions.details.squash":                        "DetailsSquash",
+	"revisions.details.toggle_collapsed":              "DetailsToggleCollapsed",
+	"revisions.details.toggle_select":                 "DetailsToggleSelect",
+	"revisions.details.toggle_tree":                   "DetailsToggleTree",
+	"revisions.details.visual.cancel":                 "Cancel",
+	"revisions.details.visual.visual_mode":            "DetailsVisualMode",
+	"revisions.details.visual_mode":                   "DetailsVisualMode",
+	"revisions.diff":                                  "ShowDiff",
+	"revisions.diff_edit":                             "DiffEdit",
+	"revisions.diff_range.apply":                      "Apply",
+	"revisions.diff_range.cancel":                     "Cancel",
+	"revisions.diff_range.swap":                       "DiffRangeSwap",
+	"revisions.diff_range.target_picker":              "DiffRangeOpenTargetPicker{Target:$enum(target)}",
+	"revisions.duplicate.ace_jump":                    "StartAceJump",
+	"revisions.duplicate.apply":                       "Apply{Force:$bool(force)}",
+	"revisions.duplicate.cancel":                      "Cancel",
+	"revisions.duplicate.force_apply":                 "Apply{Force:true}",
+	"revisions.duplicate.jump_to_working_copy":        "Navigate{Target:TargetWorkingCopy}",
+	"revisions.duplicate.set_target":                  "DuplicateSetTarget{Target:$enum(target)}",
+	"revisions.duplicate.target_picker":               "DuplicateOpenTargetPicker",
+	"revisions.edit":                                  "StartEdit",
+	"revisions.evolog.apply":                          "Apply{Force:$bool(force)}",
+	"revisions.evolog.cancel":                         "Cancel",
+	"revisions.evolog.diff":                           "EvologDiff",
+	"revisions.evolog.move_down":                      "EvologNavigate{Delta:1}",
+	"revisions.evolog.move_up":                        "EvologNavigate{Delta:-1}",
+	"revisions.evolog.page_down":                      "EvologNavigate{Delta:1,IsPage:true}",
+	"revisions.evolog.page_up":                        "EvologNavigate{Delta:-1,IsPage:true}",
+	"revisions.evolog.quit":                           "Quit",
+	"revisions.evolog.restore":                        "EvologRestore",
+	"revisions.fix":                                   "Fix",
+	"revisions.force_apply":                           "Apply{Force:true}",
+	"revisions.force_edit":                            "StartEdit{IgnoreImmutable:true}",
+	"revisions.force_fix":                             "Fix{IgnoreImmutable:true}",
+	"revisions.go_to_bottom":                          "GoToBottom",
+	"revisions.go_to_top":                             "GoToTop",
+	"revisions.inline_describe.accept":                "InlineDescribeAccept{Force:$bool(force)}",
+	"revisions.inline_describe.add_trailers":          "InlineDescribeAddTrailers",
+	"revisions.inline_describe.cancel":                "Cancel",
+	"revisions.inline_describe.editor":                "InlineDescribeEditor",
+	"revisions.inline_describe.force_accept":          "InlineDescribeAccept{Force:true}",
+	"revisions.inline_describe.new_line":              "InlineDescribeNewLine",
+	"revisions.inline_describe.prefix_picker":         "InlineDescribePrefixPicker",
+	"revisions.jump_to_children":                      "Navigate{Target:TargetChild}",
+	"revisions.jump_to_parent":                        "Navigate{Target:TargetParent}",
+	"revisions.jump_to_working_copy":                  "Navigate{Target:TargetWorkingCopy}",
+	"revisions.metaedit.apply":                        "Apply{Force:$bool(force)}",
+	"revisions.metaedit.cancel":                       "Cancel",
+	"revisions.metaedit.force_apply":                  "Apply{Force:true}",
+	"revisions.metaedit.next_field":                   "MetaEditFocusField{Delta:1}",
+	"revisions.metaedit.prev_field":                   "MetaEditFocusField{Delta:-1}",
+	"revisions.metaedit.reset_author":                 "MetaEditResetAuthor{Force:$bool(force)}",
+	"revisions.move_down":                             "Navigate{Delta:1}",
+	"revisions.move_up":                               "Navigate{Delta:-1}",
+	"revisions.narrow_to_selection":                   "NarrowToSelection",
+	"revisions.new":                                   "StartNew",
+	"revisions.new_between.apply":                     "Apply",
+	"revisions.new_between.cancel":                    "Cancel",
+	"revisions.new_between.toggle_insert_before":      "NewBetweenToggleInsertBefore",
+	"revisions.next":                                  "NextRevision{Count:$int(count)}",
+	"revisions.next_conflict":                         "NextRevision{Conflict:true}",
+	"revisions.next_edit":                             "NextRevision{Count:$int(count),Edit:true}",
+	"revisions.open_abandon":                          "OpenAbandon",
+	"revisions.open_absorb":                           "OpenAbsorb",
+	"revisions.open_details":                          "OpenDetails",
+	"revisions.open_diff_range":                       "OpenDiffRange",
+	"revisions.open_duplicate":                        "OpenDuplicate",
+	"revisions.open_evolog":                           "OpenEvolog",
+	"revisions.open_inline_describe":                  "OpenInlineDescribe",
+	"revisions.open_metaedit":                         "OpenMetaEdit",
+	"revisions.open_new_between":                      "OpenNewBetween",
+	"revisions.open_parallelize":                      "OpenParallelize",
+	"revisions.open_rebase":                           "OpenRebase",
+	"revisions.open_revert":                           "OpenRevert",
+	"revisions.open_set_bookmark":                     "OpenSetBookmark{Value:$string?(value)}",
+	"revisions.open_set_parents":                      "OpenSetParents",
+	"revisions.open_simplify_parents":                 "OpenSimplifyParents",
+	"revisions.open_squash":                           "OpenSquash",
+	"revisions.page_down":                             "Navigate{Delta:1,IsPage:true}",
+	"revisions.page_up":                               "Navigate{Delta:-1,IsPage:true}",
+	"revisions.parallelize.ace_jump":                  "StartAceJump",
+	"revisions.parallelize.apply":                     "Apply{Force:$bool(force)}",
+	"revisions.parallelize.cancel":                    "Cancel",
+	"revisions.parallelize.force_apply":               "Apply{Force:true}",
+	"revisions.parallelize.jump_to_working_copy":      "Navigate{Target:TargetWorkingCopy}",
+	"revisions.parallelize.toggle_select":             "ParallelizeToggleSelect",
+	"revisions.prev":                                  "PrevRevision{Count:$int(count)}",
+	"revisions.prev_conflict":                         "PrevRevision{Conflict:true}",
+	"revisions.prev_edit":                             "PrevRevision{Count:$int(count),Edit:true}",
+	"revisions.quick_search.clear":                    "RevisionsQuickSearchClear",
+	"revisions.quick_search.input.apply":              "Apply",
+	"revisions.quick_search.input.cancel":             "Cancel",
+	"revisions.quick_search.next":                     "QuickSearchCycle",
+	"revisions.quick_search.prev":                     "QuickSearchCycle{Reverse:true}",
+	"revisions.rebase.ace_jump":                       "StartAceJump",
+	"revisions.rebase.apply":                          "Apply{Force:$bool(force)}",
+	"revisions.rebase.cancel":                         "Cancel",
+	"revisions.rebase.force_apply":                    "Apply{Force:true}",
+	"revisions.rebase.jump_to_working_copy":           "Navigate{Target:TargetWorkingCopy}",
+	"revisions.rebase.set_source":                     "RebaseSetSource{Source:$enum(source)}",
+	"revisions.rebase.set_target":                     "RebaseSetTarget{Target:$enum(target)}",
+	"revisions.rebase.skip_emptied":                   "RebaseToggleSkipEmptied",
+	"revisions.rebase.target_picker":                  "RebaseOpenTargetPicker",
+	"revisions.refresh":                               "Refresh",
+	"revisions.repeat":                                "RepeatLastOperation",
+	"revisions.revert.apply":                          "Apply{Force:$bool(force)}",
+	"revisions.revert.cancel":                         "Cancel",
+	"revisions.revert.force_apply":                    "Apply{Force:true}",
+	"revisions.revert.set_target":                     "RevertSetTarget{Target:$enum(target)}",
+	"revisions.revert.target_picker":                  "RevertOpenTargetPicker",
+	"revisions.select_revset":                         "RevisionsSelectRevset{Revset:$string?(revset)}",
+	"revisions.set_bookmark.apply":                    "Apply",
+	"revisions.set_bookmark.autocomplete":             "AutocompleteCycle",
+	"revisions.set_bookmark.autocomplete_back":        "AutocompleteCycle{Reverse:true}",
+	"revisions.set_bookmark.cancel":                   "Cancel",
+	"revisions.set_parents.ace_jump":                  "StartAceJump",
+	"revisions.set_parents.apply":                     "Apply",
+	"revisions.set_parents.cancel":                    "Cancel",
+	"revisions.set_parents.jump_to_working_copy":      "Navigate{Target:TargetWorkingCopy}",
+	"revisions.set_parents.toggle_select":             "SetParentsToggleSelect",
+	"revisions.simplify_parents.ace_jump":             "StartAceJump",
+	"revisions.simplify_parents.apply":                "Apply{Force:$bool(force)}",
+	"revisions.simplify_parents.cancel":               "Cancel",
+	"revisions.simplify_parents.force_apply":          "Apply{Force:true}",
+	"revisions.simplify_parents.jump_to_working_copy": "Navigate{Target:TargetWorkingCopy}",
+	"revisions.simplify_parents.toggle_descendants":   "SimplifyParentsToggleDescendants",
+	"revisions.simplify_parents.toggle_select":        "SimplifyParentsToggleSelect",
+	"revisions.split":                                 "StartSplit",
+	"revisions.split_parallel":                        "StartSplit{IsParallel:true}",
+	"revisions.squash.ace_jump":                       "StartAceJump",
+	"revisions.squash.apply":                          "Apply{Force:$bool(force)}",
+	"revisions.squash.cancel":                         "Cancel",
+	"revisions.squash.force_apply":                    "Apply{Force:true}",
+	"revisions.squash.interactive":                    "SquashToggleOption{Option:SquashOptionInteractive}",
+	"revisions.squash.jump_to_working_copy":           "Navigate{Target:TargetWorkingCopy}",
+	"revisions.squash.keep_emptied":                   "SquashToggleOption{Option:SquashOptionKeepEmptied}",
+	"revisions.squash.target_picker":                  "SquashOpenTargetPicker",
+	"revisions.squash.use_destination_msg":            "SquashToggleOption{Option:SquashOptionUseDestinationMessage}",
+	"revisions.target_picker.apply":                   "TargetPickerApply{Force:$bool(force)}",
+	"revisions.target_picker.autocomplete":            "AutocompleteCycle",
+	"revisions.target_picker.autocomplete_back":       "AutocompleteCycle{Reverse:true}",
+	"revisions.target_picker.cancel":                  "TargetPickerCancel",
+	"revisions.target_picker.force_apply":             "TargetPickerApply{Force:true}",
+	"revisions.target_picker.move_down":               "TargetPickerNavigate{Delta:1}",
+	"revisions.target_picker.move_up":                 "TargetPickerNavigate{Delta:-1}",
+	"revisions.toggle_select":                         "RevisionsToggleSelect",
+	"revisions.visual.cancel":                         "Cancel",
+	"revisions.visual.visual_mode":                    "RevisionsVisualMode",
+	"revisions.visual_mode":                           "RevisionsVisualMode",
+	"revset.apply":                                    "Apply",
+	"revset.autocomplete":                             "CompletionCycle",
+	"revset.autocomplete_back":                        "CompletionCycle{Reverse:true}",
+	"revset.cancel":                                   "Cancel",
+	"revset.edit":                                     "Edit{Clear:$bool(clear)}",
+	"revset.insert_ancestors":                         "InsertSelectionRevset{Kind:SelectionRevsetAncestors}",
+	"revset.insert_descendants":                       "InsertSelectionRevset{Kind:SelectionRevsetDescendants}",
+	"revset.insert_heads":                             "InsertSelectionRevset{Kind:SelectionRevsetHeads}",
+	"revset.insert_range":                             "InsertSelectionRevset{Kind:SelectionRevsetRange}",
+	"revset.insert_roots":                             "InsertSelectionRevset{Kind:SelectionRevsetRoots}",
+	"revset.insert_selection":                         "InsertSelectionRevset{Kind:SelectionRevsetSelected}",
+	"revset.move_down":                                "CompletionMove{Delta:1}",
+	"revset.move_up":                                  "CompletionMove{Delta:-1}",
+	"revset.reset":                                    "Reset",
+	"revset.set":                                      "Set{Value:$string(value)}",
+	"status.input.apply":                              "Apply",
+	"status.input.autocomplete":                       "SuggestCycle",
+	"status.input.cancel":                             "Cancel",
+	"status.input.move_down":                          "SuggestNavigate{Delta:-1}",
+	"status.input.move_up":                            "SuggestNavigate{Delta:1}",
+	"status.input.page_down":                          "SuggestNavigate{Delta:-1}",
+	"status.input.page_up":                            "SuggestNavigate{Delta:1}",
+	"theme_browser.apply":                             "Apply",
+	"theme_browser.cancel":                            "Cancel",
+	"theme_browser.edit":                              "ThemeBrowserEdit",
+	"theme_browser.move_down":                         "ThemeBrowserNavigate{Delta:1}",
+	"theme_browser.move_up":                           "ThemeBrowserNavigate{Delta:-1}",
+	"theme_editor.apply":                              "Apply",
+	"theme_editor.cancel":                             "Cancel",
+	"theme_editor.edit_bg":                            "ThemeEditorEdit{Background:true}",
+	"theme_editor.edit_fg":                            "ThemeEditorEdit",
+	"theme_editor.move_down":                          "ThemeEditorNavigate{Delta:1}",
+	"theme_editor.move_up":                            "ThemeEditorNavigate{Delta:-1}",
+	"theme_editor.save":                               "ThemeEditorSave",
+	"theme_editor.toggle_bold":                        "ThemeEditorToggleBold",
+	"ui.cancel":                                       "Cancel",
+	"ui.change_theme":                                 "ChangeTheme{Name:$string(name)}",
+	"ui.command_palette":                              "CommandPaletteToggle",
+	"ui.exec_jj":                                      "ExecJJ",
+	"ui.exec_shell":                                   "ExecShell",
+	"ui.expand_status":                                "ExpandStatusToggle",
+	"ui.file_search_toggle":                           "FileSearchToggle",
+	"ui.open_bookmarks":                               "OpenBookmarks",
+	"ui.open_command_history":                         "CommandHistoryToggle",
+	"ui.open_content_search":                          "OpenContentSearch",
+	"ui.open_git":                                     "OpenGit",
+	"ui.open_help":                                    "OpenHelp",
+	"ui.open_notifications":                           "OpenNotifications",
+	"ui.open_oplog":                                   "OpLogOpen",
+	"ui.open_redo":                                    "Redo",
+	"ui.open_revset":                                  "Edit{Clear:true}",
+	"ui.open_theme_browser":                           "OpenThemeBrowser",
+	"ui.open_theme_editor":                            "OpenThemeEditor",
+	"ui.open_undo":                                    "Undo",
+	"ui.preview.show":                                 "PreviewShow{Content:$string(content)}",
+	"ui.preview_expand":                               "PreviewExpand",
+	"ui.preview_half_page_down":                       "PreviewScroll{Kind:PreviewHalfPageDown}",
+	"ui.preview_half_page_up":                         "PreviewScroll{Kind:PreviewHalfPageUp}",
+	"ui.preview_scroll_down":                          "PreviewScroll{Kind:PreviewScrollDown}",
+	"ui.preview_scroll_up":                            "PreviewScroll{Kind:PreviewScrollUp}",
+	"ui.preview_shrink":                               "PreviewShrink",
+	"ui.preview_toggle":                               "PreviewToggle",
+	"ui.preview_toggle_bottom":                        "PreviewToggleBottom",
+	"ui.quick_search":                                 "QuickSearch",
+	"ui.quit":                                         "Quit",
+	"ui.revision_finder":                              "RevisionFinderToggle",
+	"ui.suspend":                                      "Suspend",
+	"undo.apply":                                      "Apply",
+	"undo.cancel":                                     "Cancel",
+	"undo.next":                                       "OptionSelect{Delta:1}",
+	"undo.prev":                                       "OptionSelect{Delta:-1}",
+}
+
+var builtInActionHides = map[string][]string{
+	"choose.cancel":                   {"ui.cancel"},
+	"command_history.close":           {"ui.cancel"},
+	"oplog.close":                     {"ui.cancel"},
+	"revisions.details.cancel":        {"ui.cancel"},
+	"revisions.details.filter_cancel": {"revisions.details.cancel", "ui.cancel"},
+	"revisions.details.visual.cancel": {"revisions.details.cancel"},
+	"revisions.quick_search.clear":    {"revisions.cancel", "ui.cancel"},
+	"revisions.target_picker.cancel":  {"ui.cancel"},
+}
+
 func ActionScopes(action string) []string {
 	action = strings.TrimSpace(action)
 	scopes, ok := builtInActionScopes[action]
@@ -386,6 +891,18 @@ func ActionScopes(action string) []string {
 	return append([]string(nil), scopes...)
 }
 
+// ActionCommand returns the intent an action dispatches together with the
+// fields it sets; actions with the same command do the same thing.
+func ActionCommand(action string) string {
+	return builtInActionCommands[strings.TrimSpace(action)]
+}
+
+// ActionHides reports whether action is declared to replace the outer scope
+// action hidden on the same key.
+func ActionHides(action string, hidden string) bool {
+	return slices.Contains(builtInActionHides[strings.TrimSpace(action)], strings.TrimSpace(hidden))
+}
+
 func ActionArgSchema(action string) map[string]string {
 	return builtInActionArgSchemas[action]
 }
@@ -430,6 +947,10 @@ func ValidateBuiltInActionArgs(action string, args map[string]any) error {
 			if _, ok := value.(bool); !ok {
 				return fmt.Errorf("action %q arg %q expects bool", action, key)
 			}
+		case "int":
+			if _, ok := actionargs.AsInt(value); !ok {
+				return fmt.Errorf("action %q arg %q expects int", action, key)
+			}
 		case "string":
 			if _, ok := value.(string); !ok {
 				return fmt.Errorf("action %q arg %q expects string", action, key)
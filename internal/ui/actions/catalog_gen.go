@@ -11,11 +11,14 @@ const (
 	ScopeBookmarks           = "bookmarks"
 	ScopeChoose              = "choose"
 	ScopeCommandHistory      = "command_history"
+	ScopeContentSearch       = "content_search"
 	ScopeDiff                = "diff"
 	ScopeFileSearch          = "file_search"
 	ScopeGit                 = "git"
 	ScopeHelp                = "help"
 	ScopeInput               = "input"
+	ScopeJumpLabels          = "jump_labels"
+	ScopeNotifications       = "notifications"
 	ScopeOplog               = "oplog"
 	ScopeOplogQuickSearch    = "oplog.quick_search"
 	ScopePassword            = "password"
@@ -26,21 +29,28 @@ const (
 	ScopeAceJump             = "revisions.ace_jump"
 	ScopeDetails             = "revisions.details"
 	ScopeDetailsConfirmation = "revisions.details.confirmation"
+	ScopeDetailsVisual       = "revisions.details.visual"
 	ScopeDiffRange           = "revisions.diff_range"
 	ScopeDuplicate           = "revisions.duplicate"
 	ScopeEvolog              = "revisions.evolog"
 	ScopeInlineDescribe      = "revisions.inline_describe"
+	ScopeMetaedit            = "revisions.metaedit"
 	ScopeNewBetween          = "revisions.new_between"
+	ScopeParallelize         = "revisions.parallelize"
 	ScopeQuickSearch         = "revisions.quick_search"
 	ScopeQuickSearchInput    = "revisions.quick_search.input"
 	ScopeRebase              = "revisions.rebase"
 	ScopeRevert              = "revisions.revert"
 	ScopeSetBookmark         = "revisions.set_bookmark"
 	ScopeSetParents          = "revisions.set_parents"
+	ScopeSimplifyParents     = "revisions.simplify_parents"
 	ScopeSquash              = "revisions.squash"
 	ScopeTargetPicker        = "revisions.target_picker"
+	ScopeVisual              = "revisions.visual"
 	ScopeRevset              = "revset"
 	ScopeStatusInput         = "status.input"
+	ScopeThemeBrowser        = "theme_browser"
+	ScopeThemeEditor         = "theme_editor"
 	ScopeUi                  = "ui"
 	ScopeUiPreview           = "ui.preview"
 	ScopeUndo                = "undo"
@@ -50,6 +60,8 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 	switch scope {
 	case ScopeBookmarks:
 		switch action {
+		case keybindings.Action("bookmarks.ace_jump"):
+			return intents.StartAceJump{}, true
 		case keybindings.Action("bookmarks.apply"):
 			return intents.Apply{}, true
 		case keybindings.Action("bookmarks.bookmark_delete"):
@@ -105,8 +117,25 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 		case keybindings.Action("command_history.move_up"):
 			return intents.CommandHistoryNavigate{Delta: -1}, true
 		}
+	case ScopeContentSearch:
+		switch action {
+		case keybindings.Action("content_search.apply"):
+			return intents.Apply{}, true
+		case keybindings.Action("content_search.cancel"):
+			return intents.Cancel{}, true
+		case keybindings.Action("content_search.move_down"):
+			return intents.ContentSearchNavigate{Delta: 1}, true
+		case keybindings.Action("content_search.move_up"):
+			return intents.ContentSearchNavigate{Delta: -1}, true
+		case keybindings.Action("content_search.next_field"):
+			return intents.ContentSearchFocusField{Delta: 1}, true
+		case keybindings.Action("content_search.prev_field"):
+			return intents.ContentSearchFocusField{Delta: -1}, true
+		}
 	case ScopeDiff:
 		switch action {
+		case keybindings.Action("diff.ace_jump"):
+			return intents.StartAceJump{}, true
 		case keybindings.Action("diff.half_page_down"):
 			return intents.DiffScroll{Kind: intents.DiffHalfPageDown}, true
 		case keybindings.Action("diff.half_page_up"):
@@ -163,6 +192,8 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 		}
 	case ScopeGit:
 		switch action {
+		case keybindings.Action("git.ace_jump"):
+			return intents.StartAceJump{}, true
 		case keybindings.Action("git.apply"):
 			return intents.Apply{}, true
 		case keybindings.Action("git.cancel"):
@@ -218,8 +249,32 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 		case keybindings.Action("input.cancel"):
 			return intents.Cancel{}, true
 		}
+	case ScopeJumpLabels:
+		switch action {
+		case keybindings.Action("jump_labels.apply"):
+			return intents.Apply{}, true
+		case keybindings.Action("jump_labels.cancel"):
+			return intents.Cancel{}, true
+		}
+	case ScopeNotifications:
+		switch action {
+		case keybindings.Action("notifications.close"):
+			return intents.Cancel{}, true
+		case keybindings.Action("notifications.copy"):
+			return intents.NotificationsCopy{}, true
+		case keybindings.Action("notifications.cycle_severity"):
+			return intents.NotificationsCycleSeverity{}, true
+		case keybindings.Action("notifications.move_down"):
+			return intents.NotificationsNavigate{Delta: 1}, true
+		case keybindings.Action("notifications.move_up"):
+			return intents.NotificationsNavigate{Delta: -1}, true
+		case keybindings.Action("notifications.rerun"):
+			return intents.NotificationsRerun{}, true
+		}
 	case ScopeOplog:
 		switch action {
+		case keybindings.Action("oplog.ace_jump"):
+			return intents.StartAceJump{}, true
 		case keybindings.Action("oplog.close"):
 			return intents.OpLogClose{}, true
 		case keybindings.Action("oplog.diff"):
@@ -284,10 +339,14 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 			return intents.DiffEdit{}, true
 		case keybindings.Action("revisions.edit"):
 			return intents.StartEdit{}, true
+		case keybindings.Action("revisions.fix"):
+			return intents.Fix{}, true
 		case keybindings.Action("revisions.force_apply"):
 			return intents.Apply{Force: true}, true
 		case keybindings.Action("revisions.force_edit"):
 			return intents.StartEdit{IgnoreImmutable: true}, true
+		case keybindings.Action("revisions.force_fix"):
+			return intents.Fix{IgnoreImmutable: true}, true
 		case keybindings.Action("revisions.go_to_bottom"):
 			return intents.GoToBottom{}, true
 		case keybindings.Action("revisions.go_to_top"):
@@ -302,8 +361,16 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 			return intents.Navigate{Delta: 1}, true
 		case keybindings.Action("revisions.move_up"):
 			return intents.Navigate{Delta: -1}, true
+		case keybindings.Action("revisions.narrow_to_selection"):
+			return intents.NarrowToSelection{}, true
 		case keybindings.Action("revisions.new"):
 			return intents.StartNew{}, true
+		case keybindings.Action("revisions.next"):
+			return intents.NextRevision{Count: actionargs.IntArg(args, "count", 0)}, true
+		case keybindings.Action("revisions.next_conflict"):
+			return intents.NextRevision{Conflict: true}, true
+		case keybindings.Action("revisions.next_edit"):
+			return intents.NextRevision{Count: actionargs.IntArg(args, "count", 0), Edit: true}, true
 		case keybindings.Action("revisions.open_abandon"):
 			return intents.OpenAbandon{}, true
 		case keybindings.Action("revisions.open_absorb"):
@@ -318,8 +385,12 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 			return intents.OpenEvolog{}, true
 		case keybindings.Action("revisions.open_inline_describe"):
 			return intents.OpenInlineDescribe{}, true
+		case keybindings.Action("revisions.open_metaedit"):
+			return intents.OpenMetaEdit{}, true
 		case keybindings.Action("revisions.open_new_between"):
 			return intents.OpenNewBetween{}, true
+		case keybindings.Action("revisions.open_parallelize"):
+			return intents.OpenParallelize{}, true
 		case keybindings.Action("revisions.open_rebase"):
 			return intents.OpenRebase{}, true
 		case keybindings.Action("revisions.open_revert"):
@@ -328,20 +399,34 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 			return intents.OpenSetBookmark{Value: actionargs.StringArg(args, "value", "")}, true
 		case keybindings.Action("revisions.open_set_parents"):
 			return intents.OpenSetParents{}, true
+		case keybindings.Action("revisions.open_simplify_parents"):
+			return intents.OpenSimplifyParents{}, true
 		case keybindings.Action("revisions.open_squash"):
 			return intents.OpenSquash{}, true
 		case keybindings.Action("revisions.page_down"):
 			return intents.Navigate{Delta: 1, IsPage: true}, true
 		case keybindings.Action("revisions.page_up"):
 			return intents.Navigate{Delta: -1, IsPage: true}, true
+		case keybindings.Action("revisions.prev"):
+			return intents.PrevRevision{Count: actionargs.IntArg(args, "count", 0)}, true
+		case keybindings.Action("revisions.prev_conflict"):
+			return intents.PrevRevision{Conflict: true}, true
+		case keybindings.Action("revisions.prev_edit"):
+			return intents.PrevRevision{Count: actionargs.IntArg(args, "count", 0), Edit: true}, true
 		case keybindings.Action("revisions.refresh"):
 			return intents.Refresh{}, true
+		case keybindings.Action("revisions.repeat"):
+			return intents.RepeatLastOperation{}, true
+		case keybindings.Action("revisions.select_revset"):
+			return intents.RevisionsSelectRevset{Revset: actionargs.StringArg(args, "revset", "")}, true
 		case keybindings.Action("revisions.split"):
 			return intents.StartSplit{}, true
 		case keybindings.Action("revisions.split_parallel"):
 			return intents.StartSplit{IsParallel: true}, true
 		case keybindings.Action("revisions.toggle_select"):
 			return intents.RevisionsToggleSelect{}, true
+		case keybindings.Action("revisions.visual_mode"):
+			return intents.RevisionsVisualMode{}, true
 		}
 	case ScopeAbandon:
 		switch action {
@@ -386,8 +471,12 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 		switch action {
 		case keybindings.Action("revisions.details.absorb"):
 			return intents.DetailsAbsorb{}, true
+		case keybindings.Action("revisions.details.ace_jump"):
+			return intents.StartAceJump{}, true
 		case keybindings.Action("revisions.details.cancel"):
 			return intents.DetailsClose{}, true
+		case keybindings.Action("revisions.details.cycle_sort"):
+			return intents.DetailsCycleSort{}, true
 		case keybindings.Action("revisions.details.diff"):
 			return intents.DetailsDiff{}, true
 		case keybindings.Action("revisions.details.filter"):
@@ -420,8 +509,14 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 			return intents.DetailsSplit{IsParallel: true}, true
 		case keybindings.Action("revisions.details.squash"):
 			return intents.DetailsSquash{}, true
+		case keybindings.Action("revisions.details.toggle_collapsed"):
+			return intents.DetailsToggleCollapsed{}, true
 		case keybindings.Action("revisions.details.toggle_select"):
 			return intents.DetailsToggleSelect{}, true
+		case keybindings.Action("revisions.details.toggle_tree"):
+			return intents.DetailsToggleTree{}, true
+		case keybindings.Action("revisions.details.visual_mode"):
+			return intents.DetailsVisualMode{}, true
 		}
 	case ScopeDetailsConfirmation:
 		switch action {
@@ -436,6 +531,13 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 		case keybindings.Action("revisions.details.confirmation.prev"):
 			return intents.OptionSelect{Delta: -1}, true
 		}
+	case ScopeDetailsVisual:
+		switch action {
+		case keybindings.Action("revisions.details.visual.cancel"):
+			return intents.Cancel{}, true
+		case keybindings.Action("revisions.details.visual.visual_mode"):
+			return intents.DetailsVisualMode{}, true
+		}
 	case ScopeDiffRange:
 		switch action {
 		case keybindings.Action("revisions.diff_range.apply"):
@@ -489,6 +591,8 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 		switch action {
 		case keybindings.Action("revisions.inline_describe.accept"):
 			return intents.InlineDescribeAccept{Force: actionargs.BoolArg(args, "force", false)}, true
+		case keybindings.Action("revisions.inline_describe.add_trailers"):
+			return intents.InlineDescribeAddTrailers{}, true
 		case keybindings.Action("revisions.inline_describe.cancel"):
 			return intents.Cancel{}, true
 		case keybindings.Action("revisions.inline_describe.editor"):
@@ -497,6 +601,23 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 			return intents.InlineDescribeAccept{Force: true}, true
 		case keybindings.Action("revisions.inline_describe.new_line"):
 			return intents.InlineDescribeNewLine{}, true
+		case keybindings.Action("revisions.inline_describe.prefix_picker"):
+			return intents.InlineDescribePrefixPicker{}, true
+		}
+	case ScopeMetaedit:
+		switch action {
+		case keybindings.Action("revisions.metaedit.apply"):
+			return intents.Apply{Force: actionargs.BoolArg(args, "force", false)}, true
+		case keybindings.Action("revisions.metaedit.cancel"):
+			return intents.Cancel{}, true
+		case keybindings.Action("revisions.metaedit.force_apply"):
+			return intents.Apply{Force: true}, true
+		case keybindings.Action("revisions.metaedit.next_field"):
+			return intents.MetaEditFocusField{Delta: 1}, true
+		case keybindings.Action("revisions.metaedit.prev_field"):
+			return intents.MetaEditFocusField{Delta: -1}, true
+		case keybindings.Action("revisions.metaedit.reset_author"):
+			return intents.MetaEditResetAuthor{Force: actionargs.BoolArg(args, "force", false)}, true
 		}
 	case ScopeNewBetween:
 		switch action {
@@ -507,6 +628,21 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 		case keybindings.Action("revisions.new_between.toggle_insert_before"):
 			return intents.NewBetweenToggleInsertBefore{}, true
 		}
+	case ScopeParallelize:
+		switch action {
+		case keybindings.Action("revisions.parallelize.ace_jump"):
+			return intents.StartAceJump{}, true
+		case keybindings.Action("revisions.parallelize.apply"):
+			return intents.Apply{Force: actionargs.BoolArg(args, "force", false)}, true
+		case keybindings.Action("revisions.parallelize.cancel"):
+			return intents.Cancel{}, true
+		case keybindings.Action("revisions.parallelize.force_apply"):
+			return intents.Apply{Force: true}, true
+		case keybindings.Action("revisions.parallelize.jump_to_working_copy"):
+			return intents.Navigate{Target: intents.TargetWorkingCopy}, true
+		case keybindings.Action("revisions.parallelize.toggle_select"):
+			return intents.ParallelizeToggleSelect{}, true
+		}
 	case ScopeQuickSearch:
 		switch action {
 		case keybindings.Action("revisions.quick_search.clear"):
@@ -581,6 +717,23 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 		case keybindings.Action("revisions.set_parents.toggle_select"):
 			return intents.SetParentsToggleSelect{}, true
 		}
+	case ScopeSimplifyParents:
+		switch action {
+		case keybindings.Action("revisions.simplify_parents.ace_jump"):
+			return intents.StartAceJump{}, true
+		case keybindings.Action("revisions.simplify_parents.apply"):
+			return intents.Apply{Force: actionargs.BoolArg(args, "force", false)}, true
+		case keybindings.Action("revisions.simplify_parents.cancel"):
+			return intents.Cancel{}, true
+		case keybindings.Action("revisions.simplify_parents.force_apply"):
+			return intents.Apply{Force: true}, true
+		case keybindings.Action("revisions.simplify_parents.jump_to_working_copy"):
+			return intents.Navigate{Target: intents.TargetWorkingCopy}, true
+		case keybindings.Action("revisions.simplify_parents.toggle_descendants"):
+			return intents.SimplifyParentsToggleDescendants{}, true
+		case keybindings.Action("revisions.simplify_parents.toggle_select"):
+			return intents.SimplifyParentsToggleSelect{}, true
+		}
 	case ScopeSquash:
 		switch action {
 		case keybindings.Action("revisions.squash.ace_jump"):
@@ -619,6 +772,13 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 		case keybindings.Action("revisions.target_picker.move_up"):
 			return intents.TargetPickerNavigate{Delta: -1}, true
 		}
+	case ScopeVisual:
+		switch action {
+		case keybindings.Action("revisions.visual.cancel"):
+			return intents.Cancel{}, true
+		case keybindings.Action("revisions.visual.visual_mode"):
+			return intents.RevisionsVisualMode{}, true
+		}
 	case ScopeRevset:
 		switch action {
 		case keybindings.Action("revset.apply"):
@@ -631,6 +791,18 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 			return intents.Cancel{}, true
 		case keybindings.Action("revset.edit"):
 			return intents.Edit{Clear: actionargs.BoolArg(args, "clear", false)}, true
+		case keybindings.Action("revset.insert_ancestors"):
+			return intents.InsertSelectionRevset{Kind: intents.SelectionRevsetAncestors}, true
+		case keybindings.Action("revset.insert_descendants"):
+			return intents.InsertSelectionRevset{Kind: intents.SelectionRevsetDescendants}, true
+		case keybindings.Action("revset.insert_heads"):
+			return intents.InsertSelectionRevset{Kind: intents.SelectionRevsetHeads}, true
+		case keybindings.Action("revset.insert_range"):
+			return intents.InsertSelectionRevset{Kind: intents.SelectionRevsetRange}, true
+		case keybindings.Action("revset.insert_roots"):
+			return intents.InsertSelectionRevset{Kind: intents.SelectionRevsetRoots}, true
+		case keybindings.Action("revset.insert_selection"):
+			return intents.InsertSelectionRevset{Kind: intents.SelectionRevsetSelected}, true
 		case keybindings.Action("revset.move_down"):
 			return intents.CompletionMove{Delta: 1}, true
 		case keybindings.Action("revset.move_up"):
@@ -657,12 +829,46 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 		case keybindings.Action("status.input.page_up"):
 			return intents.SuggestNavigate{Delta: 1}, true
 		}
+	case ScopeThemeBrowser:
+		switch action {
+		case keybindings.Action("theme_browser.apply"):
+			return intents.Apply{}, true
+		case keybindings.Action("theme_browser.cancel"):
+			return intents.Cancel{}, true
+		case keybindings.Action("theme_browser.edit"):
+			return intents.ThemeBrowserEdit{}, true
+		case keybindings.Action("theme_browser.move_down"):
+			return intents.ThemeBrowserNavigate{Delta: 1}, true
+		case keybindings.Action("theme_browser.move_up"):
+			return intents.ThemeBrowserNavigate{Delta: -1}, true
+		}
+	case ScopeThemeEditor:
+		switch action {
+		case keybindings.Action("theme_editor.apply"):
+			return intents.Apply{}, true
+		case keybindings.Action("theme_editor.cancel"):
+			return intents.Cancel{}, true
+		case keybindings.Action("theme_editor.edit_bg"):
+			return intents.ThemeEditorEdit{Background: true}, true
+		case keybindings.Action("theme_editor.edit_fg"):
+			return intents.ThemeEditorEdit{}, true
+		case keybindings.Action("theme_editor.move_down"):
+			return intents.ThemeEditorNavigate{Delta: 1}, true
+		case keybindings.Action("theme_editor.move_up"):
+			return intents.ThemeEditorNavigate{Delta: -1}, true
+		case keybindings.Action("theme_editor.save"):
+			return intents.ThemeEditorSave{}, true
+		case keybindings.Action("theme_editor.toggle_bold"):
+			return intents.ThemeEditorToggleBold{}, true
+		}
 	case ScopeUi:
 		switch action {
 		case keybindings.Action("ui.cancel"):
 			return intents.Cancel{}, true
 		case keybindings.Action("ui.change_theme"):
 			return intents.ChangeTheme{Name: actionargs.StringArg(args, "name", "")}, true
+		case keybindings.Action("ui.command_palette"):
+			return intents.CommandPaletteToggle{}, true
 		case keybindings.Action("ui.exec_jj"):
 			return intents.ExecJJ{}, true
 		case keybindings.Action("ui.exec_shell"):
@@ -675,16 +881,24 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 			return intents.OpenBookmarks{}, true
 		case keybindings.Action("ui.open_command_history"):
 			return intents.CommandHistoryToggle{}, true
+		case keybindings.Action("ui.open_content_search"):
+			return intents.OpenContentSearch{}, true
 		case keybindings.Action("ui.open_git"):
 			return intents.OpenGit{}, true
 		case keybindings.Action("ui.open_help"):
 			return intents.OpenHelp{}, true
+		case keybindings.Action("ui.open_notifications"):
+			return intents.OpenNotifications{}, true
 		case keybindings.Action("ui.open_oplog"):
 			return intents.OpLogOpen{}, true
 		case keybindings.Action("ui.open_redo"):
 			return intents.Redo{}, true
 		case keybindings.Action("ui.open_revset"):
 			return intents.Edit{Clear: true}, true
+		case keybindings.Action("ui.open_theme_browser"):
+			return intents.OpenThemeBrowser{}, true
+		case keybindings.Action("ui.open_theme_editor"):
+			return intents.OpenThemeEditor{}, true
 		case keybindings.Action("ui.open_undo"):
 			return intents.Undo{}, true
 		case keybindings.Action("ui.preview_expand"):
@@ -707,6 +921,8 @@ func ResolveIntent(scope string, action keybindings.Action, args map[string]any)
 			return intents.QuickSearch{}, true
 		case keybindings.Action("ui.quit"):
 			return intents.Quit{}, true
+		case keybindings.Action("ui.revision_finder"):
+			return intents.RevisionFinderToggle{}, true
 		case keybindings.Action("ui.suspend"):
 			return intents.Suspend{}, true
 		}
@@ -13,6 +13,8 @@ type Binding struct {
 	Key    []string
 	Seq    []string
 	Args   map[string]any
+	// When is a condition the binding applies under, see ParseCondition.
+	When string
 }
 
 func (b Binding) validate() error {
@@ -38,6 +40,11 @@ func (b Binding) validate() error {
 	if len(b.Seq) == 1 {
 		return fmt.Errorf("binding %q in scope %q has seq with only one key; use key instead", b.Action, b.Scope)
 	}
+	if b.When != "" {
+		if _, err := ParseCondition(b.When); err != nil {
+			return fmt.Errorf("binding %q in scope %q: %w", b.Action, b.Scope, err)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,286 @@
+package bindings
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ConditionValues returns the current value of a condition variable: a bool,
+// an int or a string.
+type ConditionValues func(name string) any
+
+// ConditionVariables lists the variables the `when` condition of a binding can
+// refer to.
+var ConditionVariables = []string{
+	"selection.is_working_copy",
+	"selection.has_conflict",
+	"selection.is_root",
+	"checked.count",
+	"preview.visible",
+}
+
+// Condition is a parsed `when` expression, such as
+// `selection.is_working_copy && checked.count > 1`. It combines variables,
+// numbers, quoted strings and true/false with !, &&, ||, parentheses and the
+// comparisons == != < <= > >=.
+type Condition struct {
+	root conditionNode
+}
+
+type conditionNode interface {
+	eval(values ConditionValues) any
+}
+
+type (
+	literalNode  struct{ value any }
+	variableNode struct{ name string }
+	notNode      struct{ operand conditionNode }
+	logicalNode  struct {
+		and         bool
+		left, right conditionNode
+	}
+	compareNode struct {
+		op          string
+		left, right conditionNode
+	}
+)
+
+// ParseCondition parses a `when` expression.
+func ParseCondition(expr string) (Condition, error) {
+	tokens, err := tokenizeCondition(expr)
+	if err != nil {
+		return Condition{}, err
+	}
+	p := &conditionParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return Condition{}, err
+	}
+	if p.pos < len(p.tokens) {
+		return Condition{}, fmt.Errorf("unexpected %q in condition %q", p.tokens[p.pos], expr)
+	}
+	return Condition{root: root}, nil
+}
+
+// Eval reports whether the condition holds. An empty condition always holds.
+func (c Condition) Eval(values ConditionValues) bool {
+	if c.root == nil {
+		return true
+	}
+	return truthy(c.root.eval(values))
+}
+
+func (n literalNode) eval(ConditionValues) any { return n.value }
+
+func (n variableNode) eval(values ConditionValues) any {
+	if values == nil {
+		return nil
+	}
+	return values(n.name)
+}
+
+func (n notNode) eval(values ConditionValues) any { return !truthy(n.operand.eval(values)) }
+
+func (n logicalNode) eval(values ConditionValues) any {
+	left := truthy(n.left.eval(values))
+	if n.and {
+		return left && truthy(n.right.eval(values))
+	}
+	return left || truthy(n.right.eval(values))
+}
+
+func (n compareNode) eval(values ConditionValues) any {
+	left, right := n.left.eval(values), n.right.eval(values)
+	var cmp int
+	leftNumber, leftOk := left.(int)
+	rightNumber, rightOk := right.(int)
+	if leftOk && rightOk {
+		cmp = leftNumber - rightNumber
+	} else {
+		cmp = strings.Compare(fmt.Sprint(left), fmt.Sprint(right))
+	}
+	switch n.op {
+	case "==":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+func truthy(value any) bool {
+	switch value := value.(type) {
+	case bool:
+		return value
+	case int:
+		return value != 0
+	case string:
+		return value != ""
+	}
+	return false
+}
+
+type conditionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *conditionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *conditionParser) parseOr() (conditionNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "||" {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (conditionNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "&&" {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseUnary() (conditionNode, error) {
+	switch p.peek() {
+	case "!":
+		p.pos++
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	case "(":
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing ) in condition")
+		}
+		p.pos++
+		return inner, nil
+	}
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	switch op := p.peek(); op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.pos++
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{op: op, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *conditionParser) parseOperand() (conditionNode, error) {
+	token := p.peek()
+	if token == "" {
+		return nil, fmt.Errorf("condition ends unexpectedly")
+	}
+	p.pos++
+	switch {
+	case token == "true" || token == "false":
+		return literalNode{value: token == "true"}, nil
+	case token[0] == '"' || token[0] == '\'':
+		return literalNode{value: token[1 : len(token)-1]}, nil
+	case unicode.IsDigit(rune(token[0])):
+		number, err := strconv.Atoi(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q in condition", token)
+		}
+		return literalNode{value: number}, nil
+	case isIdentStart(rune(token[0])):
+		if !slices.Contains(ConditionVariables, token) {
+			return nil, fmt.Errorf("unknown condition variable %q", token)
+		}
+		return variableNode{name: token}, nil
+	}
+	return nil, fmt.Errorf("unexpected %q in condition", token)
+}
+
+func tokenizeCondition(expr string) ([]string, error) {
+	var tokens []string
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("()", r):
+			tokens = append(tokens, string(r))
+			i++
+		case r == '"' || r == '\'':
+			end := slices.Index(runes[i+1:], r)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string in condition %q", expr)
+			}
+			tokens = append(tokens, string(runes[i:i+end+2]))
+			i += end + 2
+		case isIdentStart(r) || unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (isIdentStart(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		default:
+			op := string(r)
+			if i+1 < len(runes) {
+				if two := string(runes[i : i+2]); slices.Contains([]string{"&&", "||", "==", "!=", "<=", ">="}, two) {
+					op = two
+				}
+			}
+			if !slices.Contains([]string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!"}, op) {
+				return nil, fmt.Errorf("unexpected %q in condition %q", op, expr)
+			}
+			tokens = append(tokens, op)
+			i += len(op)
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("condition is empty")
+	}
+	return tokens, nil
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
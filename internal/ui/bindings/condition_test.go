@@ -0,0 +1,68 @@
+package bindings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCondition_Eval(t *testing.T) {
+	values := func(name string) any {
+		switch name {
+		case "selection.is_working_copy":
+			return true
+		case "selection.has_conflict":
+			return false
+		case "checked.count":
+			return 2
+		}
+		return nil
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"selection.is_working_copy", true},
+		{"!selection.is_working_copy", false},
+		{"selection.has_conflict", false},
+		{"checked.count > 1", true},
+		{"checked.count >= 3", false},
+		{"checked.count == 2 && !selection.has_conflict", true},
+		{"selection.has_conflict || checked.count < 1", false},
+		{"!(selection.has_conflict || checked.count != 2)", true},
+		{"selection.is_working_copy == true", true},
+		{"preview.visible", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			condition, err := ParseCondition(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, condition.Eval(values))
+		})
+	}
+}
+
+func TestParseCondition_Errors(t *testing.T) {
+	tests := map[string]string{
+		"":                              "condition is empty",
+		"selection.is_wc":               `unknown condition variable "selection.is_wc"`,
+		"checked.count >":               "condition ends unexpectedly",
+		"(preview.visible":              "missing )",
+		"checked.count = 1":             `unexpected "="`,
+		"preview.visible 'x":            "unterminated string",
+		"preview.visible checked.count": `unexpected "checked.count"`,
+	}
+	for expr, want := range tests {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseCondition(expr)
+			assert.ErrorContains(t, err, want)
+		})
+	}
+}
+
+func TestValidateBindings_RejectsInvalidCondition(t *testing.T) {
+	err := ValidateBindings([]Binding{{Action: "a", Scope: "revisions", Key: []string{"x"}, When: "checked.count >"}})
+	assert.ErrorContains(t, err, "condition ends unexpectedly")
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/idursun/jjui/internal/ui/context"
 	"github.com/idursun/jjui/internal/ui/intents"
 	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/operations/ace_jump"
 	"github.com/idursun/jjui/internal/ui/render"
 )
 
@@ -69,6 +70,7 @@ type Model struct {
 	filterText          string
 	categoryFilter      string
 	ensureCursorVisible bool
+	jumpLabels          *ace_jump.Labels
 	title               string
 }
 
@@ -117,8 +119,19 @@ func (i item) ShortCut() string {
 }
 
 func (m *Model) Scopes() []common.Scope {
+	if m.jumpLabels.Active() {
+		return []common.Scope{
+			m.jumpLabels.Scope(),
+			{
+				Name:    actions.ScopeBookmarks,
+				Leak:    common.LeakNone,
+				Handler: m,
+			},
+		}
+	}
 	if m.IsEditing() {
 		return []common.Scope{
+			//jjui:layer scope=bookmarks.filter
 			{
 				Name:    actions.ScopeBookmarks + ".filter",
 				Leak:    common.LeakNone,
@@ -401,6 +414,13 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		}
 		m.moveCursor(msg.Delta)
 		return nil, true
+	case intents.StartAceJump:
+		m.jumpLabels = ace_jump.NewListLabels(m.listRenderer, func(index int) tea.Cmd {
+			m.cursor = index
+			m.ensureCursorVisible = true
+			return nil
+		})
+		return nil, true
 	case intents.Cancel:
 		if m.filterState == filterEditing {
 			m.resetTextFilter()
@@ -89,6 +89,7 @@ func (m *Model) IsEditing() bool {
 func (m *Model) Scopes() []common.Scope {
 	if m.IsEditing() {
 		return []common.Scope{
+			//jjui:layer scope=choose.filter
 			{
 				Name:    actions.ScopeChoose + ".filter",
 				Leak:    common.LeakNone,
@@ -0,0 +1,323 @@
+package command_palette
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/actionmeta"
+	keybindings "github.com/idursun/jjui/internal/ui/bindings"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/fuzzy_search"
+	"github.com/idursun/jjui/internal/ui/help"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/render"
+	"github.com/sahilm/fuzzy"
+)
+
+const paletteAction = "ui.command_palette"
+
+type entry struct {
+	action string
+	args   map[string]any
+	desc   string
+	keys   string
+}
+
+func (e entry) String() string {
+	parts := []string{e.desc}
+	if e.action != "" {
+		parts = append(parts, e.action)
+	}
+	if e.keys != "" {
+		parts = append(parts, e.keys)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// Model lists the actions available in the current scopes, or the values of
+// an enum argument while prompting for the arguments of the chosen action.
+type Model struct {
+	scopes  []string
+	entries []entry
+	lines   []string
+	cursor  int
+	max     int
+	matches fuzzy.Matches
+	// action, args and arg are set while prompting for the value of arg
+	action  string
+	args    map[string]any
+	arg     string
+	argType string
+}
+
+type initMsg struct{}
+
+func NewModel(msg common.CommandPaletteMsg) *Model {
+	m := &Model{
+		max:    30,
+		scopes: msg.Scopes,
+		action: msg.Action,
+		args:   msg.Args,
+	}
+	if msg.Action == "" {
+		m.entries = buildEntries(msg.Scopes, config.Current.Bindings, config.Current.Actions)
+	} else if missing := missingArgs(msg.Action, msg.Args); len(missing) > 0 {
+		m.arg = missing[0]
+		m.argType = actionmeta.ActionArgSchema(msg.Action)[m.arg]
+		if values, ok := strings.CutPrefix(m.argType, "enum:"); ok {
+			for _, value := range strings.Split(values, "|") {
+				m.entries = append(m.entries, entry{desc: value})
+			}
+		}
+		if m.argType == "bool" {
+			m.entries = []entry{{desc: "true"}, {desc: "false"}}
+		}
+	}
+	m.lines = make([]string, len(m.entries))
+	for i, e := range m.entries {
+		m.lines[i] = e.String()
+	}
+	return m
+}
+
+// buildEntries lists the bound actions of scopes, innermost first, followed
+// by the unbound built-in actions and the configured Lua actions available
+// in them.
+func buildEntries(scopes []string, bindings []config.BindingConfig, actions []config.ActionConfig) []entry {
+	var entries []entry
+	seen := map[string]bool{}
+	seenActions := map[string]bool{}
+	add := func(e entry) {
+		identity := e.action
+		if len(e.args) > 0 {
+			data, _ := json.Marshal(e.args)
+			identity += "\x00" + string(data)
+		}
+		if e.action == paletteAction || seen[identity] {
+			return
+		}
+		seen[identity] = true
+		seenActions[e.action] = true
+		entries = append(entries, e)
+	}
+
+	for _, scope := range scopes {
+		for _, binding := range help.BuildBindingEntries(keybindings.ScopeName(scope), bindings) {
+			add(entry{action: string(binding.Action), args: binding.Args, desc: binding.Desc, keys: binding.Label})
+		}
+	}
+	for _, action := range actionmeta.BuiltInActions() {
+		inScope := slices.ContainsFunc(actionmeta.ActionScopes(action), func(scope string) bool {
+			return slices.Contains(scopes, scope)
+		})
+		if inScope && !seenActions[action] {
+			add(entry{action: action, desc: help.DescFromAction(action)})
+		}
+	}
+	for _, action := range actions {
+		name := strings.TrimSpace(action.Name)
+		if name == "" || seenActions[name] {
+			continue
+		}
+		if scope := strings.TrimSpace(action.Scope); scope != "" && !slices.Contains(scopes, scope) {
+			continue
+		}
+		desc := strings.TrimSpace(action.Desc)
+		if desc == "" {
+			desc = help.DescFromAction(name)
+		}
+		keys := help.BindingLabel(config.BindingConfig{Key: action.Key, Seq: action.Seq})
+		add(entry{action: name, args: action.Args, desc: desc, keys: keys})
+	}
+	return entries
+}
+
+// missingArgs returns the required arguments of a built-in action that are
+// not in args.
+func missingArgs(action string, args map[string]any) []string {
+	var missing []string
+	for _, name := range actionmeta.ActionRequiredArgs(action) {
+		if _, ok := args[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// Prompt is the prompt of the status input: the name of the argument being
+// asked for, if any.
+func (m *Model) Prompt() string {
+	if m.arg != "" {
+		return m.arg + "> "
+	}
+	return "> "
+}
+
+func (m *Model) Init() tea.Cmd {
+	return func() tea.Msg {
+		return initMsg{}
+	}
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case intents.Intent:
+		return m.handleIntent(msg)
+	case initMsg:
+		m.search("")
+	case fuzzy_search.SearchMsg:
+		m.search(msg.Input)
+	}
+	return nil
+}
+
+func (m *Model) handleIntent(intent intents.Intent) tea.Cmd {
+	switch intent := intent.(type) {
+	case intents.SuggestNavigate:
+		m.moveCursor(intent.Delta)
+	case intents.CommandPaletteAccept:
+		return m.accept(intent.Input)
+	}
+	return nil
+}
+
+// accept runs the selected action, or asks for its missing arguments first.
+func (m *Model) accept(input string) tea.Cmd {
+	if m.action == "" {
+		selected, ok := m.selected()
+		if !ok {
+			return nil
+		}
+		return m.next(selected.action, maps.Clone(selected.args))
+	}
+
+	value := input
+	if len(m.entries) > 0 {
+		selected, ok := m.selected()
+		if !ok {
+			return nil
+		}
+		value = selected.desc
+	}
+	parsed, err := parseArg(m.argType, value)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", m.arg, err)
+		return intents.Invoke(intents.AddMessage{Text: err.Error(), Err: err})
+	}
+	args := maps.Clone(m.args)
+	if args == nil {
+		args = map[string]any{}
+	}
+	args[m.arg] = parsed
+	return m.next(m.action, args)
+}
+
+func (m *Model) next(action string, args map[string]any) tea.Cmd {
+	if len(missingArgs(action, args)) > 0 {
+		scopes := m.scopes
+		return func() tea.Msg {
+			return common.CommandPaletteMsg{Scopes: scopes, Action: action, Args: args}
+		}
+	}
+	return func() tea.Msg {
+		return common.DispatchActionMsg{Action: action, Args: args}
+	}
+}
+
+func parseArg(argType string, value string) (any, error) {
+	switch argType {
+	case "int":
+		return strconv.Atoi(strings.TrimSpace(value))
+	case "bool":
+		return strconv.ParseBool(strings.TrimSpace(value))
+	}
+	return value, nil
+}
+
+func (m *Model) selected() (entry, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.matches) {
+		return entry{}, false
+	}
+	return m.entries[m.matches[m.cursor].Index], true
+}
+
+func (m *Model) moveCursor(inc int) {
+	l := min(len(m.matches), m.max)
+	if l == 0 {
+		return
+	}
+	n := m.cursor + inc
+	if n < 0 {
+		n = l - 1
+	}
+	if n >= l {
+		n = 0
+	}
+	m.cursor = n
+}
+
+func (m *Model) Max() int {
+	return m.max
+}
+
+func (m *Model) Matches() fuzzy.Matches {
+	return m.matches
+}
+
+func (m *Model) SelectedMatch() int {
+	return m.cursor
+}
+
+func (m *Model) Len() int {
+	return len(m.lines)
+}
+
+func (m *Model) String(i int) string {
+	if i < 0 || i >= len(m.lines) {
+		return ""
+	}
+	return m.lines[i]
+}
+
+func (m *Model) search(input string) {
+	src := &fuzzy_search.RefinedSource{Source: m}
+	m.cursor = 0
+	m.matches = src.Search(input, m.Len())
+}
+
+func (m *Model) ViewRect(dl *render.DisplayContext, box layout.Box) {
+	if desired := box.R.Dy()/2 - 1; desired > 0 {
+		m.max = desired
+	}
+	content := m.viewContent()
+	if content == "" {
+		return
+	}
+	_, h := lipgloss.Size(content)
+	rect := layout.Rect(box.R.Min.X, box.R.Max.Y-h, box.R.Dx(), h)
+	background := common.DefaultPalette.Get("status", "", "text", false)
+	dl.AddFill(rect, ' ', background, render.ZFuzzyOverlay)
+	dl.AddDraw(rect, content, render.ZFuzzyOverlay)
+	dl.AddHighlight(rect, background, render.ZFuzzyOverlay)
+}
+
+func (m *Model) viewContent() string {
+	shown := len(m.matches)
+	if shown == 0 {
+		return ""
+	}
+	title := fmt.Sprintf("  %d of %d actions ", shown, len(m.entries))
+	if m.action != "" {
+		title = fmt.Sprintf("  %s for %s ", m.arg, m.action)
+	}
+	titleStyle := common.DefaultPalette.Get("status", "", "title", false)
+	return lipgloss.JoinVertical(0, titleStyle.Render(title), fuzzy_search.View(m))
+}
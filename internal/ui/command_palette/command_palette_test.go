@@ -0,0 +1,82 @@
+package command_palette
+
+import (
+	"testing"
+
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/fuzzy_search"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildEntries_ListsBoundBuiltInAndLuaActionsOfScopes(t *testing.T) {
+	bindings := []config.BindingConfig{
+		{Action: "revisions.open_abandon", Scope: "revisions", Desc: "abandon", Key: config.StringList{"a"}},
+		{Action: "ui.command_palette", Scope: "ui", Key: config.StringList{"ctrl+k"}},
+	}
+	actions := []config.ActionConfig{
+		{Name: "copy-id", Lua: "copy()", Desc: "copy change id"},
+		{Name: "oplog-only", Lua: "noop()", Scope: "oplog"},
+	}
+
+	entries := buildEntries([]string{"revisions", "ui"}, bindings, actions)
+
+	require.NotEmpty(t, entries)
+	assert.Equal(t, entry{action: "revisions.open_abandon", desc: "abandon", keys: "a"}, entries[0])
+	actionNames := map[string]entry{}
+	for _, e := range entries {
+		actionNames[e.action] = e
+	}
+	assert.Contains(t, actionNames, "revisions.new", "unbound built-in actions of the scopes are listed")
+	assert.Equal(t, "copy change id", actionNames["copy-id"].desc)
+	assert.NotContains(t, actionNames, "oplog-only")
+	assert.NotContains(t, actionNames, "oplog.revert")
+	assert.NotContains(t, actionNames, "ui.command_palette")
+}
+
+func TestModel_AcceptPromptsForMissingArgsThenDispatches(t *testing.T) {
+	model := NewModel(common.CommandPaletteMsg{Scopes: []string{"ui"}, Action: "ui.change_theme"})
+	model.Update(model.Init()())
+
+	assert.Equal(t, "name> ", model.Prompt())
+	msg := model.Update(intents.CommandPaletteAccept{Input: "dark"})()
+
+	assert.Equal(t, common.DispatchActionMsg{Action: "ui.change_theme", Args: map[string]any{"name": "dark"}}, msg)
+}
+
+func TestModel_EnumArgsAreChosenFromTheList(t *testing.T) {
+	model := NewModel(common.CommandPaletteMsg{
+		Scopes: []string{"revisions.rebase"},
+		Action: "revisions.rebase.set_source",
+	})
+	model.Update(fuzzy_search.SearchMsg{Input: "bra"})
+
+	msg := model.Update(intents.CommandPaletteAccept{Input: "bra"})()
+
+	assert.Equal(t, common.DispatchActionMsg{
+		Action: "revisions.rebase.set_source",
+		Args:   map[string]any{"source": "branch"},
+	}, msg)
+}
+
+func TestModel_ChoosingActionWithRequiredArgsReopensForArgs(t *testing.T) {
+	model := &Model{scopes: []string{"ui"}, max: 30, entries: []entry{{action: "ui.change_theme", desc: "change theme"}}}
+	model.lines = []string{model.entries[0].String()}
+	model.Update(fuzzy_search.SearchMsg{Input: ""})
+
+	msg := model.Update(intents.CommandPaletteAccept{})()
+
+	assert.Equal(t, common.CommandPaletteMsg{Scopes: []string{"ui"}, Action: "ui.change_theme", Args: nil}, msg)
+}
+
+func TestModel_InvalidIntArgReportsError(t *testing.T) {
+	model := &Model{action: "revisions.move_up", arg: "count", argType: "int", max: 30}
+
+	msg := model.Update(intents.CommandPaletteAccept{Input: "many"})()
+
+	message, ok := msg.(intents.AddMessage)
+	require.True(t, ok)
+	assert.Error(t, message.Err)
+}
@@ -38,6 +38,10 @@ type Completion struct {
 
 type Option func(m *AutoCompletionInput)
 
+//jjui:palette scope=revset role=matched|text|dimmed
+//jjui:palette scope=revset selected=true
+//jjui:palette role=matched|text|dimmed
+//jjui:palette selected=true
 func WithStyleScope(scope string) Option {
 	return func(m *AutoCompletionInput) {
 		styles := AutoCompleteStyles{
@@ -29,6 +29,11 @@ type (
 	CommandRunningMsg struct {
 		ID      int
 		Command string
+		Args    []string
+		// Location is the repository the command runs in.
+		Location string
+		// Stdin is set when the command reads its input from stdin.
+		Stdin bool
 	}
 	CommandCompletedMsg struct {
 		ID     int
@@ -40,7 +45,9 @@ type (
 	}
 	QuickSearchMsg  string
 	UpdateRevSetMsg string
-	ExecMsg         struct {
+	// PreviewRevSetMsg shows the graph for a revset without committing it to history.
+	PreviewRevSetMsg string
+	ExecMsg          struct {
 		Line string
 		Mode ExecMode
 	}
@@ -63,6 +70,21 @@ type (
 		Commit     *jj.Commit
 		RawFileOut []byte // raw output from `jj file list`
 	}
+	RevisionFinderMsg struct{}
+	// CommandPaletteMsg opens the command palette with the actions of Scopes.
+	// Action is set while the palette prompts for the missing arguments of
+	// the chosen action.
+	CommandPaletteMsg struct {
+		Scopes []string
+		Action string
+		Args   map[string]any
+	}
+	// RevealRevisionMsg switches to Revset and selects Revision once the
+	// revisions are reloaded.
+	RevealRevisionMsg struct {
+		Revset   string
+		Revision string
+	}
 	ShowPreview     struct{}
 	RunLuaScriptMsg struct {
 		Script       string
@@ -84,8 +106,10 @@ type (
 	RestoreOperationMsg struct {
 		Operation any
 	}
-	StartAceJumpMsg     struct{}
-	OpenTargetPickerMsg struct {
+	StartAceJumpMsg struct{}
+	// PromptSelectRevsetMsg asks for a revset whose revisions get checked.
+	PromptSelectRevsetMsg struct{}
+	OpenTargetPickerMsg   struct {
 		Payload any // additional information to carry with the target picker
 		Sources []source.Source
 	}
@@ -168,6 +192,12 @@ func UpdateRevSet(revset string) tea.Cmd {
 	}
 }
 
+func PreviewRevSet(revset string) tea.Cmd {
+	return func() tea.Msg {
+		return PreviewRevSetMsg(revset)
+	}
+}
+
 func FileSearch(revset string, commit *jj.Commit, rawFileOut []byte) tea.Cmd {
 	return func() tea.Msg {
 		return FileSearchMsg{
@@ -178,6 +208,22 @@ func FileSearch(revset string, commit *jj.Commit, rawFileOut []byte) tea.Cmd {
 	}
 }
 
+func RevisionFinder() tea.Msg {
+	return RevisionFinderMsg{}
+}
+
+func CommandPalette(scopes []string) tea.Cmd {
+	return func() tea.Msg {
+		return CommandPaletteMsg{Scopes: scopes}
+	}
+}
+
+func RevealRevision(revset string, revision string) tea.Cmd {
+	return func() tea.Msg {
+		return RevealRevisionMsg{Revset: revset, Revision: revision}
+	}
+}
+
 type ExecMode struct {
 	Mode   string
 	Prompt string
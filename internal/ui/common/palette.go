@@ -1,6 +1,8 @@
 package common
 
 import (
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/idursun/jjui/internal/config"
@@ -12,8 +14,12 @@ var DefaultPalette = NewPalette()
 
 type Palette struct {
 	styles map[string]paletteStyle
+	colors map[string]config.Color
 	cache  map[paletteCacheKey]lipgloss.Style
-	blend  paletteBackgroundBlend
+	// used remembers every lookup made through Get, keyed by its selector, so
+	// that Resolve returns the style the UI actually renders a key with.
+	used  map[string]paletteCacheKey
+	blend paletteBackgroundBlend
 }
 
 type paletteCacheKey struct {
@@ -39,6 +45,7 @@ func NewPalette() *Palette {
 	return &Palette{
 		styles: make(map[string]paletteStyle),
 		cache:  make(map[paletteCacheKey]lipgloss.Style),
+		used:   make(map[string]paletteCacheKey),
 	}
 }
 
@@ -56,6 +63,7 @@ func (p *Palette) Update(styleMap map[string]config.Color) {
 	clear(p.cache)
 	clear(p.styles)
 	normalizedStyles := config.NormalizeColorSelectors(styleMap)
+	p.colors = normalizedStyles
 	for key, color := range normalizedStyles {
 		p.addColor(key, color)
 	}
@@ -109,6 +117,9 @@ func (p *Palette) get(scope, component, role string, isSelected bool) lipgloss.S
 	if style, ok := p.cache[cacheKey]; ok {
 		return style
 	}
+	if selector := cacheKey.selector(); selector != "" {
+		p.used[selector] = cacheKey
+	}
 
 	keys := paletteKeys(scope, component, role, isSelected)
 	finalStyle := lipgloss.NewStyle()
@@ -126,14 +137,55 @@ func (p *Palette) get(scope, component, role string, isSelected bool) lipgloss.S
 	return finalStyle
 }
 
+// Keys returns the sorted selectors looked up through Get so far, together
+// with the selectors the current theme defines.
+func (p *Palette) Keys() []string {
+	keys := slices.Collect(maps.Keys(p.used))
+	for key := range p.colors {
+		if _, ok := p.used[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Colors returns a copy of the colours the palette was last updated with.
+func (p *Palette) Colors() map[string]config.Color {
+	return maps.Clone(p.colors)
+}
+
+// Resolve returns the style a selector listed by Keys renders with.
+func (p *Palette) Resolve(key string) lipgloss.Style {
+	if lookup, ok := p.used[key]; ok {
+		return p.get(lookup.scope, lookup.component, lookup.role, lookup.isSelected)
+	}
+	entry := p.styles[key]
+	return entry.style
+}
+
+func (k paletteCacheKey) selector() string {
+	var fields []string
+	for _, field := range []string{k.scope, k.component, k.role} {
+		if field != "" {
+			fields = append(fields, field)
+		}
+	}
+	key := strings.Join(fields, " ")
+	if k.isSelected {
+		key += ":" + string(config.SelectedVariant)
+	}
+	return key
+}
+
 func paletteKeys(scope, component, role string, isSelected bool) []string {
-	baseCandidates := paletteCandidates(scope, component, role)
+	baseCandidates := config.PaletteCandidates(scope, component, role)
 	keys := make([]string, 0, len(baseCandidates)*2+1)
 	if isSelected {
 		// Role selectors remain authoritative for selected elements. Their explicit
 		// selected variants win first; broader selected selectors only fill properties
 		// that the role's selected and base styles leave unset.
-		contextCandidates := paletteCandidates(scope, component, "")
+		contextCandidates := config.PaletteCandidates(scope, component, "")
 		contextSet := make(map[string]struct{}, len(contextCandidates))
 		for _, candidate := range contextCandidates {
 			contextSet[candidate] = struct{}{}
@@ -217,37 +269,6 @@ func cloneTerminalPalette(terminalPalette map[int]string) map[int]string {
 	return cloned
 }
 
-func paletteCandidates(scope, component, role string) []string {
-	candidates := make([]string, 0, 7)
-	seen := make(map[string]struct{}, 7)
-	add := func(parts ...string) {
-		nonEmpty := parts[:0]
-		for _, part := range parts {
-			if part != "" {
-				nonEmpty = append(nonEmpty, part)
-			}
-		}
-		if len(nonEmpty) == 0 {
-			return
-		}
-		key := strings.Join(nonEmpty, " ")
-		if _, ok := seen[key]; ok {
-			return
-		}
-		seen[key] = struct{}{}
-		candidates = append(candidates, key)
-	}
-
-	add(scope, component, role)
-	add(scope, component)
-	add(scope, role)
-	add(scope)
-	add(component, role)
-	add(component)
-	add(role)
-	return candidates
-}
-
 func (p *Palette) GetBorder(scope, component, role string, isSelected bool, border lipgloss.Border) lipgloss.Style {
 	style := p.Get(scope, component, role, isSelected)
 	return lipgloss.NewStyle().
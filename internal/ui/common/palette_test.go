@@ -277,3 +277,21 @@ func TestPaletteUpdate_ReplacesCachedTheme(t *testing.T) {
 	assert.Equal(t, lipgloss.Color(Blue), p.Get("", "", "text", false).GetForeground())
 	assert.Equal(t, lipgloss.NewStyle().GetForeground(), p.Get("", "", "dark_only", false).GetForeground())
 }
+
+func TestPaletteKeys_ListsLookupsAndThemeSelectors(t *testing.T) {
+	p := NewPalette()
+	p.Update(map[string]config.Color{
+		"title":          {Fg: Red},
+		"selected help":  {Bg: Blue},
+		"revisions text": {Fg: Green},
+	})
+
+	p.Get("revisions", "", "text", false)
+	p.Get("help", "", "", true)
+	p.Get("", "", "", false)
+
+	assert.Equal(t, []string{"help:selected", "revisions text", "title"}, p.Keys())
+	assert.Equal(t, lipgloss.Color(Blue), p.Resolve("help:selected").GetBackground())
+	assert.Equal(t, lipgloss.Color(Red), p.Resolve("title").GetForeground())
+	assert.Equal(t, config.Color{Fg: Green}, p.Colors()["revisions text"])
+}
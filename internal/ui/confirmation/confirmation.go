@@ -252,6 +252,10 @@ func New(messages []string, opts ...Option) *Model {
 	}
 
 	// Set styles after options are applied so styleScope is considered.
+	//jjui:palette scope=confirmation role=border|text|dimmed
+	//jjui:palette scope=confirmation selected=true
+	//jjui:palette scope=revisions|undo|redo component=confirmation role=border|text|dimmed
+	//jjui:palette scope=revisions|undo|redo component=confirmation selected=true
 	m.Styles = Styles{
 		Border:   common.DefaultPalette.GetBorder(scope, component, "border", false, lipgloss.RoundedBorder()),
 		Text:     common.DefaultPalette.Get(scope, component, "text", false).PaddingRight(1),
@@ -0,0 +1,400 @@
+package content_search
+
+import (
+	stdcontext "context"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/actions"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/input"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+const (
+	fieldDiff = iota
+	fieldDescription
+	fieldFiles
+	// focusResults follows the form fields when cycling the focus
+	focusResults
+)
+
+type streamStartedMsg struct {
+	tag    int
+	reader *resultReader
+	err    error
+}
+
+type resultMsg struct {
+	tag    int
+	reader *resultReader
+	result Result
+	done   bool
+	err    error
+}
+
+type itemClickMsg struct {
+	Index int
+}
+
+type itemScrollMsg struct {
+	Delta      int
+	Horizontal bool
+}
+
+func (m itemScrollMsg) SetDelta(delta int, horizontal bool) tea.Msg {
+	m.Delta = delta
+	m.Horizontal = horizontal
+	return m
+}
+
+var _ common.ImmediateModel = (*Model)(nil)
+var _ common.Editable = (*Model)(nil)
+
+type Model struct {
+	context             *context.MainContext
+	form                *input.Form
+	focus               int
+	results             []Result
+	cursor              int
+	running             bool
+	stopped             bool
+	err                 error
+	tag                 int
+	cancel              stdcontext.CancelFunc
+	listRenderer        *render.ListRenderer
+	ensureCursorVisible bool
+}
+
+func NewModel(ctx *context.MainContext) *Model {
+	form := input.NewForm("content_search",
+		input.Field{Label: "Diff contains", Placeholder: "text, or regex:/glob:/exact: pattern"},
+		input.Field{Label: "Description", Placeholder: "text, or regex:/glob:/exact: pattern"},
+		input.Field{Label: "Files", Placeholder: "fileset"},
+	)
+	form.Z = render.ZMenuContent
+	listRenderer := render.NewListRenderer(itemScrollMsg{})
+	listRenderer.Z = render.ZMenuContent
+	return &Model{
+		context:      ctx,
+		form:         form,
+		listRenderer: listRenderer,
+	}
+}
+
+func (m *Model) IsEditing() bool {
+	return m.focus != focusResults
+}
+
+func (m *Model) Scopes() []common.Scope {
+	return []common.Scope{
+		//jjui:layer scope=content_search
+		{
+			Name:    actions.ScopeContentSearch,
+			Leak:    common.LeakNone,
+			Handler: m,
+		},
+	}
+}
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *Model) query() Query {
+	return Query{
+		Diff:        m.form.Value(fieldDiff),
+		Description: m.form.Value(fieldDescription),
+		Files:       m.form.Value(fieldFiles),
+	}
+}
+
+func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
+	switch intent := intent.(type) {
+	case intents.ContentSearchFocusField:
+		return m.focusNext(intent.Delta), true
+	case intents.ContentSearchNavigate:
+		m.move(intent.Delta)
+		return nil, true
+	case intents.Apply:
+		if m.focus == focusResults {
+			return m.jump(), true
+		}
+		return m.search(), true
+	case intents.Cancel:
+		if m.running {
+			m.stop()
+			return nil, true
+		}
+		m.stop()
+		return common.Close, true
+	}
+	return nil, false
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case intents.Intent:
+		cmd, _ := m.HandleIntent(msg)
+		return cmd
+	case streamStartedMsg:
+		if msg.tag != m.tag {
+			return closeReader(msg.reader)
+		}
+		if msg.err != nil {
+			m.running = false
+			m.err = msg.err
+			return nil
+		}
+		return readNext(msg.tag, msg.reader)
+	case resultMsg:
+		if msg.tag != m.tag {
+			if !msg.done {
+				return closeReader(msg.reader)
+			}
+			return nil
+		}
+		if msg.done {
+			m.running = false
+			m.err = msg.err
+			return nil
+		}
+		m.results = append(m.results, msg.result)
+		return readNext(msg.tag, msg.reader)
+	case itemClickMsg:
+		if msg.Index < 0 || msg.Index >= len(m.results) {
+			return nil
+		}
+		m.cursor = msg.Index
+		return m.jump()
+	case itemScrollMsg:
+		if msg.Horizontal {
+			return nil
+		}
+		m.listRenderer.StartLine = max(m.listRenderer.StartLine+msg.Delta, 0)
+		return nil
+	case common.CloseViewMsg:
+		m.stop()
+		return nil
+	case tea.KeyMsg, tea.PasteMsg:
+		if m.focus == focusResults {
+			return nil
+		}
+		m.err = nil
+		return m.form.Update(msg)
+	}
+	return nil
+}
+
+func (m *Model) focusNext(delta int) tea.Cmd {
+	count := focusResults + 1
+	m.focus = (m.focus + delta + count) % count
+	if m.focus == focusResults {
+		m.form.Blur()
+		return nil
+	}
+	return m.form.Focus(m.focus)
+}
+
+func (m *Model) move(delta int) {
+	if len(m.results) == 0 {
+		return
+	}
+	m.cursor = min(max(m.cursor+delta, 0), len(m.results)-1)
+	m.ensureCursorVisible = true
+}
+
+// search starts jj in the background; the results are read one revision at a
+// time until jj exits or the search is stopped.
+func (m *Model) search() tea.Cmd {
+	query := m.query()
+	if query.IsEmpty() {
+		m.err = errors.New("fill in at least one field")
+		return nil
+	}
+	m.stop()
+	m.results = nil
+	m.cursor = 0
+	m.err = nil
+	m.running = true
+	m.stopped = false
+	m.listRenderer.StartLine = 0
+
+	m.tag++
+	tag := m.tag
+	ctx, cancel := stdcontext.WithCancel(stdcontext.Background())
+	m.cancel = cancel
+
+	var match func(string) bool
+	if diff := strings.TrimSpace(query.Diff); diff != "" {
+		match = lineMatcher(diff)
+	}
+	args := jj.ContentSearch(query.Revset(), match != nil)
+	runner := m.context.CommandRunner
+	return func() tea.Msg {
+		command, err := runner.RunCommandStreaming(ctx, args)
+		if err != nil {
+			cancel()
+			return streamStartedMsg{tag: tag, err: err}
+		}
+		return streamStartedMsg{tag: tag, reader: newResultReader(command, command.ErrPipe, match)}
+	}
+}
+
+// stop cancels the running search, keeping the results found so far.
+func (m *Model) stop() {
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+	if m.running {
+		m.running = false
+		m.stopped = true
+		// results of the cancelled search are dropped when they arrive
+		m.tag++
+	}
+}
+
+func (m *Model) jump() tea.Cmd {
+	if m.cursor < 0 || m.cursor >= len(m.results) {
+		return nil
+	}
+	m.stop()
+	return tea.Batch(common.Close, m.context.SelectRevision(m.results[m.cursor].CommitId))
+}
+
+func readNext(tag int, reader *resultReader) tea.Cmd {
+	return func() tea.Msg {
+		result, ok := reader.next()
+		if !ok {
+			return resultMsg{tag: tag, reader: reader, done: true, err: reader.close()}
+		}
+		return resultMsg{tag: tag, reader: reader, result: result}
+	}
+}
+
+func closeReader(reader *resultReader) tea.Cmd {
+	if reader == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		_ = reader.close()
+		return nil
+	}
+}
+
+func (m *Model) status() string {
+	switch {
+	case m.err != nil:
+		return ""
+	case m.running:
+		return fmt.Sprintf("searching... %d found", len(m.results))
+	case m.stopped:
+		return fmt.Sprintf("stopped, %d found", len(m.results))
+	case m.tag == 0:
+		return "enter to search, tab to move between fields and results"
+	case len(m.results) == 1:
+		return "1 revision"
+	default:
+		return fmt.Sprintf("%d revisions", len(m.results))
+	}
+}
+
+func (m *Model) itemHeight(index int) int {
+	if m.results[index].Snippet != "" {
+		return 2
+	}
+	return 1
+}
+
+func (m *Model) ViewRect(dl *render.DisplayContext, box layout.Box) {
+	pw, ph := box.R.Dx(), box.R.Dy()
+	contentWidth := max(min(pw, 120)-4, 0)
+	contentHeight := max(min(ph, 40)-4, 0)
+	frame := box.Center(contentWidth+2, contentHeight+2)
+	if frame.R.Dx() <= 0 || frame.R.Dy() <= 0 {
+		return
+	}
+
+	titleStyle := common.DefaultPalette.Get("content_search", "", "title", false)
+	textStyle := common.DefaultPalette.Get("content_search", "", "text", false)
+	dimmedStyle := common.DefaultPalette.Get("content_search", "", "dimmed", false)
+	errorStyle := common.DefaultPalette.Get("content_search", "", "error", false)
+	borderStyle := common.DefaultPalette.GetBorder("content_search", "", "border", false, lipgloss.NormalBorder())
+
+	dl.AddBackdrop(box.R, render.ZMenuBorder-1)
+	contentBox := frame.Inset(1)
+	if contentBox.R.Dx() <= 0 || contentBox.R.Dy() <= 0 {
+		return
+	}
+	dl.AddFill(contentBox.R, ' ', textStyle, render.ZMenuContent)
+	borderBase := lipgloss.NewStyle().Width(contentBox.R.Dx()).Height(contentBox.R.Dy()).Render("")
+	dl.AddDraw(frame.R, borderStyle.Render(borderBase), render.ZMenuBorder)
+
+	titleBox, contentBox := contentBox.CutTop(1)
+	title := titleStyle.Render("Content search") + textStyle.Render("  ")
+	if m.err != nil {
+		title += errorStyle.Render(strings.SplitN(m.err.Error(), "\n", 2)[0])
+	} else {
+		title += dimmedStyle.Render(m.status())
+	}
+	dl.AddDraw(titleBox.R, title, render.ZMenuContent)
+
+	_, contentBox = contentBox.CutTop(1)
+	formBox, contentBox := contentBox.CutTop(m.form.Height())
+	m.form.ViewRect(dl, formBox)
+
+	_, listBox := contentBox.CutTop(1)
+	m.renderResults(dl, listBox)
+}
+
+func (m *Model) renderResults(dl *render.DisplayContext, listBox layout.Box) {
+	if listBox.R.Dx() <= 0 || listBox.R.Dy() <= 0 || len(m.results) == 0 {
+		return
+	}
+	textStyle := common.DefaultPalette.Get("content_search", "", "text", false)
+	dimmedStyle := common.DefaultPalette.Get("content_search", "", "dimmed", false)
+	changeIdStyle := common.DefaultPalette.Get("content_search", "", "change_id", false)
+	matchedStyle := common.DefaultPalette.Get("content_search", "", "matched", false)
+	selectedStyle := common.DefaultPalette.GetBlended("content_search", "", "", true)
+
+	m.listRenderer.Render(
+		dl,
+		listBox,
+		len(m.results),
+		m.cursor,
+		m.ensureCursorVisible,
+		m.itemHeight,
+		func(dl *render.DisplayContext, index int, rect layout.Rectangle) {
+			result := m.results[index]
+			description := result.Description
+			if description == "" {
+				description = "(no description set)"
+			}
+			header := changeIdStyle.Render(result.ChangeId) +
+				textStyle.Render(" "+description) +
+				dimmedStyle.Render(" "+result.Author+" "+result.Date)
+			if result.Matches > 1 {
+				header += dimmedStyle.Render(fmt.Sprintf(" (%d lines)", result.Matches))
+			}
+			dl.AddDraw(layout.Rect(rect.Min.X, rect.Min.Y, rect.Dx(), 1), header, render.ZMenuContent)
+			if result.Snippet != "" && rect.Dy() > 1 {
+				snippet := dimmedStyle.Render("  "+result.File+": ") + matchedStyle.Render(result.Snippet)
+				dl.AddDraw(layout.Rect(rect.Min.X, rect.Min.Y+1, rect.Dx(), 1), snippet, render.ZMenuContent)
+			}
+			if index == m.cursor && m.focus == focusResults {
+				dl.AddPaint(rect, selectedStyle, render.ZMenuContent)
+			}
+		},
+		func(index int, _ tea.Mouse) tea.Msg { return itemClickMsg{Index: index} },
+	)
+	m.listRenderer.RegisterScroll(dl, listBox)
+	m.ensureCursorVisible = false
+}
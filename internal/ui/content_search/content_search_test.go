@@ -0,0 +1,143 @@
+package content_search
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuery_Revset(t *testing.T) {
+	tests := []struct {
+		query    Query
+		expected string
+	}{
+		{Query{Diff: "TODO"}, `diff_contains("TODO")`},
+		{Query{Diff: `say "hi"`}, `diff_contains("say \"hi\"")`},
+		{Query{Description: "regex:^fix"}, `description(regex:"^fix")`},
+		{Query{Diff: "glob-i:*todo*", Files: "src/"}, `diff_contains(glob-i:"*todo*") & files("src/")`},
+		{Query{Diff: "a:b", Description: " wip "}, `diff_contains("a:b") & description("wip")`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.query.Revset())
+		})
+	}
+}
+
+func TestLineMatcher(t *testing.T) {
+	assert.True(t, lineMatcher("TODO")("// TODO: fix"))
+	assert.False(t, lineMatcher("TODO")("// todo: fix"))
+	assert.True(t, lineMatcher("substring-i:TODO")("// todo: fix"))
+	assert.True(t, lineMatcher(`regex:\bfoo\(`)("x := foo(1)"))
+	assert.True(t, lineMatcher("glob:*foo*")("x := foo(1)"))
+	assert.False(t, lineMatcher("exact:foo")("foo()"))
+	assert.False(t, lineMatcher("regex:(")("("), "invalid patterns match nothing")
+}
+
+func searchOutput(records ...string) []byte {
+	return []byte(strings.Join(records, ""))
+}
+
+func record(changeId, commitId, description string, diff ...string) string {
+	header := strings.Join([]string{changeId, commitId, description, "Jane", "", "2024-01-02"}, jj.FinderFieldSeparator)
+	return jj.ContentSearchRecordSeparator + header + "\n" + strings.Join(diff, "\n") + "\n"
+}
+
+func TestSearch_StreamsResultsWithMatchingLine(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.ContentSearch(`diff_contains("needle")`, true)).SetOutput(searchOutput(
+		record("kx", "a1", "add needle",
+			"diff --git a/main.go b/main.go",
+			"--- a/main.go",
+			"+++ b/main.go",
+			"@@ -1,0 +2,1 @@",
+			"+var x = needle()",
+			"+// another needle",
+		),
+		record("qz", "b2", "remove it",
+			"diff --git a/old.go b/old.go",
+			"-needle := 1",
+		),
+	))
+	defer commandRunner.Verify()
+
+	model := NewModel(test.NewTestContext(commandRunner))
+	test.SimulateModel(model, test.Type("needle"))
+	test.SimulateModel(model, intents.Invoke(intents.Apply{}))
+
+	assert.False(t, model.running)
+	assert.NoError(t, model.err)
+	assert.Equal(t, []Result{
+		{ChangeId: "kx", CommitId: "a1", Description: "add needle", Author: "Jane", Date: "2024-01-02", File: "main.go", Snippet: "+var x = needle()", Matches: 2},
+		{ChangeId: "qz", CommitId: "b2", Description: "remove it", Author: "Jane", Date: "2024-01-02", File: "old.go", Snippet: "-needle := 1", Matches: 1},
+	}, model.results)
+}
+
+func TestSearch_WithoutDiffPatternSkipsDiffs(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.ContentSearch(`description("wip")`, false)).SetOutput(searchOutput(record("kx", "a1", "wip")))
+	defer commandRunner.Verify()
+
+	model := NewModel(test.NewTestContext(commandRunner))
+	test.SimulateModel(model, intents.Invoke(intents.ContentSearchFocusField{Delta: 1}))
+	test.SimulateModel(model, test.Type("wip"))
+	test.SimulateModel(model, intents.Invoke(intents.Apply{}))
+
+	assert.Len(t, model.results, 1)
+	assert.Empty(t, model.results[0].Snippet)
+}
+
+func TestSearch_EmptyQueryIsRejected(t *testing.T) {
+	model := NewModel(test.NewTestContext(test.NewTestCommandRunner(t)))
+
+	cmd := model.Update(intents.Apply{})
+
+	assert.Nil(t, cmd)
+	assert.EqualError(t, model.err, "fill in at least one field")
+}
+
+func TestCancel_StopsRunningSearchAndDropsLateResults(t *testing.T) {
+	model := NewModel(test.NewTestContext(test.NewTestCommandRunner(t)))
+	model.form.SetValue(fieldDiff, "needle")
+	model.search()
+	assert.True(t, model.running)
+
+	cmd := model.Update(intents.Cancel{})
+
+	assert.Nil(t, cmd, "the first cancel only stops the search")
+	assert.False(t, model.running)
+	assert.Equal(t, "stopped, 0 found", model.status())
+
+	model.Update(resultMsg{tag: model.tag - 1, result: Result{ChangeId: "late"}, done: true})
+	assert.Empty(t, model.results)
+
+	cmd = model.Update(intents.Cancel{})
+	assert.Equal(t, common.CloseViewMsg{}, cmd())
+}
+
+func TestApply_OnResultsRevealsRevision(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.RevsetCount("b2 & (@)", 1))
+	defer commandRunner.Verify()
+	ctx := test.NewTestContext(commandRunner)
+	ctx.CurrentRevset = "@"
+	model := NewModel(ctx)
+	model.results = []Result{{ChangeId: "kx", CommitId: "a1"}, {ChangeId: "qz", CommitId: "b2"}}
+	model.Update(intents.ContentSearchFocusField{Delta: -1})
+	model.Update(intents.ContentSearchNavigate{Delta: 1})
+
+	cmd := model.Update(intents.Apply{})
+
+	var msgs []tea.Msg
+	for _, c := range cmd().(tea.BatchMsg) {
+		msgs = append(msgs, c())
+	}
+	assert.Contains(t, msgs, common.RevealRevisionMsg{Revset: "(@) | b2", Revision: "b2"})
+	assert.Contains(t, msgs, common.CloseViewMsg{})
+}
@@ -0,0 +1,107 @@
+package content_search
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var patternKinds = []string{"exact", "glob", "regex", "substring"}
+
+// Query is what the user filled in the search form. Every field is optional
+// but at least one has to be set for a search to run.
+type Query struct {
+	Diff        string
+	Description string
+	Files       string
+}
+
+func (q Query) IsEmpty() bool {
+	return strings.TrimSpace(q.Diff) == "" && strings.TrimSpace(q.Description) == "" && strings.TrimSpace(q.Files) == ""
+}
+
+// Revset combines the filled-in fields with `&`.
+func (q Query) Revset() string {
+	var terms []string
+	if diff := strings.TrimSpace(q.Diff); diff != "" {
+		terms = append(terms, fmt.Sprintf("diff_contains(%s)", stringPattern(diff)))
+	}
+	if description := strings.TrimSpace(q.Description); description != "" {
+		terms = append(terms, fmt.Sprintf("description(%s)", stringPattern(description)))
+	}
+	if files := strings.TrimSpace(q.Files); files != "" {
+		terms = append(terms, fmt.Sprintf("files(%s)", quote(files)))
+	}
+	return strings.Join(terms, " & ")
+}
+
+// stringPattern quotes value, keeping a leading pattern kind such as
+// `regex:` or `glob-i:` outside the quotes.
+func stringPattern(value string) string {
+	if kind, rest, ok := splitPatternKind(value); ok {
+		return kind + ":" + quote(rest)
+	}
+	return quote(value)
+}
+
+func splitPatternKind(value string) (string, string, bool) {
+	kind, rest, ok := strings.Cut(value, ":")
+	if !ok {
+		return "", value, false
+	}
+	for _, known := range patternKinds {
+		if kind == known || kind == known+"-i" {
+			return kind, rest, true
+		}
+	}
+	return "", value, false
+}
+
+func quote(value string) string {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	return `"` + value + `"`
+}
+
+// lineMatcher reports whether a changed line matches the diff pattern the
+// same way diff_contains does, so that the matching line can be shown.
+func lineMatcher(value string) func(string) bool {
+	kind, text, _ := splitPatternKind(value)
+	caseInsensitive := strings.HasSuffix(kind, "-i")
+	kind = strings.TrimSuffix(kind, "-i")
+
+	var expr string
+	switch kind {
+	case "regex":
+		expr = text
+	case "glob":
+		expr = "^" + globToRegexp(text) + "$"
+	case "exact":
+		expr = "^" + regexp.QuoteMeta(text) + "$"
+	default:
+		expr = regexp.QuoteMeta(text)
+	}
+	if caseInsensitive {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return func(string) bool { return false }
+	}
+	return re.MatchString
+}
+
+func globToRegexp(glob string) string {
+	var sb strings.Builder
+	for _, r := range glob {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return sb.String()
+}
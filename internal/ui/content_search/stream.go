@@ -0,0 +1,153 @@
+package content_search
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/idursun/jjui/internal/jj"
+)
+
+// Result is a revision matching the search. Snippet is the first changed
+// line matching the diff pattern, and Matches counts all of them.
+type Result struct {
+	ChangeId    string
+	CommitId    string
+	Description string
+	Author      string
+	Date        string
+	File        string
+	Snippet     string
+	Matches     int
+}
+
+// resultReader turns the output of jj.ContentSearch into results, one
+// revision at a time, so that they can be shown while jj is still searching.
+type resultReader struct {
+	output     io.ReadCloser
+	errOutput  io.Reader
+	scanner    *bufio.Scanner
+	match      func(string) bool
+	pending    *Result
+	file       string
+	stderr     bytes.Buffer
+	stderrMu   sync.Mutex
+	stderrDone chan struct{}
+}
+
+func newResultReader(output io.ReadCloser, errOutput io.Reader, match func(string) bool) *resultReader {
+	scanner := bufio.NewScanner(output)
+	// diffs of generated files easily exceed the default line limit
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	r := &resultReader{
+		output:     output,
+		errOutput:  errOutput,
+		scanner:    scanner,
+		match:      match,
+		stderrDone: make(chan struct{}),
+	}
+	go r.readStderr()
+	return r
+}
+
+func (r *resultReader) readStderr() {
+	defer close(r.stderrDone)
+	if r.errOutput == nil {
+		return
+	}
+	buf := make([]byte, 1024)
+	for {
+		n, err := r.errOutput.Read(buf)
+		if n > 0 {
+			r.stderrMu.Lock()
+			r.stderr.Write(buf[:n])
+			r.stderrMu.Unlock()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// next returns the next complete result, or false once the output is
+// exhausted.
+func (r *resultReader) next() (Result, bool) {
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		if header, ok := strings.CutPrefix(line, jj.ContentSearchRecordSeparator); ok {
+			previous := r.pending
+			r.pending = parseHeader(header)
+			r.file = ""
+			if previous != nil {
+				return *previous, true
+			}
+			continue
+		}
+		r.addDiffLine(line)
+	}
+	if r.pending != nil {
+		result := *r.pending
+		r.pending = nil
+		return result, true
+	}
+	return Result{}, false
+}
+
+func (r *resultReader) addDiffLine(line string) {
+	if r.pending == nil || r.match == nil {
+		return
+	}
+	if header, ok := strings.CutPrefix(line, "diff --git "); ok {
+		if _, path, found := strings.Cut(header, " b/"); found {
+			r.file = path
+		}
+		return
+	}
+	if strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "--- ") {
+		return
+	}
+	if !strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "-") {
+		return
+	}
+	if !r.match(line[1:]) {
+		return
+	}
+	r.pending.Matches++
+	if r.pending.Snippet == "" {
+		r.pending.File = r.file
+		r.pending.Snippet = line
+	}
+}
+
+func parseHeader(header string) *Result {
+	fields := strings.Split(header, jj.FinderFieldSeparator)
+	for len(fields) < 6 {
+		fields = append(fields, "")
+	}
+	return &Result{
+		ChangeId:    fields[0],
+		CommitId:    fields[1],
+		Description: fields[2],
+		Author:      fields[3],
+		Date:        fields[5],
+	}
+}
+
+// close waits for jj to exit and returns its error output when it failed.
+// Searches that were cancelled end without an error.
+func (r *resultReader) close() error {
+	<-r.stderrDone
+	err := r.output.Close()
+	if err == nil || errors.Is(err, io.EOF) {
+		return nil
+	}
+	r.stderrMu.Lock()
+	defer r.stderrMu.Unlock()
+	if message := strings.TrimSpace(r.stderr.String()); message != "" {
+		return errors.New(message)
+	}
+	return err
+}
@@ -24,6 +24,9 @@ type CommandRunner interface {
 	RunCommandStreaming(ctx context.Context, args []string) (*StreamingCommand, error)
 	RunCommand(args []string, continuations ...tea.Cmd) tea.Cmd
 	RunCommandWithInput(args []string, input string, continuations ...tea.Cmd) tea.Cmd
+	// RunCommandOnSuccess is like RunCommand but skips the continuations
+	// when the command fails.
+	RunCommandOnSuccess(args []string, continuations ...tea.Cmd) tea.Cmd
 	RunInteractiveCommand(args []string, continuation tea.Cmd) tea.Cmd
 }
 
@@ -78,12 +81,19 @@ func (a *MainCommandRunner) RunCommandStreaming(ctx context.Context, args []stri
 	}, nil
 }
 
-func (a *MainCommandRunner) runCommandWithInput(args []string, input *string, continuations []tea.Cmd) tea.Cmd {
+func (a *MainCommandRunner) runCommandWithInput(args []string, input *string, continuations []tea.Cmd, onlyOnSuccess bool) tea.Cmd {
 	id := a.nextID()
 	command := "jj " + strings.Join(args, " ")
+	// the command below prepends --color to args
+	commandArgs := slices.Clone(args)
+	location := a.Location
+	// the commands of a sequence run one after the other, so the
+	// continuations see the result of the command
+	failed := false
 	commands := make([]tea.Cmd, 0)
 	commands = append(commands,
 		func() tea.Msg {
+			failed = true // until the command completes without an error
 			started, cancel, env := a.Askpass.NewSubprocess(strings.Join(args, " "))
 			defer cancel()
 			if !slices.Contains(args, "--color") {
@@ -124,27 +134,46 @@ func (a *MainCommandRunner) runCommandWithInput(args []string, input *string, co
 					err = errors.New(output.String())
 				}
 			}
+			failed = err != nil
 			return common.CommandCompletedMsg{
 				ID:     id,
 				Output: output.String(),
 				Err:    err,
 			}
 		})
-	commands = append(commands, continuations...)
+	for _, continuation := range continuations {
+		if onlyOnSuccess && continuation != nil {
+			continuation = skipOnFailure(&failed, continuation)
+		}
+		commands = append(commands, continuation)
+	}
 	return tea.Batch(
 		func() tea.Msg {
-			return common.CommandRunningMsg{ID: id, Command: command}
+			return common.CommandRunningMsg{ID: id, Command: command, Args: commandArgs, Location: location, Stdin: input != nil}
 		},
 		tea.Sequence(commands...),
 	)
 }
 
+func skipOnFailure(failed *bool, continuation tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		if *failed {
+			return nil
+		}
+		return continuation()
+	}
+}
+
 func (a *MainCommandRunner) RunCommandWithInput(args []string, input string, continuations ...tea.Cmd) tea.Cmd {
-	return a.runCommandWithInput(args, &input, continuations)
+	return a.runCommandWithInput(args, &input, continuations, false)
 }
 
 func (a *MainCommandRunner) RunCommand(args []string, continuations ...tea.Cmd) tea.Cmd {
-	return a.runCommandWithInput(args, nil, continuations)
+	return a.runCommandWithInput(args, nil, continuations, false)
+}
+
+func (a *MainCommandRunner) RunCommandOnSuccess(args []string, continuations ...tea.Cmd) tea.Cmd {
+	return a.runCommandWithInput(args, nil, continuations, true)
 }
 
 func (a *MainCommandRunner) RunInteractiveCommand(args []string, continuation tea.Cmd) tea.Cmd {
@@ -176,6 +205,11 @@ func (c *StreamingCommand) Close() error {
 	c.once.Do(func() {
 		log.Println("closing streaming command")
 		pipeErr := c.ReadCloser.Close()
+		// streams that are not backed by a process only have output to close
+		if c.cmd == nil {
+			err = pipeErr
+			return
+		}
 
 		if c.ctx.Err() != nil {
 			log.Println("killing process due to context cancellation")
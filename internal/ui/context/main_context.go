@@ -1,6 +1,7 @@
 package context
 
 import (
+	"fmt"
 	"reflect"
 	"slices"
 	"strings"
@@ -37,6 +38,7 @@ type MainContext struct {
 	TerminalPalette           map[int]string
 	ThemeBackgroundBlend      float64
 	Histories                 *config.Histories
+	MessageLog                *config.MessageLog
 	ScriptVM                  *lua.LState
 }
 
@@ -46,8 +48,9 @@ func NewAppContext(location string, aps *askpass.Server) *MainContext {
 			Location: location,
 			Askpass:  aps,
 		},
-		Location:  location,
-		Histories: config.NewHistories(),
+		Location:   location,
+		Histories:  config.NewHistories(),
+		MessageLog: config.NewMessageLog(config.DefaultMessageLogPath()),
 	}
 
 	m.JJConfig = &config.JJConfig{}
@@ -148,6 +151,19 @@ func (ctx *MainContext) CreateReplacements() map[string]string {
 	return replacements
 }
 
+// SelectRevision selects revision, widening the current revset first when the
+// revision isn't part of it. The revset is checked in the returned command.
+func (ctx *MainContext) SelectRevision(revision string) tea.Cmd {
+	current := ctx.CurrentRevset
+	return func() tea.Msg {
+		out, err := ctx.RunCommandImmediate(jj.RevsetCount(fmt.Sprintf("%s & (%s)", revision, current), 1))
+		if err == nil && strings.TrimSpace(string(out)) != "" {
+			return common.RefreshMsg{SelectedRevision: revision}
+		}
+		return common.RevealRevisionMsg{Revset: fmt.Sprintf("(%s) | %s", current, revision), Revision: revision}
+	}
+}
+
 func (ctx *MainContext) ChangeWorkspace(path string) {
 	ctx.Location = path
 	if runner, ok := ctx.CommandRunner.(*MainCommandRunner); ok {
@@ -174,3 +190,37 @@ func (ctx *MainContext) GetSelectedRevisions() map[string]bool {
 	}
 	return selectedRevisions
 }
+
+const luaExpressionsCache = "__jjui_segment_expressions"
+
+// EvalLua evaluates a Lua expression in the script VM and returns its value as
+// text. Compiled expressions are cached in the VM.
+func (ctx *MainContext) EvalLua(expr string) (string, error) {
+	L := ctx.ScriptVM
+	if L == nil {
+		return "", fmt.Errorf("lua vm is not initialized")
+	}
+	cache, ok := L.GetGlobal(luaExpressionsCache).(*lua.LTable)
+	if !ok {
+		cache = L.NewTable()
+		L.SetGlobal(luaExpressionsCache, cache)
+	}
+	fn, ok := cache.RawGetString(expr).(*lua.LFunction)
+	if !ok {
+		var err error
+		fn, err = L.LoadString("return " + expr)
+		if err != nil {
+			return "", err
+		}
+		cache.RawSetString(expr, fn)
+	}
+	if err := L.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}); err != nil {
+		return "", err
+	}
+	value := L.Get(-1)
+	L.Pop(1)
+	if value == lua.LNil {
+		return "", nil
+	}
+	return lua.LVAsString(value), nil
+}
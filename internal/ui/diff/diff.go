@@ -1,11 +1,13 @@
 package diff
 
 import (
+	"regexp"
 	"slices"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
 	uv "github.com/charmbracelet/ultraviolet"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/jj/source"
 	"github.com/idursun/jjui/internal/ui/actions"
@@ -13,18 +15,25 @@ import (
 	appContext "github.com/idursun/jjui/internal/ui/context"
 	"github.com/idursun/jjui/internal/ui/intents"
 	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/operations/ace_jump"
 	"github.com/idursun/jjui/internal/ui/operations/target_picker"
 	"github.com/idursun/jjui/internal/ui/render"
 )
 
 type viewMode interface {
 	totalLines(width int) int
+	// rowOf returns the visual row where the given line starts
+	rowOf(line int, width int) int
 	scrollHorizontal(delta int, viewportWidth int)
 	ViewRect(dl *render.DisplayContext, box layout.Box, scrollY int)
 }
 
 const allFilesTargetLabel = "(all files)"
 
+// fileHeaderPattern matches the line starting each file in both the git and
+// the color-words diff formats.
+var fileHeaderPattern = regexp.MustCompile(`^(diff --git |(Added|Modified|Removed|Renamed|Copied) .*:$)`)
+
 type defaultView struct {
 	lines        []string
 	maxLineWidth int
@@ -42,6 +51,10 @@ func (v *defaultView) totalLines(_ int) int {
 	return len(v.lines)
 }
 
+func (v *defaultView) rowOf(line int, _ int) int {
+	return line
+}
+
 func (v *defaultView) scrollHorizontal(delta int, viewportWidth int) {
 	maxScroll := max(0, v.maxLineWidth-viewportWidth)
 	v.scrollX = max(0, min(v.scrollX+delta, maxScroll))
@@ -124,6 +137,14 @@ func (v *wrappedView) firstLine(scrollY int, width int) (line int, skip int) {
 	return idx, scrollY - v.visualRowStart[idx]
 }
 
+func (v *wrappedView) rowOf(line int, width int) int {
+	v.ensureIndex(width)
+	if line < 0 || line >= len(v.visualRowStart) {
+		return line
+	}
+	return v.visualRowStart[line]
+}
+
 func (v *wrappedView) scrollHorizontal(_ int, _ int) {}
 
 func (v *wrappedView) ViewRect(dl *render.DisplayContext, box layout.Box, scrollY int) {
@@ -166,6 +187,8 @@ type Model struct {
 
 	lines        []string
 	maxLineWidth int
+	fileHeaders  []int
+	jumpLabels   *ace_jump.Labels
 
 	scrollY        int
 	viewportWidth  int
@@ -189,6 +212,9 @@ type fileLoadedMsg struct {
 }
 
 func (m *Model) Scopes() []common.Scope {
+	if m.jumpLabels.Active() {
+		return []common.Scope{m.jumpLabels.Scope()}
+	}
 	return []common.Scope{
 		{
 			Name:    actions.ScopeDiff,
@@ -203,19 +229,20 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 	case intents.Cancel:
 		return common.Close, true
 	case intents.DiffScroll:
+		count := max(msg.Count, 1)
 		switch msg.Kind {
 		case intents.DiffScrollUp:
-			m.scrollY -= 1
+			m.scrollY -= count
 		case intents.DiffScrollDown:
-			m.scrollY += 1
+			m.scrollY += count
 		case intents.DiffPageUp:
-			m.scrollY -= m.viewportHeight
+			m.scrollY -= m.viewportHeight * count
 		case intents.DiffPageDown:
-			m.scrollY += m.viewportHeight
+			m.scrollY += m.viewportHeight * count
 		case intents.DiffHalfPageUp:
-			m.scrollY -= m.viewportHeight / 2
+			m.scrollY -= m.viewportHeight / 2 * count
 		case intents.DiffHalfPageDown:
-			m.scrollY += m.viewportHeight / 2
+			m.scrollY += m.viewportHeight / 2 * count
 		case intents.DiffMoveTop:
 			m.scrollY = 0
 		case intents.DiffMoveBottom:
@@ -244,6 +271,16 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 	case intents.DiffOpenTargetPicker:
 		return m.openTargetPicker(), true
 
+	case intents.StartAceJump:
+		m.jumpLabels = ace_jump.NewLabels(m.visibleFileHeaders(), func(line int) tea.Cmd {
+			m.scrollY = m.mode.rowOf(line, m.viewportWidth)
+			return nil
+		})
+		if !m.jumpLabels.Active() {
+			return intents.Invoke(intents.AddMessage{Text: "No file headers on screen"}), true
+		}
+		return nil, true
+
 	case intents.DiffFileNavigate:
 		if len(m.originalArgs) == 0 || m.context == nil {
 			return intents.Invoke(intents.AddMessage{Text: "File navigation is unavailable for this diff"}), true
@@ -350,6 +387,13 @@ func (m *Model) SetContent(content string) {
 	m.lines = lines
 	m.maxLineWidth = maxWidth
 	m.scrollY = 0
+	m.fileHeaders = nil
+	m.jumpLabels = nil
+	for i, line := range lines {
+		if fileHeaderPattern.MatchString(ansi.Strip(line)) {
+			m.fileHeaders = append(m.fileHeaders, i)
+		}
+	}
 
 	if wrapped {
 		m.mode = newWrappedView(lines)
@@ -360,7 +404,7 @@ func (m *Model) SetContent(content string) {
 
 func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
-	case intents.DiffScroll, intents.DiffToggleWrap, intents.DiffShow, intents.DiffOpenTargetPicker, intents.DiffFileNavigate, intents.DiffScrollHorizontal:
+	case intents.DiffScroll, intents.DiffToggleWrap, intents.DiffShow, intents.DiffOpenTargetPicker, intents.DiffFileNavigate, intents.DiffScrollHorizontal, intents.StartAceJump:
 		cmd, _ := m.HandleIntent(msg.(intents.Intent))
 		return cmd
 
@@ -406,9 +450,30 @@ func (m *Model) ViewRect(dl *render.DisplayContext, box layout.Box) {
 	m.clampScroll(width, height)
 
 	m.mode.ViewRect(dl, box, m.scrollY)
+	if m.jumpLabels.Active() {
+		for _, line := range m.fileHeaders {
+			y := m.mode.rowOf(line, width) - m.scrollY
+			if y < 0 || y >= height {
+				continue
+			}
+			m.jumpLabels.RenderItemOverlay(dl, line, layout.Rect(box.R.Min.X, box.R.Min.Y+y, width, 1), 1)
+		}
+	}
 	dl.AddInteraction(box.R, ScrollMsg{}, render.InteractionScroll, 0)
 }
 
+// visibleFileHeaders returns the file header lines that are on screen.
+func (m *Model) visibleFileHeaders() []int {
+	var headers []int
+	for _, line := range m.fileHeaders {
+		row := m.mode.rowOf(line, m.viewportWidth)
+		if row >= m.scrollY && row < m.scrollY+m.viewportHeight {
+			headers = append(headers, line)
+		}
+	}
+	return headers
+}
+
 func New(output string) *Model {
 	return NewWithContext(nil, output, nil)
 }
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	tea "charm.land/bubbletea/v2"
 	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/jj/source"
 	"github.com/idursun/jjui/internal/ui/common"
@@ -322,3 +323,18 @@ func TestFileNavigateUnavailableWithoutArgs(t *testing.T) {
 	require.True(t, ok)
 	assert.Contains(t, msg.Text, "unavailable")
 }
+
+func TestAceJump_LabelsVisibleFileHeadersAndScrollsToChosenOne(t *testing.T) {
+	model := New("Modified regular file a.go:\n1\n2\nAdded regular file b.go:\n3\nRemoved regular file c.go:\n4")
+	test.RenderImmediate(model, 40, 5)
+
+	model.Update(intents.StartAceJump{})
+	require.True(t, model.jumpLabels.Active())
+	view := test.Stripped(test.RenderImmediate(model, 40, 5))
+	assert.Contains(t, view, "sdded regular file b.go:")
+
+	model.jumpLabels.Update(tea.KeyPressMsg{Code: 's', Text: "s"})
+
+	assert.False(t, model.jumpLabels.Active())
+	assert.Equal(t, 3, model.scrollY)
+}
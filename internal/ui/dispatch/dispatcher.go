@@ -14,6 +14,15 @@ type Continuation struct {
 	Desc   string
 	Action bindings.Action
 	IsLeaf bool
+	// Sequences lists the bindings reached through Key with the keys left to
+	// press after it, which are none for a leaf.
+	Sequences []SequenceHint
+}
+
+// SequenceHint is a binding reachable from a pending sequence.
+type SequenceHint struct {
+	Keys []string
+	Desc string
 }
 
 // ResolveResult is the outcome of resolving a key press.
@@ -24,8 +33,13 @@ type ResolveResult struct {
 	Pending       bool
 	Consumed      bool
 	Continuations []Continuation
+	// Count is the count typed before the key, as in 5j, or 0 when there is none.
+	Count int
 }
 
+// maxCount caps count prefixes so that a run of digits cannot overflow.
+const maxCount = 9999
+
 type candidate struct {
 	scope   bindings.ScopeName
 	binding bindings.Binding
@@ -33,10 +47,13 @@ type candidate struct {
 
 // Dispatcher resolves key presses against active scopes and bindings.
 type Dispatcher struct {
-	bindings map[bindings.ScopeName][]bindings.Binding
+	bindings   map[bindings.ScopeName][]bindings.Binding
+	conditions map[string]bindings.Condition
+	values     bindings.ConditionValues
 
-	buffered   []tea.Key
+	pressed    int
 	candidates []candidate
+	count      int
 }
 
 func NewDispatcher(availableBindings []bindings.Binding) (*Dispatcher, error) {
@@ -44,16 +61,37 @@ func NewDispatcher(availableBindings []bindings.Binding) (*Dispatcher, error) {
 		return nil, err
 	}
 
-	d := &Dispatcher{bindings: make(map[bindings.ScopeName][]bindings.Binding)}
+	d := &Dispatcher{
+		bindings:   make(map[bindings.ScopeName][]bindings.Binding),
+		conditions: make(map[string]bindings.Condition),
+	}
 	for _, binding := range availableBindings {
 		d.bindings[binding.Scope] = append(d.bindings[binding.Scope], binding)
+		if binding.When != "" {
+			// validated above
+			d.conditions[binding.When], _ = bindings.ParseCondition(binding.When)
+		}
 	}
 	return d, nil
 }
 
+// SetConditionValues sets where the `when` conditions of the bindings read
+// their variables from. Until it is set, conditional bindings never apply.
+func (d *Dispatcher) SetConditionValues(values bindings.ConditionValues) {
+	d.values = values
+}
+
+func (d *Dispatcher) applies(binding bindings.Binding) bool {
+	if binding.When == "" {
+		return true
+	}
+	return d.values != nil && d.conditions[binding.When].Eval(d.values)
+}
+
 func (d *Dispatcher) ResetSequence() {
-	d.buffered = nil
+	d.pressed = 0
 	d.candidates = nil
+	d.count = 0
 }
 
 // Resolve applies dispatch rules for a key in the provided layer chain.
@@ -70,12 +108,13 @@ func (d *Dispatcher) Resolve(msg tea.KeyMsg, scopes []common.Scope) ResolveResul
 
 	seqCandidates := d.initialSequenceCandidates(key, scopes)
 	if len(seqCandidates) > 0 {
-		d.buffered = []tea.Key{key}
+		d.pressed = 1
 		d.candidates = seqCandidates
 		return ResolveResult{
 			Pending:       true,
 			Consumed:      true,
 			Continuations: d.pendingContinuations(),
+			Count:         d.count,
 		}
 	}
 
@@ -83,30 +122,80 @@ func (d *Dispatcher) Resolve(msg tea.KeyMsg, scopes []common.Scope) ResolveResul
 		scopeBindings := d.bindings[scope.Name]
 		for i := len(scopeBindings) - 1; i >= 0; i-- {
 			binding := scopeBindings[i]
-			if len(binding.Key) == 0 {
+			if len(binding.Key) == 0 || !d.applies(binding) {
 				continue
 			}
 			for _, candidateKey := range binding.Key {
 				if keyMatches(candidateKey, key) {
-					return ResolveResult{Action: binding.Action, Scope: scope.Name, Args: bindings.CloneArgs(binding.Args), Consumed: true}
+					count := d.count
+					d.count = 0
+					return ResolveResult{Action: binding.Action, Scope: scope.Name, Args: bindings.CloneArgs(binding.Args), Consumed: true, Count: count}
 				}
 			}
 		}
 	}
 
+	if d.extendCount(key, scopes) {
+		return ResolveResult{Pending: true, Consumed: true, Count: d.count}
+	}
+	d.count = 0
 	return ResolveResult{}
 }
 
+// extendCount appends an unbound digit to the count prefix. A count starts
+// with 1-9, so a leading 0 stays available as a key.
+func (d *Dispatcher) extendCount(key tea.Key, scopes []common.Scope) bool {
+	digit := key.String()
+	if len(digit) != 1 || digit[0] < '0' || digit[0] > '9' || (digit == "0" && d.count == 0) {
+		return false
+	}
+	if !countsAllowed(scopes) {
+		return false
+	}
+	d.count = min(d.count*10+int(digit[0]-'0'), maxCount)
+	return true
+}
+
+// countsAllowed reports whether unbound keys would reach a list rather than a
+// text input or a menu. Those block every outer scope without being global
+// themselves, and take the digits as they are typed.
+func countsAllowed(scopes []common.Scope) bool {
+	for _, scope := range scopes {
+		switch scope.Leak {
+		case common.LeakNone:
+			return scope.Global
+		case common.LeakGlobal:
+			return true
+		}
+	}
+	return true
+}
+
 func (d *Dispatcher) resolveSequenceKey(key tea.Key) ResolveResult {
 	if keyMatches("esc", key) {
 		d.ResetSequence()
 		return ResolveResult{Consumed: true}
 	}
+	return d.advanceSequence(func(candidate string) bool {
+		return keyMatches(candidate, key)
+	})
+}
 
-	nextBuffer := append(append([]tea.Key(nil), d.buffered...), key)
+// ContinueSequence advances the pending sequence by the key named as in the
+// bindings, as if it was pressed. It does nothing when no sequence is pending.
+func (d *Dispatcher) ContinueSequence(key string) ResolveResult {
+	if len(d.candidates) == 0 {
+		return ResolveResult{}
+	}
+	return d.advanceSequence(func(candidate string) bool {
+		return candidate == key
+	})
+}
+
+func (d *Dispatcher) advanceSequence(matches func(candidate string) bool) ResolveResult {
 	filtered := make([]candidate, 0, len(d.candidates))
 	for _, c := range d.candidates {
-		if isPrefix(c.binding.Seq, nextBuffer) {
+		if d.pressed < len(c.binding.Seq) && matches(c.binding.Seq[d.pressed]) {
 			filtered = append(filtered, c)
 		}
 	}
@@ -117,7 +206,7 @@ func (d *Dispatcher) resolveSequenceKey(key tea.Key) ResolveResult {
 		return ResolveResult{Consumed: true}
 	}
 
-	d.buffered = nextBuffer
+	d.pressed++
 	d.candidates = filtered
 
 	// Inner scope wins; within the same scope, last-added binding wins.
@@ -126,7 +215,7 @@ func (d *Dispatcher) resolveSequenceKey(key tea.Key) ResolveResult {
 	var matchArgs map[string]any
 	found := false
 	for _, c := range filtered {
-		if len(c.binding.Seq) != len(d.buffered) {
+		if len(c.binding.Seq) != d.pressed {
 			continue
 		}
 		if !found {
@@ -140,14 +229,16 @@ func (d *Dispatcher) resolveSequenceKey(key tea.Key) ResolveResult {
 		}
 	}
 	if found {
+		count := d.count
 		d.ResetSequence()
-		return ResolveResult{Action: matchAction, Scope: matchScope, Args: matchArgs, Consumed: true}
+		return ResolveResult{Action: matchAction, Scope: matchScope, Args: matchArgs, Consumed: true, Count: count}
 	}
 
 	return ResolveResult{
 		Pending:       true,
 		Consumed:      true,
 		Continuations: d.pendingContinuations(),
+		Count:         d.count,
 	}
 }
 
@@ -155,7 +246,7 @@ func (d *Dispatcher) initialSequenceCandidates(key tea.Key, scopes []common.Scop
 	var candidates []candidate
 	for _, scope := range common.VisibleScopes(scopes) {
 		for _, binding := range d.bindings[scope.Name] {
-			if len(binding.Seq) > 0 && keyMatches(binding.Seq[0], key) {
+			if len(binding.Seq) > 0 && keyMatches(binding.Seq[0], key) && d.applies(binding) {
 				candidates = append(candidates, candidate{scope: scope.Name, binding: binding})
 			}
 		}
@@ -171,7 +262,7 @@ func (d *Dispatcher) pendingContinuations() []Continuation {
 	order := make([]string, 0, len(d.candidates))
 	byKey := make(map[string]*entry, len(d.candidates))
 	for _, c := range d.candidates {
-		idx := len(d.buffered)
+		idx := d.pressed
 		if idx >= len(c.binding.Seq) {
 			continue
 		}
@@ -183,16 +274,19 @@ func (d *Dispatcher) pendingContinuations() []Continuation {
 			desc = string(c.binding.Action)
 		}
 
+		hint := SequenceHint{Keys: c.binding.Seq[idx+1:], Desc: desc}
 		if e, ok := byKey[next]; ok {
 			e.descs = append(e.descs, desc)
 			e.cont.IsLeaf = e.cont.IsLeaf && isLeaf
+			e.cont.Sequences = append(e.cont.Sequences, hint)
 		} else {
 			order = append(order, next)
 			byKey[next] = &entry{
 				cont: Continuation{
-					Key:    next,
-					Action: c.binding.Action,
-					IsLeaf: isLeaf,
+					Key:       next,
+					Action:    c.binding.Action,
+					IsLeaf:    isLeaf,
+					Sequences: []SequenceHint{hint},
 				},
 				descs: []string{desc},
 			}
@@ -208,18 +302,6 @@ func (d *Dispatcher) pendingContinuations() []Continuation {
 	return continuations
 }
 
-func isPrefix(full []string, prefix []tea.Key) bool {
-	if len(prefix) > len(full) {
-		return false
-	}
-	for i := range prefix {
-		if !keyMatches(full[i], prefix[i]) {
-			return false
-		}
-	}
-	return true
-}
-
 func keyMatches(candidate string, key tea.Key) bool {
 	return candidate == key.String() || candidate == key.Keystroke()
 }
@@ -206,3 +206,114 @@ func TestDispatcher_DuplicateKeySameScope_LastBindingWins(t *testing.T) {
 func runeKey(r rune) tea.KeyPressMsg {
 	return tea.KeyPressMsg{Text: string(r), Code: r}
 }
+
+func TestDispatcher_CountPrefixIsPassedWithTheAction(t *testing.T) {
+	d, err := NewDispatcher([]bindings.Binding{
+		{Action: "move_down", Scope: "revisions", Key: []string{"j"}},
+		{Action: "git_push", Scope: "revisions", Seq: []string{"g", "p"}},
+	})
+	require.NoError(t, err)
+
+	for _, digit := range "12" {
+		result := d.Resolve(runeKey(digit), createScopes("revisions"))
+		require.True(t, result.Consumed)
+		require.True(t, result.Pending)
+	}
+	result := d.Resolve(runeKey('j'), createScopes("revisions"))
+	require.Equal(t, bindings.Action("move_down"), result.Action)
+	require.Equal(t, 12, result.Count)
+
+	// the count is used up by the action
+	result = d.Resolve(runeKey('j'), createScopes("revisions"))
+	require.Equal(t, 0, result.Count)
+
+	d.Resolve(runeKey('3'), createScopes("revisions"))
+	d.Resolve(runeKey('g'), createScopes("revisions"))
+	result = d.Resolve(runeKey('p'), createScopes("revisions"))
+	require.Equal(t, bindings.Action("git_push"), result.Action)
+	require.Equal(t, 3, result.Count)
+}
+
+func TestDispatcher_CountPrefixLeavesBoundDigitsAndInputsAlone(t *testing.T) {
+	d, err := NewDispatcher([]bindings.Binding{
+		{Action: "first_tab", Scope: "revisions", Key: []string{"1"}},
+	})
+	require.NoError(t, err)
+
+	result := d.Resolve(runeKey('1'), createScopes("revisions"))
+	require.Equal(t, bindings.Action("first_tab"), result.Action)
+
+	result = d.Resolve(runeKey('0'), createScopes("revisions"))
+	require.False(t, result.Consumed, "a count cannot start with 0")
+
+	input := []common.Scope{{Name: "status.input", Leak: common.LeakNone}, {Name: "ui", Leak: common.LeakNone, Global: true}}
+	result = d.Resolve(runeKey('5'), input)
+	require.False(t, result.Consumed, "digits typed into an input are not a count")
+}
+
+func TestDispatcher_UnboundKeyDropsTheCount(t *testing.T) {
+	d, err := NewDispatcher([]bindings.Binding{
+		{Action: "move_down", Scope: "revisions", Key: []string{"j"}},
+	})
+	require.NoError(t, err)
+
+	d.Resolve(runeKey('4'), createScopes("revisions"))
+	result := d.Resolve(runeKey('x'), createScopes("revisions"))
+	require.False(t, result.Consumed)
+
+	result = d.Resolve(runeKey('j'), createScopes("revisions"))
+	require.Equal(t, 0, result.Count)
+}
+
+func TestDispatcher_ContinuationsListTheSequencesReachedThroughThem(t *testing.T) {
+	d, err := NewDispatcher([]bindings.Binding{
+		{Action: "go_top", Scope: "revisions", Seq: []string{"g", "g"}, Desc: "top"},
+		{Action: "git_push", Scope: "revisions", Seq: []string{"g", "p", "p"}, Desc: "push"},
+		{Action: "git_fetch", Scope: "revisions", Seq: []string{"g", "p", "f"}, Desc: "fetch"},
+	})
+	require.NoError(t, err)
+
+	first := d.Resolve(runeKey('g'), createScopes("revisions"))
+	require.Len(t, first.Continuations, 2)
+	require.Equal(t, []SequenceHint{{Keys: []string{}, Desc: "top"}}, first.Continuations[0].Sequences)
+	require.Equal(t, []SequenceHint{
+		{Keys: []string{"p"}, Desc: "push"},
+		{Keys: []string{"f"}, Desc: "fetch"},
+	}, first.Continuations[1].Sequences)
+}
+
+func TestDispatcher_ContinueSequenceByKeyName(t *testing.T) {
+	d, err := NewDispatcher([]bindings.Binding{
+		{Action: "git_push", Scope: "revisions", Seq: []string{"g", "p", "space"}},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, ResolveResult{}, d.ContinueSequence("p"))
+
+	require.True(t, d.Resolve(runeKey('g'), createScopes("revisions")).Pending)
+	require.True(t, d.ContinueSequence("p").Pending)
+	result := d.ContinueSequence("space")
+	require.False(t, result.Pending)
+	require.Equal(t, bindings.Action("git_push"), result.Action)
+}
+
+func TestDispatcher_ConditionalBindingFallsBackWhenItDoesNotApply(t *testing.T) {
+	d, err := NewDispatcher([]bindings.Binding{
+		{Action: "new", Scope: "revisions", Key: []string{"n"}},
+		{Action: "commit", Scope: "revisions", Key: []string{"n"}, When: "selection.is_working_copy"},
+		{Action: "resolve", Scope: "revisions", Seq: []string{"x", "r"}, When: "selection.has_conflict"},
+		{Action: "abandon", Scope: "revisions", Key: []string{"x"}},
+	})
+	require.NoError(t, err)
+
+	workingCopy := false
+	d.SetConditionValues(func(name string) any {
+		return name == "selection.is_working_copy" && workingCopy
+	})
+
+	require.Equal(t, bindings.Action("new"), d.Resolve(runeKey('n'), createScopes("revisions")).Action)
+	require.Equal(t, bindings.Action("abandon"), d.Resolve(runeKey('x'), createScopes("revisions")).Action)
+
+	workingCopy = true
+	require.Equal(t, bindings.Action("commit"), d.Resolve(runeKey('n'), createScopes("revisions")).Action)
+}
@@ -20,6 +20,7 @@ type Result struct {
 	Pending       bool
 	Consumed      bool
 	Continuations []Continuation
+	Count         int
 }
 
 // Resolver wraps a Dispatcher and extends the pipeline to resolve
@@ -55,12 +56,25 @@ func (r *Resolver) ResolveKey(msg tea.KeyMsg, scopes []common.Scope) Result {
 		return Result{}
 	}
 
-	bindResult := r.dispatcher.Resolve(msg, scopes)
+	return r.resolveBinding(r.dispatcher.Resolve(msg, scopes))
+}
+
+// ContinueSequence resolves the pending sequence continued by key, as when a
+// continuation is clicked instead of pressed.
+func (r *Resolver) ContinueSequence(key string) Result {
+	if r.dispatcher == nil {
+		return Result{}
+	}
+	return r.resolveBinding(r.dispatcher.ContinueSequence(key))
+}
+
+func (r *Resolver) resolveBinding(bindResult ResolveResult) Result {
 	if bindResult.Pending {
 		return Result{
 			Pending:       true,
 			Consumed:      true,
 			Continuations: bindResult.Continuations,
+			Count:         bindResult.Count,
 		}
 	}
 	if bindResult.Action != "" {
@@ -68,6 +82,11 @@ func (r *Resolver) ResolveKey(msg tea.KeyMsg, scopes []common.Scope) Result {
 		if bindResult.Scope != "" {
 			result.Scope = string(bindResult.Scope)
 		}
+		// a count prefix scales intents that take one and is dropped otherwise
+		if counted, ok := result.Intent.(intents.Counted); ok && bindResult.Count > 0 {
+			result.Intent = counted.WithCount(bindResult.Count)
+			result.Count = bindResult.Count
+		}
 		return result
 	}
 	if bindResult.Consumed {
@@ -86,6 +105,14 @@ func (r *Resolver) ResolveBuiltInAction(action keybindings.Action, args map[stri
 	return r.resolveAction(action, args, true)
 }
 
+// SetConditionValues sets where the `when` conditions of the bindings read
+// their variables from.
+func (r *Resolver) SetConditionValues(values keybindings.ConditionValues) {
+	if r.dispatcher != nil {
+		r.dispatcher.SetConditionValues(values)
+	}
+}
+
 // ResetSequence resets any in-progress key sequence.
 func (r *Resolver) ResetSequence() {
 	if r.dispatcher != nil {
@@ -254,3 +254,24 @@ func TestDeriveScope(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveKey_CountPrefixScalesCountedIntents(t *testing.T) {
+	r := makeResolver([]keybindings.Binding{
+		{Action: "revisions.move_up", Scope: "revisions", Key: []string{"k"}},
+		{Action: "revisions.jump_to_parent", Scope: "revisions", Key: []string{"J"}},
+		{Action: "revisions.new", Scope: "revisions", Key: []string{"n"}},
+	}, nil)
+	scopes := createScopes("revisions")
+
+	r.ResolveKey(keyMsg("5"), scopes)
+	result := r.ResolveKey(keyMsg("k"), scopes)
+	assert.Equal(t, intents.Navigate{Delta: -5}, result.Intent)
+
+	r.ResolveKey(keyMsg("3"), scopes)
+	result = r.ResolveKey(keyMsg("J"), scopes)
+	assert.Equal(t, intents.Navigate{Target: intents.TargetParent, Count: 3}, result.Intent)
+
+	r.ResolveKey(keyMsg("2"), scopes)
+	result = r.ResolveKey(keyMsg("n"), scopes)
+	assert.Equal(t, intents.StartNew{}, result.Intent, "intents without a count ignore it")
+}
@@ -0,0 +1,62 @@
+package fix
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/context"
+)
+
+// Run runs `jj fix` on sources and their descendants, or on the revsets.fix
+// revset when sources is empty. The revisions are listed in the returned
+// command first. When the fix succeeds, then runs, after which the rewritten
+// revisions and their changed files are reported, so that the report's output
+// is the one highlighted in the revision graph.
+func Run(ctx *context.MainContext, sources jj.SelectedRevisions, ignoreImmutable bool, then tea.Cmd) tea.Cmd {
+	revset := jj.FixRevset(sources, ctx.JJConfig.Revsets.Fix)
+	return func() tea.Msg {
+		before, err := ctx.RunCommandImmediate(jj.GetCommitIds(revset))
+		if err != nil {
+			return common.CommandCompletedMsg{Err: err}
+		}
+		return ctx.RunCommandOnSuccess(jj.Fix(sources, ignoreImmutable), then, report(ctx, revset, string(before)))()
+	}
+}
+
+func report(runner context.CommandRunner, revset string, before string) tea.Cmd {
+	return func() tea.Msg {
+		after, err := runner.RunCommandImmediate(jj.GetCommitIds(revset))
+		if err != nil {
+			return nil
+		}
+		rewritten := jj.RewrittenCommits(before, string(after))
+		if len(rewritten) == 0 {
+			return nil
+		}
+		return common.CommandCompletedMsg{Output: Summary(runner, rewritten)}
+	}
+}
+
+// Summary lists the rewritten revisions, each followed by the files the fix
+// changed in it. Revision lines are indented so that they are picked up when
+// highlighting affected revisions.
+func Summary(runner context.CommandRunner, rewritten []jj.RewrittenCommit) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Fixed %d revision(s):\n", len(rewritten))
+	for _, commit := range rewritten {
+		fmt.Fprintf(&b, "  %s %s\n", commit.ChangeId, commit.To)
+		output, err := runner.RunCommandImmediate(jj.DiffSummary(commit.From, commit.To))
+		if err != nil {
+			continue
+		}
+		for line := range strings.SplitSeq(string(output), "\n") {
+			if file, ok := jj.ParseSummaryFile(line); ok {
+				fmt.Fprintf(&b, "    %c %s\n", file.Status, file.Name)
+			}
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,82 @@
+package fix
+
+import (
+	"errors"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun_ReportsRewrittenRevisionsAndFiles(t *testing.T) {
+	sources := jj.NewSelectedRevisions(&jj.Commit{ChangeId: "abc"})
+	revset := jj.FixRevset(sources, "")
+
+	commandRunner := test.NewTestCommandRunner(t)
+	commitIds := commandRunner.Expect(jj.GetCommitIds(revset)).SetOutput([]byte("abcabcabcabc 1111\ndefdefdefdef 2222"))
+	commandRunner.Expect(jj.Fix(sources, false))
+	defer commandRunner.Verify()
+
+	// listing the revisions again after jj fix reports the rewritten commit
+	commandRunner.Expect(jj.DiffSummary("1111", "3333")).SetOutput([]byte("M main.go\nM README.md\n"))
+	then := func() tea.Msg {
+		commitIds.SetOutput([]byte("abcabcabcabc 3333\ndefdefdefdef 2222"))
+		return common.CommandCompletedMsg{Output: "pushed"}
+	}
+
+	var reports []string
+	test.SimulateModel(recorder(func(msg tea.Msg) {
+		if completed, ok := msg.(common.CommandCompletedMsg); ok && completed.Output != "" {
+			reports = append(reports, completed.Output)
+		}
+	}), Run(test.NewTestContext(commandRunner), sources, false, then))
+
+	assert.Equal(t, []string{"pushed", "Fixed 1 revision(s):\n  abcabcabcabc 3333\n    M main.go\n    M README.md"}, reports)
+}
+
+func TestRun_StopsWhenFixFails(t *testing.T) {
+	sources := jj.NewSelectedRevisions(&jj.Commit{ChangeId: "abc"})
+
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetCommitIds(jj.FixRevset(sources, "")))
+	commandRunner.Expect(jj.Fix(sources, false)).SetError(errors.New("formatter failed"))
+	defer commandRunner.Verify()
+
+	ran := false
+	then := func() tea.Msg {
+		ran = true
+		return nil
+	}
+	var errs []error
+	test.SimulateModel(recorder(func(msg tea.Msg) {
+		if completed, ok := msg.(common.CommandCompletedMsg); ok && completed.Err != nil {
+			errs = append(errs, completed.Err)
+		}
+	}), Run(test.NewTestContext(commandRunner), sources, false, then))
+
+	assert.False(t, ran)
+	assert.Equal(t, []error{errors.New("formatter failed")}, errs)
+}
+
+func TestRun_UsesConfiguredFixRevsetWithoutSources(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetCommitIds("mine() & mutable()"))
+	commandRunner.Expect(jj.Fix(jj.NewSelectedRevisions(), false))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.JJConfig.Revsets.Fix = "mine() & mutable()"
+	test.SimulateModel(recorder(func(tea.Msg) {}), Run(ctx, jj.NewSelectedRevisions(), false, nil))
+}
+
+type recorder func(tea.Msg)
+
+func (r recorder) Init() tea.Cmd { return nil }
+
+func (r recorder) Update(msg tea.Msg) tea.Cmd {
+	r(msg)
+	return nil
+}
@@ -97,6 +97,7 @@ func (m *CommandHistoryModel) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+//jjui:palette scope=flash selected=true
 func (m *CommandHistoryModel) ViewRect(dl *render.DisplayContext, box layout.Box) {
 	rest, _ := box.CutBottom(1)
 	area := rest.R
@@ -110,7 +111,7 @@ func (m *CommandHistoryModel) ViewRect(dl *render.DisplayContext, box layout.Box
 		rect := layout.Rect(area.Max.X-item.w, y, item.w, item.h)
 		surfaceStyle := common.DefaultPalette.Get("flash", "", "text", false)
 		if item.index == m.selectedIndex {
-			surfaceStyle = flashBackgroundStyle(m.items[item.index].Err)
+			surfaceStyle = common.DefaultPalette.Get("flash", "", "", true).Inherit(flashBackgroundStyle(m.items[item.index].Err))
 		}
 		dl.AddFill(rect, ' ', surfaceStyle, render.ZOverlay)
 		dl.AddDraw(rect, item.content, render.ZOverlay, render.PreserveBackground())
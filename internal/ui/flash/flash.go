@@ -7,6 +7,7 @@ import (
 	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/ui/common"
 	"github.com/idursun/jjui/internal/ui/intents"
@@ -30,15 +31,23 @@ type flashMessage struct {
 type Model struct {
 	messages        []flashMessage
 	messageHistory  []flashMessage // completed commands only
-	pendingCommands map[int]string
+	pendingCommands map[int]pendingCommand
 	pendingResults  map[int]pendingResult
 	spinner         spinner.Model
 	renderer        CardRenderer
 	currentId       uint64
+	messageLog      *config.MessageLog
 }
 
 const HistoryLimit = 50
 
+type pendingCommand struct {
+	command  string
+	args     []string
+	location string
+	stdin    bool
+}
+
 type pendingResult struct {
 	Output string
 	Err    error
@@ -56,19 +65,20 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		m.removeLiveMessageByID(msg.id)
 		return nil
 	case common.CommandRunningMsg:
-		m.pendingCommands[msg.ID] = msg.Command
+		pending := pendingCommand{command: msg.Command, args: msg.Args, location: msg.Location, stdin: msg.Stdin}
+		m.pendingCommands[msg.ID] = pending
 		if result, ok := m.pendingResults[msg.ID]; ok {
 			delete(m.pendingCommands, msg.ID)
 			delete(m.pendingResults, msg.ID)
-			return m.completeCommand(msg.Command, result.Output, result.Err)
+			return m.completeCommand(pending, result.Output, result.Err)
 		}
 		return m.spinner.Tick
 	case common.CommandCompletedMsg:
 		if msg.ID == 0 {
-			return m.completeCommand("", msg.Output, msg.Err)
+			return m.completeCommand(pendingCommand{}, msg.Output, msg.Err)
 		}
-		cmd := m.pendingCommands[msg.ID]
-		if cmd == "" {
+		cmd, ok := m.pendingCommands[msg.ID]
+		if !ok {
 			if m.pendingResults == nil {
 				m.pendingResults = make(map[int]pendingResult)
 			}
@@ -139,7 +149,7 @@ func (m *Model) renderMessages(dl *render.DisplayContext, area layout.Rectangle,
 func (m *Model) renderPendingCommands(dl *render.DisplayContext, area layout.Rectangle, y int) int {
 	maxWidth := area.Dx() - 4
 	for _, cmd := range m.pendingCommands {
-		content := m.renderer.RenderRunningCommand(cmd, m.spinner.View(), maxWidth)
+		content := m.renderer.RenderRunningCommand(cmd.command, m.spinner.View(), maxWidth)
 		w, h := lipgloss.Size(content)
 		y -= h
 		rect := layout.Rect(area.Max.X-w, y, w, h)
@@ -167,8 +177,8 @@ func (m *Model) removeLiveMessageByID(id uint64) bool {
 	return false
 }
 
-func (m *Model) completeCommand(command string, output string, commandErr error) tea.Cmd {
-	id := m.AddWithCommand(output, command, commandErr)
+func (m *Model) completeCommand(command pendingCommand, output string, commandErr error) tea.Cmd {
+	id := m.addWithArgs(output, command, commandErr)
 	if id != 0 && commandErr == nil {
 		expiringMessageTimeout := config.GetExpiringFlashMessageTimeout(config.Current)
 		if expiringMessageTimeout > time.Duration(0) {
@@ -185,10 +195,16 @@ func (m *Model) add(text string, error error) uint64 {
 }
 
 func (m *Model) AddWithCommand(text string, command string, error error) uint64 {
+	return m.addWithArgs(text, pendingCommand{command: command}, error)
+}
+
+func (m *Model) addWithArgs(text string, pending pendingCommand, error error) uint64 {
+	command := pending.command
 	text = strings.TrimSpace(text)
 	if text == "" && error == nil && command == "" {
 		return 0
 	}
+	m.logMessage(text, pending, error)
 
 	msg := flashMessage{
 		id:      m.nextId(),
@@ -204,6 +220,33 @@ func (m *Model) AddWithCommand(text string, command string, error error) uint64
 	return msg.id
 }
 
+// SetMessageLog makes every message also go to log, which outlives the session.
+func (m *Model) SetMessageLog(log *config.MessageLog) {
+	m.messageLog = log
+}
+
+func (m *Model) logMessage(text string, command pendingCommand, err error) {
+	if m.messageLog == nil {
+		return
+	}
+	entry := config.MessageLogEntry{
+		Time:     time.Now(),
+		Severity: config.SeverityInfo,
+		Command:  command.command,
+		Args:     command.args,
+		Location: command.location,
+		Stdin:    command.stdin,
+		Text:     ansi.Strip(text),
+	}
+	if err != nil {
+		entry.Severity = config.SeverityError
+		if text == "" {
+			entry.Text = strings.TrimSpace(ansi.Strip(err.Error()))
+		}
+	}
+	m.messageLog.Append(entry)
+}
+
 func (m *Model) addHistory(msg flashMessage) {
 	m.messageHistory = append(m.messageHistory, msg)
 	if len(m.messageHistory) > HistoryLimit {
@@ -237,7 +280,7 @@ func New() *Model {
 	return &Model{
 		messages:        make([]flashMessage, 0),
 		messageHistory:  make([]flashMessage, 0),
-		pendingCommands: make(map[int]string),
+		pendingCommands: make(map[int]pendingCommand),
 		pendingResults:  make(map[int]pendingResult),
 		renderer:        NewCardRenderer(),
 		spinner:         s,
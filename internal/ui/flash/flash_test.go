@@ -6,11 +6,13 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/ui/common"
 	"github.com/idursun/jjui/internal/ui/intents"
 	"github.com/idursun/jjui/internal/ui/layout"
 	"github.com/idursun/jjui/internal/ui/render"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAdd_IgnoresEmptyMessages(t *testing.T) {
@@ -164,3 +166,23 @@ func TestHistory_OnlyContainsCommandMessages(t *testing.T) {
 		assert.Equal(t, "command output", snapshot[0].Text)
 	}
 }
+
+func TestMessageLog_RecordsMessagesAndCommands(t *testing.T) {
+	m := New()
+	messageLog := config.NewMessageLog("")
+	m.SetMessageLog(messageLog)
+
+	m.Update(intents.AddMessage{Text: "theme saved"})
+	m.Update(common.CommandRunningMsg{ID: 3, Command: "jj git push", Args: []string{"git", "push"}})
+	m.Update(common.CommandCompletedMsg{ID: 3, Err: errors.New("\x1b[1mrejected\x1b[0m\n")})
+
+	entries := messageLog.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, config.SeverityInfo, entries[0].Severity)
+	assert.Equal(t, "theme saved", entries[0].Text)
+	assert.Equal(t, config.SeverityError, entries[1].Severity)
+	assert.Equal(t, "jj git push", entries[1].Command)
+	assert.Equal(t, []string{"git", "push"}, entries[1].Args)
+	assert.Equal(t, "rejected", entries[1].Text)
+	assert.False(t, entries[1].Time.IsZero())
+}
@@ -0,0 +1,242 @@
+package fuzzy_revisions
+
+import (
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/fuzzy_search"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/render"
+	"github.com/sahilm/fuzzy"
+)
+
+type entry struct {
+	changeId    string
+	commitId    string
+	description string
+	author      string
+	bookmarks   string
+	date        string
+	hidden      bool
+}
+
+func (e entry) String() string {
+	description := e.description
+	if description == "" {
+		description = "(no description set)"
+	}
+	parts := []string{e.changeId, description, e.author}
+	if e.bookmarks != "" {
+		parts = append(parts, e.bookmarks)
+	}
+	parts = append(parts, e.date)
+	if e.hidden {
+		parts = append(parts, "(hidden "+e.commitId+")")
+	}
+	return strings.Join(parts, " ")
+}
+
+type fuzzyRevisions struct {
+	context *context.MainContext
+	entries []entry
+	lines   []string
+	cursor  int
+	max     int
+	matches fuzzy.Matches
+	input   string
+	loading bool
+}
+
+// hiddenOperationsLimit is how many of the latest operations are searched
+// for hidden revisions.
+const hiddenOperationsLimit = 100
+
+// loadedMsg carries the output of jj.FinderVisible and jj.FinderHidden.
+type loadedMsg struct {
+	visible []byte
+	hidden  []byte
+	err     error
+}
+
+func (fzf *fuzzyRevisions) Init() tea.Cmd {
+	return func() tea.Msg {
+		visible, err := fzf.context.RunCommandImmediate(jj.FinderVisible())
+		if err != nil {
+			return loadedMsg{err: err}
+		}
+		// hidden revisions are a bonus; the finder works without them
+		operations, err := fzf.context.RunCommandImmediate(jj.FinderOperations(hiddenOperationsLimit))
+		if err != nil {
+			return loadedMsg{visible: visible}
+		}
+		hidden, _ := fzf.context.RunCommandImmediate(jj.FinderHidden(strings.Fields(string(operations))))
+		return loadedMsg{visible: visible, hidden: hidden}
+	}
+}
+
+func (fzf *fuzzyRevisions) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case intents.Intent:
+		return fzf.handleIntent(msg)
+	case loadedMsg:
+		fzf.loading = false
+		if msg.err != nil {
+			return intents.Invoke(intents.AddMessage{Text: msg.err.Error(), Err: msg.err})
+		}
+		fzf.entries = buildEntries(msg.visible, msg.hidden)
+		fzf.lines = make([]string, len(fzf.entries))
+		for i, e := range fzf.entries {
+			fzf.lines[i] = e.String()
+		}
+		fzf.search(fzf.input)
+	case fuzzy_search.SearchMsg:
+		fzf.input = msg.Input
+		fzf.search(msg.Input)
+	}
+	return nil
+}
+
+func (fzf *fuzzyRevisions) handleIntent(intent intents.Intent) tea.Cmd {
+	switch intent := intent.(type) {
+	case intents.SuggestNavigate:
+		fzf.moveCursor(intent.Delta)
+	case intents.RevisionFinderAccept:
+		if selected, ok := fzf.selected(); ok {
+			return fzf.context.SelectRevision(selected.commitId)
+		}
+	}
+	return nil
+}
+
+func (fzf *fuzzyRevisions) selected() (entry, bool) {
+	if fzf.cursor < 0 || fzf.cursor >= len(fzf.matches) {
+		return entry{}, false
+	}
+	return fzf.entries[fzf.matches[fzf.cursor].Index], true
+}
+
+func (fzf *fuzzyRevisions) moveCursor(inc int) {
+	l := min(len(fzf.matches), fzf.max)
+	if l == 0 {
+		return
+	}
+	n := fzf.cursor + inc
+	if n < 0 {
+		n = l - 1
+	}
+	if n >= l {
+		n = 0
+	}
+	fzf.cursor = n
+}
+
+func (fzf *fuzzyRevisions) Max() int {
+	return fzf.max
+}
+
+func (fzf *fuzzyRevisions) Matches() fuzzy.Matches {
+	return fzf.matches
+}
+
+func (fzf *fuzzyRevisions) SelectedMatch() int {
+	return fzf.cursor
+}
+
+func (fzf *fuzzyRevisions) Len() int {
+	return len(fzf.lines)
+}
+
+func (fzf *fuzzyRevisions) String(i int) string {
+	if i < 0 || i >= len(fzf.lines) {
+		return ""
+	}
+	return fzf.lines[i]
+}
+
+func (fzf *fuzzyRevisions) search(input string) {
+	src := &fuzzy_search.RefinedSource{Source: fzf}
+	fzf.cursor = 0
+	fzf.matches = src.Search(input, fzf.Len())
+}
+
+func (fzf *fuzzyRevisions) ViewRect(dl *render.DisplayContext, box layout.Box) {
+	if desired := box.R.Dy()/2 - 1; desired > 0 {
+		fzf.max = desired
+	}
+	content := fzf.viewContent()
+	if content == "" {
+		return
+	}
+	_, h := lipgloss.Size(content)
+	rect := layout.Rect(box.R.Min.X, box.R.Max.Y-h, box.R.Dx(), h)
+	background := common.DefaultPalette.Get("status", "", "text", false)
+	dl.AddFill(rect, ' ', background, render.ZFuzzyOverlay)
+	dl.AddDraw(rect, content, render.ZFuzzyOverlay)
+	dl.AddHighlight(rect, background, render.ZFuzzyOverlay)
+}
+
+func (fzf *fuzzyRevisions) viewContent() string {
+	if fzf.loading {
+		return common.DefaultPalette.Get("status", "", "title", false).Render("  loading revisions… ")
+	}
+	shown := len(fzf.matches)
+	if shown == 0 {
+		return ""
+	}
+	title := common.DefaultPalette.Get("status", "", "title", false).Render(
+		"  ",
+		strconv.Itoa(shown),
+		"of",
+		strconv.Itoa(len(fzf.entries)),
+		"revisions",
+		" ",
+	)
+	return lipgloss.JoinVertical(0, title, fuzzy_search.View(fzf))
+}
+
+// NewModel opens the finder in its loading state; the revisions are listed
+// by the command returned from Init.
+func NewModel(ctx *context.MainContext) fuzzy_search.Model {
+	return &fuzzyRevisions{
+		context: ctx,
+		max:     30,
+		loading: true,
+	}
+}
+
+// buildEntries lists the visible revisions followed by the versions from the
+// evolution log that aren't visible anymore.
+func buildEntries(visible []byte, hidden []byte) []entry {
+	var entries []entry
+	seen := make(map[string]struct{})
+	add := func(output []byte, isHidden bool) {
+		for _, line := range strings.Split(string(output), "\n") {
+			fields := strings.Split(line, jj.FinderFieldSeparator)
+			if len(fields) != 6 {
+				continue
+			}
+			if _, ok := seen[fields[1]]; ok {
+				continue
+			}
+			seen[fields[1]] = struct{}{}
+			entries = append(entries, entry{
+				changeId:    fields[0],
+				commitId:    fields[1],
+				description: fields[2],
+				author:      fields[3],
+				bookmarks:   fields[4],
+				date:        fields[5],
+				hidden:      isHidden,
+			})
+		}
+	}
+	add(visible, false)
+	add(hidden, true)
+	return entries
+}
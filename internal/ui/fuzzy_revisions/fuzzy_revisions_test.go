@@ -0,0 +1,94 @@
+package fuzzy_revisions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/fuzzy_search"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func finderLine(fields ...string) string {
+	return strings.Join(fields, jj.FinderFieldSeparator)
+}
+
+func newFinder(t *testing.T, commandRunner *test.CommandRunner) *fuzzyRevisions {
+	visible := finderLine("kx", "a1", "fix parser", "Jane", "main", "2024-01-02") + "\n" +
+		finderLine("qz", "b2", "", "Joe", "", "2024-01-01") + "\n"
+	hidden := finderLine("kx", "a1", "fix parser", "Jane", "main", "2024-01-02") + "\n" +
+		finderLine("kx", "c3", "wip parser", "Jane", "", "2023-12-31") + "\n"
+	ctx := test.NewTestContext(commandRunner)
+	ctx.CurrentRevset = "trunk()::"
+	model := NewModel(ctx).(*fuzzyRevisions)
+	model.Update(loadedMsg{visible: []byte(visible), hidden: []byte(hidden)})
+	return model
+}
+
+func TestInit_LoadsVisibleAndHiddenRevisions(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.FinderVisible()).SetOutput([]byte(finderLine("kx", "a1", "fix parser", "Jane", "main", "2024-01-02") + "\n"))
+	commandRunner.Expect(jj.FinderOperations(hiddenOperationsLimit)).SetOutput([]byte("op2\nop1\n"))
+	// an abandoned revision has no visible successor and only shows up at an older operation
+	commandRunner.Expect(jj.FinderHidden([]string{"op2", "op1"})).SetOutput([]byte(
+		finderLine("kx", "c3", "wip parser", "Jane", "", "2023-12-31") + "\n" +
+			finderLine("mo", "d4", "abandoned spike", "Jane", "", "2023-12-30") + "\n"))
+	defer commandRunner.Verify()
+	model := NewModel(test.NewTestContext(commandRunner)).(*fuzzyRevisions)
+	assert.Contains(t, model.viewContent(), "loading revisions")
+
+	model.Update(model.Init()())
+
+	assert.False(t, model.loading)
+	assert.Equal(t, []string{
+		"kx fix parser Jane main 2024-01-02",
+		"kx wip parser Jane 2023-12-31 (hidden c3)",
+		"mo abandoned spike Jane 2023-12-30 (hidden d4)",
+	}, model.lines)
+	assert.Len(t, model.matches, 3)
+}
+
+func TestNewModel_ListsVisibleThenHiddenRevisions(t *testing.T) {
+	model := newFinder(t, test.NewTestCommandRunner(t))
+
+	assert.Equal(t, []string{
+		"kx fix parser Jane main 2024-01-02",
+		"qz (no description set) Joe 2024-01-01",
+		"kx wip parser Jane 2023-12-31 (hidden c3)",
+	}, model.lines)
+}
+
+func TestSearch_MatchesAuthorAndDescription(t *testing.T) {
+	model := newFinder(t, test.NewTestCommandRunner(t))
+
+	model.Update(fuzzy_search.SearchMsg{Input: "jane wip"})
+
+	assert.Len(t, model.matches, 1)
+	assert.Equal(t, "c3", model.entries[model.matches[0].Index].commitId)
+}
+
+func TestAccept_SelectsRevisionInCurrentRevset(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.RevsetCount("a1 & (trunk()::)", 1)).SetOutput([]byte("x"))
+	defer commandRunner.Verify()
+	model := newFinder(t, commandRunner)
+
+	cmd := model.Update(intents.RevisionFinderAccept{})
+
+	assert.Equal(t, common.RefreshMsg{SelectedRevision: "a1"}, cmd())
+}
+
+func TestAccept_WidensRevsetForRevisionOutsideIt(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.RevsetCount("c3 & (trunk()::)", 1))
+	defer commandRunner.Verify()
+	model := newFinder(t, commandRunner)
+	model.Update(fuzzy_search.SearchMsg{Input: "wip"})
+
+	cmd := model.Update(intents.RevisionFinderAccept{})
+
+	assert.Equal(t, common.RevealRevisionMsg{Revset: "(trunk()::) | c3", Revision: "c3"}, cmd())
+}
@@ -8,12 +8,15 @@ import (
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/ui/actions"
 	"github.com/idursun/jjui/internal/ui/common"
 	"github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/fix"
 	"github.com/idursun/jjui/internal/ui/intents"
 	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/operations/ace_jump"
 	"github.com/idursun/jjui/internal/ui/render"
 )
 
@@ -91,6 +94,7 @@ type Model struct {
 	filterText          string
 	categoryFilter      string
 	ensureCursorVisible bool
+	jumpLabels          *ace_jump.Labels
 	revisions           jj.SelectedRevisions
 	remoteNames         []string
 	selectedRemoteIdx   int
@@ -106,8 +110,19 @@ func (m *Model) IsEditing() bool {
 }
 
 func (m *Model) Scopes() []common.Scope {
+	if m.jumpLabels.Active() {
+		return []common.Scope{
+			m.jumpLabels.Scope(),
+			{
+				Name:    actions.ScopeGit,
+				Leak:    common.LeakNone,
+				Handler: m,
+			},
+		}
+	}
 	if m.IsEditing() {
 		return []common.Scope{
+			//jjui:layer scope=git.filter
 			{
 				Name:    actions.ScopeGit + ".filter",
 				Leak:    common.LeakNone,
@@ -219,7 +234,7 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		if !ok {
 			return nil, true
 		}
-		return tea.Batch(common.CloseApplied, m.context.RunCommand(jj.Args(selected.command...), common.Refresh)), true
+		return m.run(selected), true
 	case intents.GitFilter:
 		filter := string(msg.Kind)
 		if filter == "" {
@@ -246,6 +261,13 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		}
 		m.moveCursor(msg.Delta)
 		return nil, true
+	case intents.StartAceJump:
+		m.jumpLabels = ace_jump.NewListLabels(m.listRenderer, func(index int) tea.Cmd {
+			m.cursor = index
+			m.ensureCursorVisible = true
+			return nil
+		})
+		return nil, true
 	case intents.Cancel:
 		if m.filterState == filterEditing {
 			m.resetTextFilter()
@@ -262,7 +284,7 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		}
 		for _, listItem := range m.visibleItems() {
 			if listItem.key == msg.Key {
-				return tea.Batch(common.CloseApplied, m.context.RunCommand(jj.Args(listItem.command...), common.Refresh)), true
+				return m.run(listItem), true
 			}
 		}
 		return nil, true
@@ -270,6 +292,16 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 	return nil, false
 }
 
+// run closes the menu and runs the item's command. Pushes are preceded by
+// `jj fix` on the selected revisions when git.fix_before_push is enabled.
+func (m *Model) run(item item) tea.Cmd {
+	command := m.context.RunCommand(jj.Args(item.command...), common.Refresh)
+	if item.category == itemCategoryPush && config.Current.Git.FixBeforePush {
+		command = fix.Run(m.context, m.revisions, false, command)
+	}
+	return tea.Batch(common.CloseApplied, command)
+}
+
 func (m *Model) executeDefaultForFilter(kind intents.GitFilterKind) tea.Cmd {
 	selectedRemote := ""
 	if len(m.remoteNames) > 0 && m.selectedRemoteIdx >= 0 && m.selectedRemoteIdx < len(m.remoteNames) {
@@ -285,18 +317,18 @@ func (m *Model) executeDefaultForFilter(kind intents.GitFilterKind) tea.Cmd {
 	if ok {
 		for _, listItem := range m.visibleItems() {
 			if slices.Equal(listItem.command, defaultCommand) {
-				return tea.Batch(common.CloseApplied, m.context.RunCommand(jj.Args(listItem.command...), common.Refresh))
+				return m.run(listItem)
 			}
 		}
 	}
 
 	if selected, ok := m.selectedItem(); ok {
-		return tea.Batch(common.CloseApplied, m.context.RunCommand(jj.Args(selected.command...), common.Refresh))
+		return m.run(selected)
 	}
 
 	for _, listItem := range m.visibleItems() {
 		if string(listItem.category) == string(kind) {
-			return tea.Batch(common.CloseApplied, m.context.RunCommand(jj.Args(listItem.command...), common.Refresh))
+			return m.run(listItem)
 		}
 	}
 	return nil
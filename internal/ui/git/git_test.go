@@ -1,10 +1,12 @@
 package git
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/ui/intents"
 	"github.com/idursun/jjui/internal/ui/layout"
@@ -25,6 +27,58 @@ func Test_Push(t *testing.T) {
 	test.SimulateModel(op, func() tea.Msg { return intents.Apply{} })
 }
 
+func Test_PushRunsFixFirstWhenConfigured(t *testing.T) {
+	original := config.Current.Git.FixBeforePush
+	t.Cleanup(func() { config.Current.Git.FixBeforePush = original })
+	config.Current.Git.FixBeforePush = true
+
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GitRemoteList()).SetOutput([]byte(""))
+	commandRunner.Expect(jj.GetCommitIds(jj.DefaultFixRevset))
+	commandRunner.Expect(jj.Fix(jj.NewSelectedRevisions(), false))
+	commandRunner.Expect(jj.GitPush("--remote", ""))
+	defer commandRunner.Verify()
+
+	op := NewModel(test.NewTestContext(commandRunner), jj.NewSelectedRevisions())
+	test.SimulateModel(op, op.Init())
+	_ = test.RenderImmediate(op, 100, 40)
+	test.SimulateModel(op, func() tea.Msg { return intents.Apply{} })
+}
+
+func Test_PushIsSkippedWhenFixFails(t *testing.T) {
+	original := config.Current.Git.FixBeforePush
+	t.Cleanup(func() { config.Current.Git.FixBeforePush = original })
+	config.Current.Git.FixBeforePush = true
+
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GitRemoteList()).SetOutput([]byte(""))
+	commandRunner.Expect(jj.GetCommitIds(jj.DefaultFixRevset))
+	commandRunner.Expect(jj.Fix(jj.NewSelectedRevisions(), false)).SetError(errors.New("formatter failed"))
+	defer commandRunner.Verify()
+
+	op := NewModel(test.NewTestContext(commandRunner), jj.NewSelectedRevisions())
+	test.SimulateModel(op, op.Init())
+	_ = test.RenderImmediate(op, 100, 40)
+	test.SimulateModel(op, func() tea.Msg { return intents.Apply{} })
+}
+
+func Test_FetchDoesNotRunFix(t *testing.T) {
+	original := config.Current.Git.FixBeforePush
+	t.Cleanup(func() { config.Current.Git.FixBeforePush = original })
+	config.Current.Git.FixBeforePush = true
+
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GitRemoteList()).SetOutput([]byte(""))
+	commandRunner.Expect(jj.GitFetch("--remote", ""))
+	defer commandRunner.Verify()
+
+	op := NewModel(test.NewTestContext(commandRunner), jj.NewSelectedRevisions())
+	test.SimulateModel(op, op.Init())
+	_ = test.RenderImmediate(op, 100, 40)
+	test.SimulateModel(op, func() tea.Msg { return intents.GitFilter{Kind: intents.GitFilterFetch} })
+	test.SimulateModel(op, func() tea.Msg { return intents.GitFilter{Kind: intents.GitFilterFetch} })
+}
+
 func Test_Fetch(t *testing.T) {
 	commandRunner := test.NewTestCommandRunner(t)
 	commandRunner.Expect(jj.GitRemoteList()).SetOutput([]byte(""))
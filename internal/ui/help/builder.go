@@ -32,12 +32,33 @@ func FlatEntries(groups []ScopeGroup) []Entry {
 	return entries
 }
 
+// BindingEntry is a help entry together with the action it runs.
+type BindingEntry struct {
+	Entry
+	Action keybindings.Action
+	Args   map[string]any
+}
+
 // BuildFromBindings returns short-help entries for the given scope.
 func BuildFromBindings(
 	scope keybindings.ScopeName,
 	bindings []config.BindingConfig,
 ) []Entry {
-	entries := make([]Entry, 0)
+	bindingEntries := BuildBindingEntries(scope, bindings)
+	entries := make([]Entry, 0, len(bindingEntries))
+	for _, e := range bindingEntries {
+		entries = append(entries, e.Entry)
+	}
+	return entries
+}
+
+// BuildBindingEntries is BuildFromBindings keeping the action and args of
+// each entry.
+func BuildBindingEntries(
+	scope keybindings.ScopeName,
+	bindings []config.BindingConfig,
+) []BindingEntry {
+	entries := make([]BindingEntry, 0)
 	seenActions := map[string]struct{}{}
 
 	for i := len(bindings) - 1; i >= 0; i-- {
@@ -59,9 +80,13 @@ func BuildFromBindings(
 			continue
 		}
 
-		entries = append(entries, Entry{
-			Label: label,
-			Desc:  bindingDesc(b),
+		entries = append(entries, BindingEntry{
+			Entry: Entry{
+				Label: label,
+				Desc:  bindingDesc(b),
+			},
+			Action: action,
+			Args:   b.Args,
 		})
 		seenActions[identity] = struct{}{}
 	}
@@ -187,19 +212,19 @@ func bindingDesc(b config.BindingConfig) string {
 	if desc := strings.TrimSpace(b.Desc); desc != "" {
 		return desc
 	}
-	return descFromAction(string(keybindings.Action(strings.TrimSpace(b.Action))))
+	return DescFromAction(string(keybindings.Action(strings.TrimSpace(b.Action))))
 }
 
 func continuationDesc(c dispatch.Continuation) string {
 	if desc := strings.TrimSpace(c.Desc); desc != "" {
 		return desc
 	}
-	return descFromAction(string(c.Action))
+	return DescFromAction(string(c.Action))
 }
 
-// descFromAction derives a human-readable description from the action token
+// DescFromAction derives a human-readable description from the action token
 // (last segment after '.'), replacing underscores with spaces.
-func descFromAction(action string) string {
+func DescFromAction(action string) string {
 	token := actionToken(action)
 	if token == "" {
 		return ""
@@ -30,11 +30,14 @@ var scopeDisplayNames = map[string]string{
 	"revisions.duplicate":            "Duplicate",
 	"revisions.abandon":              "Abandon",
 	"revisions.set_parents":          "Set Parents",
+	"revisions.parallelize":          "Parallelize",
+	"revisions.simplify_parents":     "Simplify Parents",
 	"revisions.details":              "Details",
 	"revisions.details.confirmation": "Details Confirmation",
 	"revisions.evolog":               "Evolog",
 	"revisions.inline_describe":      "Inline Describe",
 	"revisions.set_bookmark":         "Set Bookmark",
+	"revisions.metaedit":             "Edit Metadata",
 	"revisions.target_picker":        "Target Picker",
 	"revisions.ace_jump":             "Ace Jump",
 	"revisions.quick_search":         "Quick Search",
@@ -67,11 +70,14 @@ var scopeOrder = []string{
 	"revisions.duplicate",
 	"revisions.abandon",
 	"revisions.set_parents",
+	"revisions.parallelize",
+	"revisions.simplify_parents",
 	"revisions.details",
 	"revisions.details.confirmation",
 	"revisions.evolog",
 	"revisions.inline_describe",
 	"revisions.set_bookmark",
+	"revisions.metaedit",
 	"revisions.target_picker",
 	"revisions.ace_jump",
 	"revisions.quick_search",
@@ -121,6 +127,7 @@ func (m *Model) IsFocused() bool {
 func (m *Model) Scopes() []common.Scope {
 	if m.IsEditing() {
 		return []common.Scope{
+			//jjui:layer scope=help.filter
 			{
 				Name:    actions.ScopeHelp + ".filter",
 				Leak:    common.LeakNone,
@@ -428,7 +435,7 @@ func bindingsToEntries(bindings []config.BindingConfig) []Entry {
 		}
 		desc := strings.TrimSpace(b.Desc)
 		if desc == "" {
-			desc = descFromAction(action)
+			desc = DescFromAction(action)
 		}
 
 		key := action + "|" + desc
@@ -0,0 +1,193 @@
+package input
+
+import (
+	"fmt"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+// Field describes a single labelled line of a Form.
+type Field struct {
+	Label       string
+	Value       string
+	Placeholder string
+	Validate    func(string) error
+}
+
+// Form is a stack of labelled text inputs with per-field validation. It is
+// meant to be embedded by operations that need more than a single value.
+type Form struct {
+	fields  []Field
+	inputs  []textinput.Model
+	focused int
+	err     error
+	scope   string
+	Z       int // z-index of the drawn fields; default is 0.
+}
+
+func NewForm(scope string, fields ...Field) *Form {
+	f := &Form{
+		fields: fields,
+		inputs: make([]textinput.Model, len(fields)),
+		scope:  scope,
+	}
+	for i, field := range fields {
+		ti := textinput.New()
+		ti.Prompt = ""
+		ti.Placeholder = field.Placeholder
+		ti.SetVirtualCursor(false)
+		ti.SetValue(field.Value)
+		ti.CursorEnd()
+		f.inputs[i] = ti
+	}
+	if len(f.inputs) > 0 {
+		f.inputs[0].Focus()
+	}
+	return f
+}
+
+func (f *Form) Focused() int {
+	return f.focused
+}
+
+// FocusNext moves the focus by delta fields, wrapping around at both ends.
+func (f *Form) FocusNext(delta int) tea.Cmd {
+	if len(f.inputs) == 0 {
+		return nil
+	}
+	return f.focus((f.focused + delta + len(f.inputs)) % len(f.inputs))
+}
+
+// Focus moves the focus to the field at index.
+func (f *Form) Focus(index int) tea.Cmd {
+	return f.focus(index)
+}
+
+// Blur removes the focus from the form, e.g. while a list next to it is
+// being navigated.
+func (f *Form) Blur() {
+	if len(f.inputs) > 0 {
+		f.inputs[f.focused].Blur()
+	}
+}
+
+func (f *Form) focus(index int) tea.Cmd {
+	f.inputs[f.focused].Blur()
+	f.focused = index
+	return f.inputs[f.focused].Focus()
+}
+
+func (f *Form) Value(index int) string {
+	return f.inputs[index].Value()
+}
+
+func (f *Form) SetValue(index int, value string) {
+	f.inputs[index].SetValue(value)
+	f.inputs[index].CursorEnd()
+}
+
+// SetInitialValue replaces the value a field is compared against by Changed.
+// The field shows the new value unless it was already edited.
+func (f *Form) SetInitialValue(index int, value string) {
+	if !f.Changed(index) {
+		f.SetValue(index, value)
+	}
+	f.fields[index].Value = value
+}
+
+// Changed reports whether the field differs from its initial value.
+func (f *Form) Changed(index int) bool {
+	return f.inputs[index].Value() != f.fields[index].Value
+}
+
+// Validate runs every field validator and focuses the first invalid field.
+// The error is kept so that it is displayed under the form until the next edit.
+func (f *Form) Validate() error {
+	f.err = nil
+	for i, field := range f.fields {
+		if field.Validate == nil {
+			continue
+		}
+		if err := field.Validate(f.inputs[i].Value()); err != nil {
+			f.err = fmt.Errorf("%s: %w", field.Label, err)
+			f.focus(i)
+			return f.err
+		}
+	}
+	return nil
+}
+
+func (f *Form) Err() error {
+	return f.err
+}
+
+func (f *Form) Update(msg tea.Msg) tea.Cmd {
+	if len(f.inputs) == 0 {
+		return nil
+	}
+	switch msg.(type) {
+	case tea.KeyMsg, tea.PasteMsg:
+		f.err = nil
+	}
+	var cmd tea.Cmd
+	f.inputs[f.focused], cmd = f.inputs[f.focused].Update(msg)
+	return cmd
+}
+
+// Height is the number of lines the form needs, including the error line.
+func (f *Form) Height() int {
+	if f.err != nil {
+		return len(f.inputs) + 1
+	}
+	return len(f.inputs)
+}
+
+func (f *Form) ViewRect(dl *render.DisplayContext, box layout.Box) {
+	//jjui:palette scope=content_search|metaedit role=text|dimmed|title|error
+	textStyle := common.DefaultPalette.Get(f.scope, "", "text", false).Inline(true)
+	dimmedStyle := common.DefaultPalette.Get(f.scope, "", "dimmed", false).Inline(true)
+	labelStyle := common.DefaultPalette.Get(f.scope, "", "title", false).Inline(true)
+	errorStyle := common.DefaultPalette.Get(f.scope, "", "error", false).Inline(true)
+
+	labelWidth := 0
+	for _, field := range f.fields {
+		labelWidth = max(labelWidth, render.StringWidth(field.Label))
+	}
+	labelWidth += 2
+
+	inputWidth := max(box.R.Dx()-labelWidth-1, 1)
+	for i := range f.inputs {
+		if i >= box.R.Dy() {
+			return
+		}
+		ti := &f.inputs[i]
+		styles := ti.Styles()
+		styles.Focused.Text = textStyle
+		styles.Focused.Placeholder = dimmedStyle
+		styles.Blurred.Text = dimmedStyle
+		styles.Blurred.Placeholder = dimmedStyle
+		ti.SetStyles(styles)
+		ti.SetWidth(inputWidth)
+
+		style := dimmedStyle
+		if i == f.focused && ti.Focused() {
+			style = labelStyle
+		}
+		labelRect := layout.Rect(box.R.Min.X, box.R.Min.Y+i, labelWidth, 1)
+		dl.AddDraw(labelRect, style.Width(labelWidth).Render(f.fields[i].Label+":"), f.Z)
+		inputRect := layout.Rect(box.R.Min.X+labelWidth, box.R.Min.Y+i, inputWidth+1, 1)
+		dl.AddDraw(inputRect, ti.View(), f.Z)
+		if i == f.focused && ti.Focused() {
+			dl.SetCursorInRect(ti.Cursor(), inputRect, 0, 0)
+		}
+	}
+
+	if f.err != nil && len(f.inputs) < box.R.Dy() {
+		errRect := layout.Rect(box.R.Min.X, box.R.Min.Y+len(f.inputs), box.R.Dx(), 1)
+		dl.AddDraw(errRect, errorStyle.Render(f.err.Error()), f.Z)
+	}
+}
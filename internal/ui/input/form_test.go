@@ -0,0 +1,63 @@
+package input
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForm_FocusNextWraps(t *testing.T) {
+	form := NewForm("input", Field{Label: "first"}, Field{Label: "second"})
+
+	form.FocusNext(1)
+	assert.Equal(t, 1, form.Focused())
+	form.FocusNext(1)
+	assert.Equal(t, 0, form.Focused())
+	form.FocusNext(-1)
+	assert.Equal(t, 1, form.Focused())
+}
+
+func TestForm_ValidateFocusesInvalidField(t *testing.T) {
+	form := NewForm("input",
+		Field{Label: "first", Value: "ok"},
+		Field{Label: "second", Validate: func(v string) error {
+			if v == "" {
+				return errors.New("required")
+			}
+			return nil
+		}},
+	)
+
+	err := form.Validate()
+	assert.EqualError(t, err, "second: required")
+	assert.Equal(t, 1, form.Focused())
+	assert.Equal(t, 3, form.Height(), "error line should be part of the form height")
+
+	form.SetValue(1, "value")
+	assert.NoError(t, form.Validate())
+	assert.Equal(t, 2, form.Height())
+	assert.True(t, form.Changed(1))
+	assert.False(t, form.Changed(0))
+}
+
+func TestForm_SetInitialValueKeepsEdits(t *testing.T) {
+	form := NewForm("input", Field{Label: "first"}, Field{Label: "second"})
+	form.SetValue(1, "typed")
+
+	form.SetInitialValue(0, "loaded")
+	form.SetInitialValue(1, "loaded")
+	assert.Equal(t, "loaded", form.Value(0))
+	assert.False(t, form.Changed(0))
+	assert.Equal(t, "typed", form.Value(1))
+	assert.True(t, form.Changed(1))
+}
+
+func TestForm_ViewRect(t *testing.T) {
+	form := NewForm("input", Field{Label: "Name", Value: "jane"}, Field{Label: "Email"})
+	output := test.RenderImmediate(form, 40, form.Height())
+	assert.Contains(t, output, "Name:")
+	assert.Contains(t, output, "jane")
+	assert.Contains(t, output, "Email:")
+}
@@ -59,6 +59,7 @@ func NewWithTitle(title string, prompt string, value string) *Model {
 
 func (m *Model) Scopes() []common.Scope {
 	return []common.Scope{
+		//jjui:layer scope=input
 		{
 			Name:    actions.ScopeInput,
 			Leak:    common.LeakNone,
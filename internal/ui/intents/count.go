@@ -0,0 +1,66 @@
+package intents
+
+// Counted is implemented by intents that take a count prefix, as in 5j.
+type Counted interface {
+	Intent
+	WithCount(count int) Intent
+}
+
+func (n Navigate) WithCount(count int) Intent {
+	switch {
+	case n.ChangeID != "":
+	case n.Target != TargetNone:
+		n.Count = count
+	default:
+		if n.Delta == 0 {
+			n.Delta = 1
+		}
+		n.Delta *= count
+	}
+	return n
+}
+
+func (n NextRevision) WithCount(count int) Intent {
+	n.Count = max(n.Count, 1) * count
+	return n
+}
+
+func (p PrevRevision) WithCount(count int) Intent {
+	p.Count = max(p.Count, 1) * count
+	return p
+}
+
+func (n DetailsNavigate) WithCount(count int) Intent {
+	n.Delta *= count
+	return n
+}
+
+func (n EvologNavigate) WithCount(count int) Intent {
+	n.Delta *= count
+	return n
+}
+
+func (n OpLogNavigate) WithCount(count int) Intent {
+	n.Delta *= count
+	return n
+}
+
+func (s DiffScroll) WithCount(count int) Intent {
+	s.Count = count
+	return s
+}
+
+func (n DiffFileNavigate) WithCount(count int) Intent {
+	n.Delta *= count
+	return n
+}
+
+func (s HelpScroll) WithCount(count int) Intent {
+	s.Delta *= count
+	return s
+}
+
+func (n CommandHistoryNavigate) WithCount(count int) Intent {
+	n.Delta *= count
+	return n
+}
@@ -11,7 +11,7 @@ type DetailsNavigate struct {
 
 func (DetailsNavigate) isIntent() {}
 
-//jjui:bind scope=revisions.details action=cancel
+//jjui:bind scope=revisions.details action=cancel hides=ui.cancel
 type DetailsClose struct{}
 
 func (DetailsClose) isIntent() {}
@@ -26,7 +26,7 @@ type DetailsApplyFilter struct{}
 
 func (DetailsApplyFilter) isIntent() {}
 
-//jjui:bind scope=revisions.details action=filter_cancel
+//jjui:bind scope=revisions.details action=filter_cancel hides=revisions.details.cancel,ui.cancel
 type DetailsCancelFilter struct{}
 
 func (DetailsCancelFilter) isIntent() {}
@@ -65,6 +65,27 @@ type DetailsToggleSelect struct{}
 
 func (DetailsToggleSelect) isIntent() {}
 
+//jjui:bind scope=revisions.details action=visual_mode
+//jjui:bind scope=revisions.details.visual action=visual_mode
+type DetailsVisualMode struct{}
+
+func (DetailsVisualMode) isIntent() {}
+
+//jjui:bind scope=revisions.details action=cycle_sort
+type DetailsCycleSort struct{}
+
+func (DetailsCycleSort) isIntent() {}
+
+//jjui:bind scope=revisions.details action=toggle_tree
+type DetailsToggleTree struct{}
+
+func (DetailsToggleTree) isIntent() {}
+
+//jjui:bind scope=revisions.details action=toggle_collapsed
+type DetailsToggleCollapsed struct{}
+
+func (DetailsToggleCollapsed) isIntent() {}
+
 //jjui:bind scope=revisions.details action=revisions_changing_file
 type DetailsRevisionsChangingFile struct{}
 
@@ -22,7 +22,8 @@ const (
 //jjui:bind scope=diff action=move_top set=Kind:DiffMoveTop
 //jjui:bind scope=diff action=move_bottom set=Kind:DiffMoveBottom
 type DiffScroll struct {
-	Kind DiffScrollKind
+	Kind  DiffScrollKind
+	Count int // number of times to scroll, defaults to 1
 }
 
 func (DiffScroll) isIntent() {}
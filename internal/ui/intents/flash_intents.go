@@ -11,7 +11,7 @@ type CommandHistoryNavigate struct{ Delta int }
 
 func (CommandHistoryNavigate) isIntent() {}
 
-//jjui:bind scope=command_history action=close
+//jjui:bind scope=command_history action=close hides=ui.cancel
 type CommandHistoryClose struct{}
 
 func (CommandHistoryClose) isIntent() {}
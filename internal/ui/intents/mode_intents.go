@@ -105,6 +105,31 @@ type InlineDescribeNewLine struct{}
 
 func (InlineDescribeNewLine) isIntent() {}
 
+//jjui:bind scope=revisions.inline_describe action=add_trailers
+type InlineDescribeAddTrailers struct{}
+
+func (InlineDescribeAddTrailers) isIntent() {}
+
+//jjui:bind scope=revisions.inline_describe action=prefix_picker
+type InlineDescribePrefixPicker struct{}
+
+func (InlineDescribePrefixPicker) isIntent() {}
+
+//jjui:bind scope=revisions.metaedit action=next_field set=Delta:1
+//jjui:bind scope=revisions.metaedit action=prev_field set=Delta:-1
+type MetaEditFocusField struct {
+	Delta int
+}
+
+func (MetaEditFocusField) isIntent() {}
+
+//jjui:bind scope=revisions.metaedit action=reset_author set=Force:$bool(force)
+type MetaEditResetAuthor struct {
+	Force bool
+}
+
+func (MetaEditResetAuthor) isIntent() {}
+
 //jjui:bind scope=revisions.target_picker action=move_up set=Delta:-1
 //jjui:bind scope=revisions.target_picker action=move_down set=Delta:1
 type TargetPickerNavigate struct {
@@ -121,7 +146,7 @@ type TargetPickerApply struct {
 
 func (TargetPickerApply) isIntent() {}
 
-//jjui:bind scope=revisions.target_picker action=cancel
+//jjui:bind scope=revisions.target_picker action=cancel hides=ui.cancel
 type TargetPickerCancel struct{}
 
 func (TargetPickerCancel) isIntent() {}
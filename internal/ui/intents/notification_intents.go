@@ -0,0 +1,29 @@
+package intents
+
+//jjui:bind scope=ui action=open_notifications
+type OpenNotifications struct{}
+
+func (OpenNotifications) isIntent() {}
+
+//jjui:bind scope=notifications action=move_up set=Delta:-1
+//jjui:bind scope=notifications action=move_down set=Delta:1
+type NotificationsNavigate struct {
+	Delta int
+}
+
+func (NotificationsNavigate) isIntent() {}
+
+//jjui:bind scope=notifications action=cycle_severity
+type NotificationsCycleSeverity struct{}
+
+func (NotificationsCycleSeverity) isIntent() {}
+
+//jjui:bind scope=notifications action=copy
+type NotificationsCopy struct{}
+
+func (NotificationsCopy) isIntent() {}
+
+//jjui:bind scope=notifications action=rerun
+type NotificationsRerun struct{}
+
+func (NotificationsRerun) isIntent() {}
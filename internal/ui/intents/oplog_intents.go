@@ -16,7 +16,7 @@ type OpLogOpen struct{}
 
 func (OpLogOpen) isIntent() {}
 
-//jjui:bind scope=oplog action=close
+//jjui:bind scope=oplog action=close hides=ui.cancel
 type OpLogClose struct{}
 
 func (OpLogClose) isIntent() {}
@@ -76,7 +76,25 @@ type RevisionsToggleSelect struct{}
 
 func (RevisionsToggleSelect) isIntent() {}
 
-//jjui:bind scope=revisions.quick_search action=clear
+//jjui:bind scope=revisions action=visual_mode
+//jjui:bind scope=revisions.visual action=visual_mode
+type RevisionsVisualMode struct{}
+
+func (RevisionsVisualMode) isIntent() {}
+
+//jjui:bind scope=revisions action=select_revset set=Revset:$string?(revset)
+type RevisionsSelectRevset struct {
+	Revset string
+}
+
+func (RevisionsSelectRevset) isIntent() {}
+
+//jjui:bind scope=revisions action=repeat
+type RepeatLastOperation struct{}
+
+func (RepeatLastOperation) isIntent() {}
+
+//jjui:bind scope=revisions.quick_search action=clear hides=revisions.cancel,ui.cancel
 type RevisionsQuickSearchClear struct{}
 
 func (RevisionsQuickSearchClear) isIntent() {}
@@ -113,10 +131,13 @@ func (GoToBottom) isIntent() {}
 //jjui:bind scope=revisions.abandon action=jump_to_working_copy set=Target:TargetWorkingCopy
 //jjui:bind scope=revisions.absorb action=jump_to_working_copy set=Target:TargetWorkingCopy
 //jjui:bind scope=revisions.set_parents action=jump_to_working_copy set=Target:TargetWorkingCopy
+//jjui:bind scope=revisions.parallelize action=jump_to_working_copy set=Target:TargetWorkingCopy
+//jjui:bind scope=revisions.simplify_parents action=jump_to_working_copy set=Target:TargetWorkingCopy
 type Navigate struct {
 	Delta       int              // +N down, -N up
 	IsPage      bool             // use page-sized step when true
 	Target      NavigationTarget // logical destination (parent/child/working)
+	Count       int              // number of Target jumps, defaults to 1
 	ChangeID    string           // explicit change/commit id to select
 	FallbackID  string           // optional fallback change/commit id
 	EnsureView  *bool            // defaults to true when nil
@@ -146,6 +167,28 @@ type StartEdit struct {
 
 func (StartEdit) isIntent() {}
 
+//jjui:bind scope=revisions action=next set=Count:$int(count)
+//jjui:bind scope=revisions action=next_edit set=Count:$int(count),Edit:true
+//jjui:bind scope=revisions action=next_conflict set=Conflict:true
+type NextRevision struct {
+	Count    int  // number of steps, defaults to 1
+	Edit     bool // edit the target instead of creating a new change on top of it
+	Conflict bool // jump to the next conflicted descendant
+}
+
+func (NextRevision) isIntent() {}
+
+//jjui:bind scope=revisions action=prev set=Count:$int(count)
+//jjui:bind scope=revisions action=prev_edit set=Count:$int(count),Edit:true
+//jjui:bind scope=revisions action=prev_conflict set=Conflict:true
+type PrevRevision struct {
+	Count    int  // number of steps, defaults to 1
+	Edit     bool // edit the target instead of creating a new change on top of it
+	Conflict bool // jump to the previous conflicted ancestor
+}
+
+func (PrevRevision) isIntent() {}
+
 //jjui:bind scope=revisions action=diff_edit
 type DiffEdit struct {
 	Selected *jj.Commit
@@ -153,6 +196,22 @@ type DiffEdit struct {
 
 func (DiffEdit) isIntent() {}
 
+//jjui:bind scope=revisions action=fix
+//jjui:bind scope=revisions action=force_fix set=IgnoreImmutable:true
+type Fix struct {
+	Selected        jj.SelectedRevisions
+	IgnoreImmutable bool
+}
+
+func (Fix) isIntent() {}
+
+//jjui:bind scope=revisions action=open_metaedit
+type OpenMetaEdit struct {
+	Selected jj.SelectedRevisions
+}
+
+func (OpenMetaEdit) isIntent() {}
+
 //jjui:bind scope=revisions action=open_absorb
 type OpenAbsorb struct {
 	Selected *jj.Commit
@@ -201,6 +260,35 @@ type OpenSetParents struct {
 
 func (OpenSetParents) isIntent() {}
 
+//jjui:bind scope=revisions action=open_parallelize
+type OpenParallelize struct {
+	Selected jj.SelectedRevisions
+}
+
+func (OpenParallelize) isIntent() {}
+
+//jjui:bind scope=revisions.parallelize action=toggle_select
+type ParallelizeToggleSelect struct{}
+
+func (ParallelizeToggleSelect) isIntent() {}
+
+//jjui:bind scope=revisions action=open_simplify_parents
+type OpenSimplifyParents struct {
+	Selected jj.SelectedRevisions
+}
+
+func (OpenSimplifyParents) isIntent() {}
+
+//jjui:bind scope=revisions.simplify_parents action=toggle_select
+type SimplifyParentsToggleSelect struct{}
+
+func (SimplifyParentsToggleSelect) isIntent() {}
+
+//jjui:bind scope=revisions.simplify_parents action=toggle_descendants
+type SimplifyParentsToggleDescendants struct{}
+
+func (SimplifyParentsToggleDescendants) isIntent() {}
+
 //jjui:bind scope=revisions action=open_diff_range
 type OpenDiffRange struct{}
 
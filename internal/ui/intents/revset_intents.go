@@ -1,5 +1,7 @@
 package intents
 
+import "github.com/idursun/jjui/internal/jj"
+
 //jjui:bind scope=ui action=open_revset set=Clear:true
 //jjui:bind scope=revset action=edit set=Clear:$bool(clear)
 type Edit struct {
@@ -35,3 +37,33 @@ type CompletionMove struct {
 }
 
 func (CompletionMove) isIntent() {}
+
+type SelectionRevsetKind int
+
+const (
+	SelectionRevsetSelected    SelectionRevsetKind = iota // the revisions themselves
+	SelectionRevsetDescendants                            // x::
+	SelectionRevsetAncestors                              // ::x
+	SelectionRevsetRange                                  // x::y, order-independent
+	SelectionRevsetHeads                                  // heads(x)
+	SelectionRevsetRoots                                  // roots(x)
+)
+
+//jjui:bind scope=revset action=insert_selection set=Kind:SelectionRevsetSelected
+//jjui:bind scope=revset action=insert_descendants set=Kind:SelectionRevsetDescendants
+//jjui:bind scope=revset action=insert_ancestors set=Kind:SelectionRevsetAncestors
+//jjui:bind scope=revset action=insert_range set=Kind:SelectionRevsetRange
+//jjui:bind scope=revset action=insert_heads set=Kind:SelectionRevsetHeads
+//jjui:bind scope=revset action=insert_roots set=Kind:SelectionRevsetRoots
+type InsertSelectionRevset struct {
+	Kind SelectionRevsetKind
+}
+
+func (InsertSelectionRevset) isIntent() {}
+
+//jjui:bind scope=revisions action=narrow_to_selection
+type NarrowToSelection struct {
+	Selected jj.SelectedRevisions
+}
+
+func (NarrowToSelection) isIntent() {}
@@ -20,13 +20,56 @@ type FileSearchToggle struct{}
 
 func (FileSearchToggle) isIntent() {}
 
+//jjui:bind scope=ui action=revision_finder
+type RevisionFinderToggle struct{}
+
+func (RevisionFinderToggle) isIntent() {}
+
+type RevisionFinderAccept struct{}
+
+func (RevisionFinderAccept) isIntent() {}
+
+//jjui:bind scope=ui action=command_palette
+type CommandPaletteToggle struct{}
+
+func (CommandPaletteToggle) isIntent() {}
+
+type CommandPaletteAccept struct {
+	Input string
+}
+
+func (CommandPaletteAccept) isIntent() {}
+
+//jjui:bind scope=content_search action=next_field set=Delta:1
+//jjui:bind scope=content_search action=prev_field set=Delta:-1
+type ContentSearchFocusField struct {
+	Delta int
+}
+
+func (ContentSearchFocusField) isIntent() {}
+
+//jjui:bind scope=content_search action=move_up set=Delta:-1
+//jjui:bind scope=content_search action=move_down set=Delta:1
+type ContentSearchNavigate struct {
+	Delta int
+}
+
+func (ContentSearchNavigate) isIntent() {}
+
 //jjui:bind scope=revisions.absorb action=ace_jump
 //jjui:bind scope=revisions.rebase action=ace_jump
 //jjui:bind scope=revisions.squash action=ace_jump
 //jjui:bind scope=revisions.duplicate action=ace_jump
 //jjui:bind scope=revisions.abandon action=ace_jump
 //jjui:bind scope=revisions.set_parents action=ace_jump
+//jjui:bind scope=revisions.parallelize action=ace_jump
+//jjui:bind scope=revisions.simplify_parents action=ace_jump
 //jjui:bind scope=revisions action=ace_jump
+//jjui:bind scope=revisions.details action=ace_jump
+//jjui:bind scope=bookmarks action=ace_jump
+//jjui:bind scope=git action=ace_jump
+//jjui:bind scope=oplog action=ace_jump
+//jjui:bind scope=diff action=ace_jump
 type StartAceJump struct{}
 
 func (StartAceJump) isIntent() {}
@@ -0,0 +1,50 @@
+package intents
+
+//jjui:bind scope=ui action=open_theme_browser
+type OpenThemeBrowser struct{}
+
+func (OpenThemeBrowser) isIntent() {}
+
+//jjui:bind scope=ui action=open_theme_editor
+type OpenThemeEditor struct{}
+
+func (OpenThemeEditor) isIntent() {}
+
+//jjui:bind scope=theme_browser action=move_up set=Delta:-1
+//jjui:bind scope=theme_browser action=move_down set=Delta:1
+type ThemeBrowserNavigate struct {
+	Delta int
+}
+
+func (ThemeBrowserNavigate) isIntent() {}
+
+//jjui:bind scope=theme_browser action=edit
+type ThemeBrowserEdit struct{}
+
+func (ThemeBrowserEdit) isIntent() {}
+
+//jjui:bind scope=theme_editor action=move_up set=Delta:-1
+//jjui:bind scope=theme_editor action=move_down set=Delta:1
+type ThemeEditorNavigate struct {
+	Delta int
+}
+
+func (ThemeEditorNavigate) isIntent() {}
+
+//jjui:bind scope=theme_editor action=edit_fg
+//jjui:bind scope=theme_editor action=edit_bg set=Background:true
+type ThemeEditorEdit struct {
+	Background bool
+}
+
+func (ThemeEditorEdit) isIntent() {}
+
+//jjui:bind scope=theme_editor action=toggle_bold
+type ThemeEditorToggleBold struct{}
+
+func (ThemeEditorToggleBold) isIntent() {}
+
+//jjui:bind scope=theme_editor action=save
+type ThemeEditorSave struct{}
+
+func (ThemeEditorSave) isIntent() {}
@@ -84,6 +84,11 @@ type OpenGit struct{}
 
 func (OpenGit) isIntent() {}
 
+//jjui:bind scope=ui action=open_content_search
+type OpenContentSearch struct{}
+
+func (OpenContentSearch) isIntent() {}
+
 //jjui:bind scope=revisions action=open_set_bookmark set=Value:$string?(value)
 type OpenSetBookmark struct {
 	Value string
@@ -205,7 +210,7 @@ type ChooseApply struct{}
 
 func (ChooseApply) isIntent() {}
 
-//jjui:bind scope=choose action=cancel
+//jjui:bind scope=choose action=cancel hides=ui.cancel
 type ChooseCancel struct{}
 
 func (ChooseCancel) isIntent() {}
@@ -220,11 +225,17 @@ func (ChooseCancel) isIntent() {}
 //jjui:bind scope=revisions.abandon action=cancel
 //jjui:bind scope=revisions.absorb action=cancel
 //jjui:bind scope=revisions.set_parents action=cancel
+//jjui:bind scope=revisions.parallelize action=cancel
+//jjui:bind scope=revisions.simplify_parents action=cancel
 //jjui:bind scope=revisions.set_bookmark action=cancel
+//jjui:bind scope=revisions.metaedit action=cancel
 //jjui:bind scope=revisions.diff_range action=cancel
 //jjui:bind scope=revisions.new_between action=cancel
 //jjui:bind scope=revisions.inline_describe action=cancel
 //jjui:bind scope=revisions.ace_jump action=cancel
+//jjui:bind scope=jump_labels action=cancel
+//jjui:bind scope=revisions.visual action=cancel
+//jjui:bind scope=revisions.details.visual action=cancel hides=revisions.details.cancel
 //jjui:bind scope=ui action=cancel
 //jjui:bind scope=help action=cancel
 //jjui:bind scope=bookmarks action=cancel
@@ -237,6 +248,10 @@ func (ChooseCancel) isIntent() {}
 //jjui:bind scope=input action=cancel
 //jjui:bind scope=undo action=cancel
 //jjui:bind scope=redo action=cancel
+//jjui:bind scope=content_search action=cancel
+//jjui:bind scope=theme_browser action=cancel
+//jjui:bind scope=theme_editor action=cancel
+//jjui:bind scope=notifications action=close
 type Cancel struct{}
 
 func (Cancel) isIntent() {}
@@ -256,10 +271,17 @@ func (Cancel) isIntent() {}
 //jjui:bind scope=revisions.abandon action=force_apply set=Force:true
 //jjui:bind scope=revisions.absorb action=apply
 //jjui:bind scope=revisions.set_parents action=apply
+//jjui:bind scope=revisions.parallelize action=apply set=Force:$bool(force)
+//jjui:bind scope=revisions.parallelize action=force_apply set=Force:true
+//jjui:bind scope=revisions.simplify_parents action=apply set=Force:$bool(force)
+//jjui:bind scope=revisions.simplify_parents action=force_apply set=Force:true
 //jjui:bind scope=revisions.set_bookmark action=apply
+//jjui:bind scope=revisions.metaedit action=apply set=Force:$bool(force)
+//jjui:bind scope=revisions.metaedit action=force_apply set=Force:true
 //jjui:bind scope=revisions.diff_range action=apply
 //jjui:bind scope=revisions.new_between action=apply
 //jjui:bind scope=revisions.ace_jump action=apply
+//jjui:bind scope=jump_labels action=apply
 //jjui:bind scope=bookmarks action=apply
 //jjui:bind scope=git action=apply
 //jjui:bind scope=revisions action=apply set=Force:$bool(force)
@@ -273,6 +295,9 @@ func (Cancel) isIntent() {}
 //jjui:bind scope=help action=apply
 //jjui:bind scope=undo action=apply
 //jjui:bind scope=redo action=apply
+//jjui:bind scope=content_search action=apply
+//jjui:bind scope=theme_browser action=apply
+//jjui:bind scope=theme_editor action=apply
 type Apply struct {
 	Value string
 	Force bool
@@ -0,0 +1,316 @@
+package notifications
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/atotto/clipboard"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/actions"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+// RerunMsg asks for the jj command of a logged entry to be run again.
+type RerunMsg struct {
+	Args []string
+}
+
+type itemClickMsg struct {
+	Index int
+}
+
+type itemScrollMsg struct {
+	Delta      int
+	Horizontal bool
+}
+
+func (m itemScrollMsg) SetDelta(delta int, horizontal bool) tea.Msg {
+	m.Delta = delta
+	m.Horizontal = horizontal
+	return m
+}
+
+const (
+	timeFormat     = "2006-01-02 15:04:05"
+	commandWidth   = 30
+	repoWidth      = 16
+	detailsHeight  = 6
+	maxVisibleRows = 15
+)
+
+// writeClipboard is replaced in tests.
+var writeClipboard = clipboard.WriteAll
+
+var _ common.ImmediateModel = (*Model)(nil)
+
+// Model lists the message log, newest first, and shows the full text of the
+// highlighted entry below the list. Only commands that ran in location can be
+// run again.
+type Model struct {
+	entries             []config.MessageLogEntry
+	location            string
+	visible             []int
+	severity            config.Severity
+	selected            int
+	listRenderer        *render.ListRenderer
+	ensureCursorVisible bool
+}
+
+func New(log *config.MessageLog, location string) *Model {
+	m := &Model{
+		location:     location,
+		listRenderer: render.NewListRenderer(itemScrollMsg{}),
+	}
+	if log != nil {
+		m.entries = log.Entries()
+	}
+	m.listRenderer.Z = render.ZMenuContent
+	m.filter()
+	return m
+}
+
+func (m *Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m *Model) Scopes() []common.Scope {
+	return []common.Scope{
+		{
+			Name:    actions.ScopeNotifications,
+			Leak:    common.LeakGlobal,
+			Handler: m,
+		},
+	}
+}
+
+func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
+	switch intent := intent.(type) {
+	case intents.NotificationsNavigate:
+		m.move(m.selected + intent.Delta)
+		return nil, true
+	case intents.NotificationsCycleSeverity:
+		m.cycleSeverity()
+		return nil, true
+	case intents.NotificationsCopy:
+		entry, ok := m.current()
+		if !ok {
+			return nil, true
+		}
+		if err := writeClipboard(entryText(entry)); err != nil {
+			return intents.Invoke(intents.AddMessage{Text: err.Error(), Err: err}), true
+		}
+		return intents.Invoke(intents.AddMessage{Text: "copied to clipboard"}), true
+	case intents.NotificationsRerun:
+		entry, ok := m.current()
+		if !ok {
+			return nil, true
+		}
+		if len(entry.Args) == 0 {
+			return intents.Invoke(intents.AddMessage{Text: "this entry has no command to re-run"}), true
+		}
+		if entry.Stdin {
+			return intents.Invoke(intents.AddMessage{Text: "this command read its input from stdin and can't be re-run"}), true
+		}
+		if entry.Location != m.location {
+			return intents.Invoke(intents.AddMessage{Text: fmt.Sprintf("this command ran in %s, not in the current repository", repoName(entry.Location))}), true
+		}
+		args := slices.Clone(entry.Args)
+		return tea.Sequence(common.Close, func() tea.Msg { return RerunMsg{Args: args} }), true
+	case intents.Cancel:
+		return common.Close, true
+	}
+	return nil, false
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case intents.Intent:
+		cmd, _ := m.HandleIntent(msg)
+		return cmd
+	case itemScrollMsg:
+		if !msg.Horizontal {
+			m.listRenderer.StartLine = max(m.listRenderer.StartLine+msg.Delta, 0)
+		}
+	case itemClickMsg:
+		m.move(msg.Index)
+	}
+	return nil
+}
+
+// filter rebuilds the visible rows for the selected severity, newest first.
+func (m *Model) filter() {
+	m.visible = m.visible[:0]
+	for i := len(m.entries) - 1; i >= 0; i-- {
+		if m.severity == "" || m.entries[i].Severity == m.severity {
+			m.visible = append(m.visible, i)
+		}
+	}
+	m.selected = 0
+	m.listRenderer.StartLine = 0
+	m.ensureCursorVisible = true
+}
+
+// cycleSeverity steps the filter through every severity and back to showing
+// all entries.
+func (m *Model) cycleSeverity() {
+	index := slices.Index(config.Severities, m.severity)
+	if index+1 < len(config.Severities) {
+		m.severity = config.Severities[index+1]
+	} else {
+		m.severity = ""
+	}
+	m.filter()
+}
+
+func (m *Model) current() (config.MessageLogEntry, bool) {
+	if m.selected < 0 || m.selected >= len(m.visible) {
+		return config.MessageLogEntry{}, false
+	}
+	return m.entries[m.visible[m.selected]], true
+}
+
+func (m *Model) move(index int) {
+	if len(m.visible) == 0 {
+		return
+	}
+	m.selected = max(min(index, len(m.visible)-1), 0)
+	m.ensureCursorVisible = true
+}
+
+// repoName is the directory name of a repository, or "-" when it is unknown.
+func repoName(location string) string {
+	if location == "" {
+		return "-"
+	}
+	return filepath.Base(location)
+}
+
+// entryText is what gets copied: a header line, then the output.
+func entryText(entry config.MessageLogEntry) string {
+	header := fmt.Sprintf("%s [%s]", entry.Time.Local().Format(timeFormat), entry.Severity)
+	if entry.Command != "" {
+		header += " " + entry.Command
+	}
+	if entry.Location != "" {
+		header += " (in " + entry.Location + ")"
+	}
+	if entry.Text == "" {
+		return header
+	}
+	return header + "\n" + entry.Text
+}
+
+func (m *Model) title() string {
+	severity := "all"
+	if m.severity != "" {
+		severity = string(m.severity)
+	}
+	return fmt.Sprintf("%d of %d messages, showing %s", len(m.visible), len(m.entries), severity)
+}
+
+func (m *Model) ViewRect(dl *render.DisplayContext, box layout.Box) {
+	listHeight := max(min(len(m.visible), maxVisibleRows), 1)
+	// title, gap, list, separator and details
+	contentWidth := max(min(box.R.Dx(), 120)-4, 0)
+	contentHeight := min(1+1+listHeight+1+detailsHeight, max(box.R.Dy()-4, 0))
+	frame := box.Center(contentWidth+2, contentHeight+2)
+	if frame.R.Dx() <= 0 || frame.R.Dy() <= 0 {
+		return
+	}
+
+	titleStyle := common.DefaultPalette.Get("notifications", "", "title", false)
+	textStyle := common.DefaultPalette.Get("notifications", "", "text", false)
+	dimmedStyle := common.DefaultPalette.Get("notifications", "", "dimmed", false)
+	borderStyle := common.DefaultPalette.GetBorder("notifications", "", "border", false, lipgloss.NormalBorder())
+
+	dl.AddBackdrop(box.R, render.ZMenuBorder-1)
+	contentBox := frame.Inset(1)
+	if contentBox.R.Dx() <= 0 || contentBox.R.Dy() <= 0 {
+		return
+	}
+	dl.AddFill(contentBox.R, ' ', textStyle, render.ZMenuContent)
+	borderBase := lipgloss.NewStyle().Width(contentBox.R.Dx()).Height(contentBox.R.Dy()).Render("")
+	dl.AddDraw(frame.R, borderStyle.Render(borderBase), render.ZMenuBorder)
+
+	titleBox, contentBox := contentBox.CutTop(1)
+	dl.AddDraw(titleBox.R, titleStyle.Render("Notifications")+textStyle.Render("  ")+dimmedStyle.Render(m.title()), render.ZMenuContent)
+	_, contentBox = contentBox.CutTop(1)
+
+	detailsBox := layout.Box{}
+	if contentBox.R.Dy() > detailsHeight+1 {
+		var separatorBox layout.Box
+		contentBox, detailsBox = contentBox.CutBottom(detailsHeight)
+		contentBox, separatorBox = contentBox.CutBottom(1)
+		dl.AddDraw(separatorBox.R, dimmedStyle.Render(strings.Repeat("─", separatorBox.R.Dx())), render.ZMenuContent)
+	}
+	if len(m.visible) == 0 {
+		dl.AddDraw(contentBox.R, dimmedStyle.Render("no messages"), render.ZMenuContent)
+		return
+	}
+	m.renderList(dl, contentBox)
+	m.renderDetails(dl, detailsBox)
+}
+
+func (m *Model) renderList(dl *render.DisplayContext, listBox layout.Box) {
+	if listBox.R.Dx() <= 0 || listBox.R.Dy() <= 0 {
+		return
+	}
+	textStyle := common.DefaultPalette.Get("notifications", "", "text", false)
+	dimmedStyle := common.DefaultPalette.Get("notifications", "", "dimmed", false)
+	errorStyle := common.DefaultPalette.Get("notifications", "", "error", false)
+	selectedStyle := common.DefaultPalette.GetBlended("notifications", "", "", true)
+
+	m.listRenderer.StartLine = render.ClampStartLine(m.listRenderer.StartLine, listBox.R.Dy(), len(m.visible))
+	m.listRenderer.Render(
+		dl,
+		listBox,
+		len(m.visible),
+		m.selected,
+		m.ensureCursorVisible,
+		func(_ int) int { return 1 },
+		func(dl *render.DisplayContext, index int, rect layout.Rectangle) {
+			entry := m.entries[m.visible[index]]
+			severityStyle := dimmedStyle
+			if entry.Severity == config.SeverityError {
+				severityStyle = errorStyle
+			}
+			command := entry.Command
+			if command == "" {
+				command = "-"
+			}
+			firstLine, _, _ := strings.Cut(entry.Text, "\n")
+			line := dimmedStyle.Render(entry.Time.Local().Format(timeFormat)) +
+				severityStyle.Render(fmt.Sprintf(" %-5s ", entry.Severity)) +
+				dimmedStyle.Render(fmt.Sprintf("%-*.*s ", repoWidth, repoWidth, repoName(entry.Location))) +
+				textStyle.Render(fmt.Sprintf("%-*.*s ", commandWidth, commandWidth, command)) +
+				dimmedStyle.Render(firstLine)
+			dl.AddDraw(rect, line, render.ZMenuContent)
+			if index == m.selected {
+				dl.AddPaint(rect, selectedStyle, render.ZMenuContent)
+			}
+		},
+		func(index int, _ tea.Mouse) tea.Msg { return itemClickMsg{Index: index} },
+	)
+	m.listRenderer.RegisterScroll(dl, listBox)
+	m.ensureCursorVisible = false
+}
+
+func (m *Model) renderDetails(dl *render.DisplayContext, detailsBox layout.Box) {
+	entry, ok := m.current()
+	if !ok || detailsBox.R.Dx() <= 0 || detailsBox.R.Dy() <= 0 {
+		return
+	}
+	textStyle := common.DefaultPalette.Get("notifications", "", "text", false)
+	lines := strings.Split(entryText(entry), "\n")
+	for i, line := range lines[:min(len(lines), detailsBox.R.Dy())] {
+		rect := layout.Rect(detailsBox.R.Min.X, detailsBox.R.Min.Y+i, detailsBox.R.Dx(), 1)
+		dl.AddDraw(rect, textStyle.Render(line), render.ZMenuContent)
+	}
+}
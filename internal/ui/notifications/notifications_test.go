@@ -0,0 +1,110 @@
+package notifications
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLocation = "/src/jjui"
+
+func newTestLog(t *testing.T) *config.MessageLog {
+	log := config.NewMessageLog("")
+	at := time.Date(2026, 10, 19, 9, 30, 0, 0, time.Local)
+	log.Append(config.MessageLogEntry{Time: at, Severity: config.SeverityInfo, Text: "theme saved"})
+	log.Append(config.MessageLogEntry{Time: at.Add(time.Minute), Severity: config.SeverityError, Command: "jj git push", Args: []string{"git", "push"}, Location: testLocation, Text: "rejected\nhint: fetch first"})
+	log.Append(config.MessageLogEntry{Time: at.Add(2 * time.Minute), Severity: config.SeverityInfo, Command: "jj new", Args: []string{"new"}, Location: testLocation})
+	return log
+}
+
+func TestNotifications_ListsNewestFirstWithDetails(t *testing.T) {
+	m := New(newTestLog(t), testLocation)
+	m.Update(intents.NotificationsNavigate{Delta: 1})
+
+	rendered := test.RenderImmediate(m, 120, 30)
+	assert.Contains(t, rendered, "3 of 3 messages, showing all")
+	assert.Contains(t, rendered, "jj new")
+	assert.Contains(t, rendered, "theme saved")
+	assert.Contains(t, rendered, "2026-10-19 09:31:00 [error] jj git push (in /src/jjui)")
+	assert.Contains(t, rendered, "hint: fetch first")
+}
+
+func TestNotifications_CycleSeverityFilters(t *testing.T) {
+	m := New(newTestLog(t), testLocation)
+
+	m.Update(intents.NotificationsCycleSeverity{})
+	assert.Equal(t, config.SeverityInfo, m.severity)
+	assert.Len(t, m.visible, 2)
+
+	m.Update(intents.NotificationsCycleSeverity{})
+	assert.Equal(t, config.SeverityError, m.severity)
+	entry, ok := m.current()
+	require.True(t, ok)
+	assert.Equal(t, "jj git push", entry.Command)
+
+	m.Update(intents.NotificationsCycleSeverity{})
+	assert.Empty(t, m.severity)
+	assert.Len(t, m.visible, 3)
+}
+
+func TestNotifications_CopyWritesEntryToClipboard(t *testing.T) {
+	var copied string
+	previous := writeClipboard
+	writeClipboard = func(text string) error {
+		copied = text
+		return nil
+	}
+	t.Cleanup(func() { writeClipboard = previous })
+
+	m := New(newTestLog(t), testLocation)
+	m.Update(intents.NotificationsNavigate{Delta: 1})
+	m.Update(intents.NotificationsCopy{})
+	assert.Equal(t, "2026-10-19 09:31:00 [error] jj git push (in /src/jjui)\nrejected\nhint: fetch first", copied)
+}
+
+func TestNotifications_RerunSendsArgs(t *testing.T) {
+	m := New(newTestLog(t), testLocation)
+	m.Update(intents.NotificationsNavigate{Delta: 1})
+
+	var rerun *RerunMsg
+	test.SimulateModel(m, m.Update(intents.NotificationsRerun{}), func(msg tea.Msg) {
+		if msg, ok := msg.(RerunMsg); ok {
+			rerun = &msg
+		}
+	})
+	require.NotNil(t, rerun)
+	assert.Equal(t, []string{"git", "push"}, rerun.Args)
+}
+
+func TestNotifications_RerunRefusesStdinCommands(t *testing.T) {
+	log := config.NewMessageLog("")
+	log.Append(config.MessageLogEntry{Time: time.Now(), Severity: config.SeverityInfo, Command: "jj describe -r abc --stdin", Args: []string{"describe", "-r", "abc", "--stdin"}, Location: testLocation, Stdin: true})
+	m := New(log, testLocation)
+
+	var rerun bool
+	test.SimulateModel(m, m.Update(intents.NotificationsRerun{}), func(msg tea.Msg) {
+		if _, ok := msg.(RerunMsg); ok {
+			rerun = true
+		}
+	})
+	assert.False(t, rerun)
+}
+
+func TestNotifications_RerunRefusesCommandsFromOtherRepositories(t *testing.T) {
+	m := New(newTestLog(t), "/src/other")
+
+	var rerun bool
+	test.SimulateModel(m, m.Update(intents.NotificationsRerun{}), func(msg tea.Msg) {
+		if _, ok := msg.(RerunMsg); ok {
+			rerun = true
+		}
+	})
+	assert.False(t, rerun)
+	assert.Contains(t, test.RenderImmediate(m, 120, 30), "jjui")
+}
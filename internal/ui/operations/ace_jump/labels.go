@@ -0,0 +1,182 @@
+package ace_jump
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/ui/actions"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+// labelAlphabet lists the label characters, home row first.
+const labelAlphabet = "asdfghjklqwertyuiopzxcvbnm"
+
+var (
+	_ common.ScopeHandler = (*Labels)(nil)
+	_ render.ItemOverlay  = (*Labels)(nil)
+)
+
+// Labels puts a short label on each target so that it can be jumped to by
+// typing the label. Unlike AceJump, the labels do not depend on the item text,
+// which makes them usable for any list: files, menu items, operations, or the
+// file headers of a diff.
+//
+// All labels have the same length, so no label is a prefix of another one.
+type Labels struct {
+	targets  []int
+	labels   []string
+	prefix   string
+	active   bool
+	onJump   func(index int) tea.Cmd
+	renderer *render.ListRenderer
+}
+
+// NewLabels labels the given item indices. onJump is called with the index
+// of the chosen item.
+func NewLabels(targets []int, onJump func(index int) tea.Cmd) *Labels {
+	l := &Labels{
+		targets: targets,
+		labels:  makeLabels(len(targets)),
+		active:  len(targets) > 0,
+		onJump:  onJump,
+	}
+	return l
+}
+
+// NewListLabels labels the items currently visible in renderer and draws the
+// labels through its overlay until a jump is made or cancelled.
+func NewListLabels(renderer *render.ListRenderer, onJump func(index int) tea.Cmd) *Labels {
+	var targets []int
+	if renderer.FirstRowIndex >= 0 {
+		for i := renderer.FirstRowIndex; i <= renderer.LastRowIndex; i++ {
+			targets = append(targets, i)
+		}
+	}
+	l := NewLabels(targets, onJump)
+	if l.active {
+		l.renderer = renderer
+		renderer.Overlay = l
+	}
+	return l
+}
+
+func makeLabels(count int) []string {
+	n := len(labelAlphabet)
+	labels := make([]string, count)
+	for i := range count {
+		if count <= n {
+			labels[i] = labelAlphabet[i : i+1]
+		} else {
+			labels[i] = string(labelAlphabet[(i/n)%n]) + string(labelAlphabet[i%n])
+		}
+	}
+	return labels
+}
+
+func (l *Labels) Active() bool {
+	return l != nil && l.active
+}
+
+// Scope has to come before the scopes of the labelled list so that the typed
+// characters reach the labels.
+func (l *Labels) Scope() common.Scope {
+	//jjui:layer scope=jump_labels
+	return common.Scope{
+		Name:    actions.ScopeJumpLabels,
+		Leak:    common.LeakNone,
+		Handler: l,
+	}
+}
+
+// Label returns the part of the label for index that is left to type.
+func (l *Labels) Label(index int) (string, bool) {
+	if !l.Active() {
+		return "", false
+	}
+	for i, target := range l.targets {
+		if target != index {
+			continue
+		}
+		label := l.labels[i]
+		if len(label) < len(l.prefix) || label[:len(l.prefix)] != l.prefix {
+			return "", false
+		}
+		return label[len(l.prefix):], true
+	}
+	return "", false
+}
+
+func (l *Labels) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
+	switch intent.(type) {
+	case intents.Cancel:
+		l.stop()
+		return nil, true
+	case intents.Apply:
+		for i, label := range l.labels {
+			if len(label) >= len(l.prefix) && label[:len(l.prefix)] == l.prefix {
+				return l.jump(l.targets[i]), true
+			}
+		}
+		l.stop()
+		return nil, true
+	}
+	return nil, false
+}
+
+func (l *Labels) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case intents.Intent:
+		cmd, _ := l.HandleIntent(msg)
+		return cmd
+	case tea.KeyMsg:
+		return l.narrow(msg.String())
+	}
+	return nil
+}
+
+// narrow appends key to the typed prefix when some label starts with it, and
+// jumps once a label is complete. Other keys are ignored.
+func (l *Labels) narrow(key string) tea.Cmd {
+	if !l.Active() || len(key) != 1 {
+		return nil
+	}
+	prefix := l.prefix + key
+	matched := false
+	for i, label := range l.labels {
+		if label == prefix {
+			return l.jump(l.targets[i])
+		}
+		if len(label) > len(prefix) && label[:len(prefix)] == prefix {
+			matched = true
+		}
+	}
+	if matched {
+		l.prefix = prefix
+	}
+	return nil
+}
+
+func (l *Labels) jump(index int) tea.Cmd {
+	l.stop()
+	return l.onJump(index)
+}
+
+func (l *Labels) stop() {
+	l.active = false
+	l.prefix = ""
+	if l.renderer != nil && l.renderer.Overlay == l {
+		l.renderer.Overlay = nil
+	}
+}
+
+// RenderItemOverlay draws the label of index at the start of rect.
+func (l *Labels) RenderItemOverlay(dl *render.DisplayContext, index int, rect layout.Rectangle, z int) {
+	label, ok := l.Label(index)
+	if !ok || rect.Dx() <= 0 || rect.Dy() <= 0 {
+		return
+	}
+	style := common.DefaultPalette.Get("jump_labels", "", "label", false)
+	width := min(len(label), rect.Dx())
+	dl.AddDraw(layout.Rect(rect.Min.X, rect.Min.Y, width, 1), style.Render(label[:width]), z)
+}
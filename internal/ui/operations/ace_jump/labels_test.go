@@ -0,0 +1,88 @@
+package ace_jump
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/render"
+	"github.com/stretchr/testify/assert"
+)
+
+func keyPress(r rune) tea.KeyMsg {
+	return tea.KeyPressMsg{Code: r, Text: string(r)}
+}
+
+func TestMakeLabels_UsesTwoCharactersForLongLists(t *testing.T) {
+	assert.Equal(t, []string{"a", "s", "d"}, makeLabels(3))
+
+	labels := makeLabels(30)
+	assert.Equal(t, "aa", labels[0])
+	assert.Equal(t, "sa", labels[26])
+	assert.Len(t, labels[29], 2)
+}
+
+func TestLabels_JumpsOnCompleteLabel(t *testing.T) {
+	jumped := -1
+	labels := NewLabels(makeRange(10, 40), func(index int) tea.Cmd {
+		jumped = index
+		return nil
+	})
+
+	labels.Update(keyPress('s'))
+	assert.True(t, labels.Active())
+	rest, ok := labels.Label(36)
+	assert.True(t, ok)
+	assert.Equal(t, "a", rest)
+	_, ok = labels.Label(10)
+	assert.False(t, ok, "labels not starting with the typed prefix are hidden")
+
+	labels.Update(keyPress('d'))
+
+	assert.False(t, labels.Active())
+	assert.Equal(t, 38, jumped)
+}
+
+func TestLabels_IgnoresKeysMatchingNoLabel(t *testing.T) {
+	labels := NewLabels([]int{0, 1}, func(int) tea.Cmd { return nil })
+
+	labels.Update(keyPress('z'))
+
+	assert.True(t, labels.Active())
+	rest, _ := labels.Label(1)
+	assert.Equal(t, "s", rest)
+}
+
+func TestLabels_ApplyJumpsToFirstMatch(t *testing.T) {
+	jumped := -1
+	labels := NewLabels([]int{4, 5}, func(index int) tea.Cmd {
+		jumped = index
+		return nil
+	})
+
+	labels.Update(intents.Apply{})
+
+	assert.Equal(t, 4, jumped)
+}
+
+func TestListLabels_DetachFromRendererOnCancel(t *testing.T) {
+	renderer := render.NewListRenderer(nil)
+	renderer.FirstRowIndex, renderer.LastRowIndex = 3, 5
+	labels := NewListLabels(renderer, func(int) tea.Cmd { return nil })
+	assert.Equal(t, labels, renderer.Overlay)
+	rest, _ := labels.Label(5)
+	assert.Equal(t, "d", rest)
+
+	labels.Update(intents.Cancel{})
+
+	assert.False(t, labels.Active())
+	assert.Nil(t, renderer.Overlay)
+}
+
+func makeRange(first, last int) []int {
+	var indices []int
+	for i := first; i <= last; i++ {
+		indices = append(indices, i)
+	}
+	return indices
+}
@@ -41,6 +41,7 @@ func (o *Operation) IsFocused() bool {
 
 func (o *Operation) Scopes() []common.Scope {
 	return []common.Scope{
+		//jjui:layer scope=revisions.ace_jump
 		{
 			Name:    actions.ScopeAceJump,
 			Leak:    common.LeakNone,
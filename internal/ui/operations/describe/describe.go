@@ -1,10 +1,15 @@
 package describe
 
 import (
+	"slices"
+	"strings"
+
 	"charm.land/bubbles/v2/textarea"
 	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/ui/actions"
+	"github.com/idursun/jjui/internal/ui/choose"
 	"github.com/idursun/jjui/internal/ui/common"
 	"github.com/idursun/jjui/internal/ui/context"
 	"github.com/idursun/jjui/internal/ui/intents"
@@ -28,10 +33,25 @@ type stashedDescription struct {
 }
 
 type Operation struct {
-	context      *context.MainContext
-	input        textarea.Model
-	revision     *jj.Commit
-	originalDesc string
+	context        *context.MainContext
+	input          textarea.Model
+	revision       *jj.Commit
+	originalDesc   string
+	initialValue   string
+	prefilled      bool
+	choosingPrefix bool
+	ids            *fullIds
+}
+
+// fullIds are the full change and commit ids that trailers may reference.
+type fullIds struct {
+	changeId string
+	commitId string
+}
+
+type fullIdsLoadedMsg struct {
+	ids  fullIds
+	auto bool
 }
 
 func (o *Operation) IsEditing() bool {
@@ -80,6 +100,23 @@ func (o *Operation) Update(msg tea.Msg) tea.Cmd {
 	case intents.Intent:
 		cmd, _ := o.HandleIntent(msg)
 		return cmd
+	case choose.SelectedMsg:
+		if o.choosingPrefix {
+			o.choosingPrefix = false
+			o.setValueKeepSubjectCursor(setConventionalPrefix(o.input.Value(), msg.Value, config.Current.Describe.ConventionalPrefixes))
+		}
+		return nil
+	case choose.CancelledMsg:
+		o.choosingPrefix = false
+		return nil
+	case fullIdsLoadedMsg:
+		o.ids = &msg.ids
+		if msg.auto {
+			o.applyAutoTrailers()
+		} else {
+			o.addTrailers()
+		}
+		return nil
 	}
 
 	o.input, cmd = o.input.Update(msg)
@@ -91,7 +128,8 @@ func (o *Operation) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 	switch intent := intent.(type) {
 	case intents.Cancel:
 		unsavedDescription := o.input.Value()
-		if o.originalDesc == "" && unsavedDescription != "" {
+		// a prefilled template or trailers alone are not worth stashing
+		if o.originalDesc == "" && unsavedDescription != "" && unsavedDescription != o.initialValue {
 			stashed = &stashedDescription{
 				revision:    o.revision,
 				description: unsavedDescription,
@@ -108,10 +146,96 @@ func (o *Operation) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		return nil, true
 	case intents.InlineDescribeAccept:
 		return o.runInlineDescribeAccept(intent.Force), true
+	case intents.InlineDescribeAddTrailers:
+		if o.ids == nil && needsIds(config.Current.Describe.Trailers, false) {
+			return o.loadFullIds(false), true
+		}
+		o.addTrailers()
+		return nil, true
+	case intents.InlineDescribePrefixPicker:
+		prefixes := config.Current.Describe.ConventionalPrefixes
+		if len(prefixes) == 0 {
+			return nil, true
+		}
+		o.choosingPrefix = true
+		return choose.ShowWithTitle(prefixes, "Commit type"), true
 	}
 	return nil, false
 }
 
+func (o *Operation) trailerValues() trailerValues {
+	values := trailerValues{
+		changeId: o.revision.GetChangeId(),
+		commitId: o.revision.CommitId,
+		eval:     o.context.EvalLua,
+	}
+	if jjConfig := o.context.JJConfig; jjConfig != nil {
+		values.user = strings.TrimSpace(jjConfig.User.Name + " <" + jjConfig.User.Email + ">")
+	}
+	if o.ids != nil {
+		values.changeId, values.commitId = o.ids.changeId, o.ids.commitId
+	}
+	return values
+}
+
+// loadFullIds looks up the full ids of the revision and adds the trailers
+// once they are known.
+func (o *Operation) loadFullIds(auto bool) tea.Cmd {
+	ids := fullIds{changeId: o.revision.GetChangeId(), commitId: o.revision.CommitId}
+	return func() tea.Msg {
+		if output, err := o.context.RunCommandImmediate(jj.GetFullIds(ids.changeId)); err == nil {
+			if changeId, commitId, ok := strings.Cut(strings.TrimSpace(string(output)), "\n"); ok {
+				ids = fullIds{changeId: changeId, commitId: commitId}
+			}
+		}
+		return fullIdsLoadedMsg{ids: ids, auto: auto}
+	}
+}
+
+func (o *Operation) addTrailers() {
+	trailers := renderTrailers(config.Current.Describe.Trailers, o.trailerValues(), false)
+	o.input.SetValue(appendTrailers(o.input.Value(), trailers))
+}
+
+// applyAutoTrailers adds the auto trailers. While the message is untouched
+// they count as part of the initial value; otherwise the cursor is kept where
+// the user left it.
+func (o *Operation) applyAutoTrailers() {
+	value := o.input.Value()
+	untouched := value == o.initialValue
+	value = appendTrailers(value, renderTrailers(config.Current.Describe.Trailers, o.trailerValues(), true))
+	switch {
+	case !untouched:
+		o.setValueKeepCursor(value)
+		return
+	case o.prefilled:
+		o.setValueKeepSubjectCursor(value)
+	default:
+		o.input.SetValue(value)
+	}
+	o.initialValue = o.input.Value()
+}
+
+// setValueKeepCursor replaces the message and moves the cursor back to its
+// line and column.
+func (o *Operation) setValueKeepCursor(value string) {
+	line, column := o.input.Line(), o.input.Column()
+	o.input.SetValue(value)
+	o.input.MoveToBegin()
+	for o.input.Line() < line && o.input.Line() < o.input.LineCount()-1 {
+		o.input.CursorDown()
+	}
+	o.input.SetCursorColumn(column)
+}
+
+// setValueKeepSubjectCursor replaces the message and puts the cursor at the
+// end of the subject line.
+func (o *Operation) setValueKeepSubjectCursor(value string) {
+	o.input.SetValue(value)
+	o.input.MoveToBegin()
+	o.input.CursorEnd()
+}
+
 func (o *Operation) runInlineDescribeEditor() tea.Cmd {
 	selectedRevisions := jj.NewSelectedRevisions(o.revision)
 	cmd := jj.SetDescription(o.revision.GetChangeId(), o.input.Value(), false)
@@ -128,6 +252,9 @@ func (o *Operation) runInlineDescribeAccept(force bool) tea.Cmd {
 }
 
 func (o *Operation) Init() tea.Cmd {
+	if o.ids == nil && needsIds(config.Current.Describe.Trailers, true) {
+		return o.loadFullIds(true)
+	}
 	return nil
 }
 
@@ -143,9 +270,71 @@ func (o *Operation) ViewRect(dl *render.DisplayContext, box layout.Box) {
 
 	rect := layout.Rect(box.R.Min.X, box.R.Min.Y, box.R.Dx(), input.Height())
 	dl.AddDraw(rect, input.View(), 0)
+	o.paintColumnGuide(dl, rect, input)
 	dl.SetCursorInRect(input.Cursor(), rect, 0, 0)
 }
 
+// paintColumnGuide highlights the part of the subject and body lines that run
+// past the configured widths.
+func (o *Operation) paintColumnGuide(dl *render.DisplayContext, rect layout.Rectangle, input textarea.Model) {
+	cfg := config.Current.Describe
+	if cfg.SubjectWidth <= 0 && cfg.BodyWidth <= 0 {
+		return
+	}
+	style := common.DefaultPalette.Get("inline_describe", "", "overflow", false)
+
+	// a separate textarea of the same width tells where each character of a
+	// line ends up once it is soft-wrapped
+	probe := textarea.New()
+	probe.Prompt = ""
+	probe.ShowLineNumbers = false
+	probe.CharLimit = 0
+	probe.SetWidth(input.Width())
+
+	lines := strings.Split(input.Value(), "\n")
+	columns := overflowColumns(lines, cfg.SubjectWidth, cfg.BodyWidth)
+	row := -input.ScrollYOffset()
+	for i, line := range lines {
+		probe.SetValue(line)
+		if columns[i] >= 0 {
+			for _, span := range overflowSpans(&probe, []rune(line), columns[i]) {
+				if y := row + span.row; y >= 0 && y < rect.Dy() {
+					dl.AddPaint(layout.Rect(rect.Min.X+span.x, rect.Min.Y+y, span.width, 1), style, 0)
+				}
+			}
+		}
+		row += probe.LineInfo().Height
+	}
+}
+
+type overflowSpan struct {
+	row   int
+	x     int
+	width int
+}
+
+// overflowSpans returns the visual rows covered by the characters of line
+// past limit, where probe holds line.
+func overflowSpans(probe *textarea.Model, line []rune, limit int) []overflowSpan {
+	var spans []overflowSpan
+	width := 0
+	for i, r := range line {
+		charWidth := render.StringWidth(string(r))
+		width += charWidth
+		if width <= limit {
+			continue
+		}
+		probe.SetCursorColumn(i)
+		info := probe.LineInfo()
+		if n := len(spans); n > 0 && spans[n-1].row == info.RowOffset && spans[n-1].x+spans[n-1].width == info.CharOffset {
+			spans[n-1].width += charWidth
+			continue
+		}
+		spans = append(spans, overflowSpan{row: info.RowOffset, x: info.CharOffset, width: charWidth})
+	}
+	return spans
+}
+
 func NewOperation(context *context.MainContext, revision *jj.Commit) *Operation {
 	descOutput, _ := context.RunCommandImmediate(jj.GetDescription(revision.GetChangeId()))
 	originalDesc := string(descOutput)
@@ -157,6 +346,12 @@ func NewOperation(context *context.MainContext, revision *jj.Commit) *Operation
 	// clear the stashed description regardless
 	stashed = nil
 
+	prefilled := false
+	if desc == "" && config.Current.Describe.Template != "" {
+		desc = config.Current.Describe.Template
+		prefilled = true
+	}
+
 	input := textarea.New()
 	input.CharLimit = 0
 	input.Prompt = ""
@@ -165,15 +360,27 @@ func NewOperation(context *context.MainContext, revision *jj.Commit) *Operation
 	input.MinHeight = 1
 	input.SetVirtualCursor(false)
 
-	input.SetValue(desc)
 	input.Focus()
 
-	return &Operation{
+	op := &Operation{
 		context:      context,
 		input:        input,
 		originalDesc: originalDesc,
 		revision:     revision,
 	}
+	hasAutoTrailers := slices.ContainsFunc(config.Current.Describe.Trailers, func(t config.TrailerConfig) bool { return t.Auto })
+	op.prefilled = prefilled || hasAutoTrailers && originalDesc == ""
+	if op.prefilled {
+		op.setValueKeepSubjectCursor(desc)
+	} else {
+		op.input.SetValue(desc)
+	}
+	op.initialValue = op.input.Value()
+	// trailers referencing the full ids are added by Init once they are loaded
+	if hasAutoTrailers && !needsIds(config.Current.Describe.Trailers, true) {
+		op.applyAutoTrailers()
+	}
+	return op
 }
 
 func (o *Operation) resizeInput(width, maxHeight int) textarea.Model {
@@ -4,12 +4,17 @@ import (
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/choose"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/intents"
 	"github.com/idursun/jjui/internal/ui/layout"
 	"github.com/idursun/jjui/internal/ui/operations"
 	"github.com/idursun/jjui/internal/ui/render"
 	"github.com/idursun/jjui/test"
 	"github.com/stretchr/testify/assert"
+	lua "github.com/yuin/gopher-lua"
 )
 
 func TestEmbeddedHeight_UsesDynamicHeight(t *testing.T) {
@@ -49,3 +54,176 @@ func TestViewRect_SyncsInputSizeForWrappedCursorMovement(t *testing.T) {
 	assert.Equal(t, 0, op.input.Line())
 	assert.Equal(t, 1, op.input.LineInfo().RowOffset)
 }
+
+func withDescribeConfig(t *testing.T, cfg config.DescribeConfig) {
+	t.Helper()
+	original := config.Current.Describe
+	config.Current.Describe = cfg
+	t.Cleanup(func() { config.Current.Describe = original })
+}
+
+func TestNewOperation_PrefillsTemplateAndAutoTrailers(t *testing.T) {
+	withDescribeConfig(t, config.DescribeConfig{
+		Template: "\n\nBug: ",
+		Trailers: []config.TrailerConfig{
+			{Key: "Change-Id", Value: "I$change_id", Auto: true},
+			{Key: "Reviewed-by", Value: "Bob"},
+		},
+	})
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetDescription("change")).SetOutput([]byte(""))
+	commandRunner.Expect(jj.GetFullIds("change")).SetOutput([]byte("changefull\ncommitfull"))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), &jj.Commit{ChangeId: "change", CommitId: "commit"})
+	assert.Equal(t, "\n\nBug: ", op.input.Value(), "ids are loaded by Init")
+
+	cmd := op.Init()
+	assert.NotNil(t, cmd)
+	op.Update(cmd())
+
+	assert.Equal(t, "\n\nBug: \nChange-Id: Ichangefull", op.input.Value())
+	assert.Equal(t, 0, op.input.Line(), "cursor should be on the subject line")
+
+	op.Update(intents.Cancel{})
+	assert.Nil(t, stashed, "auto trailers are part of the untouched message")
+}
+
+func TestAddTrailers_LoadsIdsOnce(t *testing.T) {
+	withDescribeConfig(t, config.DescribeConfig{
+		Trailers: []config.TrailerConfig{{Key: "Change-Id", Value: "I$change_id"}},
+	})
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetDescription("change")).SetOutput([]byte("subject"))
+	commandRunner.Expect(jj.GetFullIds("change")).SetOutput([]byte("changefull\ncommitfull"))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), &jj.Commit{ChangeId: "change", CommitId: "commit"})
+	assert.Nil(t, op.Init())
+
+	cmd := op.Update(intents.InlineDescribeAddTrailers{})
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "subject", op.input.Value())
+	op.Update(cmd())
+	assert.Equal(t, "subject\n\nChange-Id: Ichangefull", op.input.Value())
+
+	op.input.SetValue("other")
+	assert.Nil(t, op.Update(intents.InlineDescribeAddTrailers{}), "ids are reused")
+	assert.Equal(t, "other\n\nChange-Id: Ichangefull", op.input.Value())
+}
+
+func TestAddTrailers_EvaluatesLuaTrailers(t *testing.T) {
+	withDescribeConfig(t, config.DescribeConfig{
+		Trailers: []config.TrailerConfig{
+			{Key: "Ticket", Lua: `"ACME-" .. 42`},
+			{Key: "Broken", Lua: `nil .. 1`},
+		},
+	})
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetDescription("change")).SetOutput([]byte("subject"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.ScriptVM = lua.NewState()
+	defer ctx.ScriptVM.Close()
+	op := NewOperation(ctx, &jj.Commit{ChangeId: "change", CommitId: "commit"})
+
+	op.Update(intents.InlineDescribeAddTrailers{})
+	assert.Equal(t, "subject\n\nTicket: ACME-42", op.input.Value())
+}
+
+func TestCancel_DoesNotStashUntouchedTemplate(t *testing.T) {
+	withDescribeConfig(t, config.DescribeConfig{Template: "feat: "})
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetDescription("change")).SetOutput([]byte(""))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), &jj.Commit{ChangeId: "change", CommitId: "commit"})
+	op.Update(intents.Cancel{})
+	assert.Nil(t, stashed)
+}
+
+func TestPrefixPicker_ReplacesSubjectPrefix(t *testing.T) {
+	withDescribeConfig(t, config.DescribeConfig{ConventionalPrefixes: []string{"feat", "fix"}})
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetDescription("change")).SetOutput([]byte("feat: thing\n\nbody"))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), &jj.Commit{ChangeId: "change", CommitId: "commit"})
+	cmd := op.Update(intents.InlineDescribePrefixPicker{})
+	msg, ok := cmd().(common.ShowChooseMsg)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"feat", "fix"}, msg.Options)
+
+	op.Update(choose.SelectedMsg{Value: "fix"})
+	assert.Equal(t, "fix: thing\n\nbody", op.input.Value())
+
+	// selections from other choosers are ignored
+	op.Update(choose.SelectedMsg{Value: "feat"})
+	assert.Equal(t, "fix: thing\n\nbody", op.input.Value())
+}
+
+func TestAddTrailers_UsesUserFromJJConfig(t *testing.T) {
+	withDescribeConfig(t, config.DescribeConfig{
+		Trailers: []config.TrailerConfig{{Key: "Signed-off-by", Value: "$user"}},
+	})
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetDescription("change")).SetOutput([]byte("subject"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.JJConfig = &config.JJConfig{}
+	ctx.JJConfig.User.Name = "Jane"
+	ctx.JJConfig.User.Email = "jane@example.com"
+	op := NewOperation(ctx, &jj.Commit{ChangeId: "change", CommitId: "commit"})
+	assert.Equal(t, "subject", op.input.Value(), "non-auto trailers are not inserted on open")
+
+	op.Update(intents.InlineDescribeAddTrailers{})
+	assert.Equal(t, "subject\n\nSigned-off-by: Jane <jane@example.com>", op.input.Value())
+}
+
+func TestViewRect_PaintsColumnGuideOverflow(t *testing.T) {
+	withDescribeConfig(t, config.DescribeConfig{SubjectWidth: 5, BodyWidth: 10})
+	originalPalette := common.DefaultPalette
+	palette := common.NewPalette()
+	palette.Update(map[string]config.Color{"inline_describe overflow": {Bg: "red"}})
+	common.DefaultPalette = palette
+	defer func() { common.DefaultPalette = originalPalette }()
+
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetDescription("change")).SetOutput([]byte("0123456789\n\nshort"))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), &jj.Commit{ChangeId: "change", CommitId: "commit"})
+	dl := render.NewDisplayContext()
+	op.ViewRect(dl, layout.NewBox(layout.Rect(0, 0, 40, 5)))
+	buf := render.NewScreenBuffer(40, 5)
+	dl.Render(buf)
+	assert.Nil(t, buf.CellAt(4, 0).Style.Bg, "subject within the limit should not be painted")
+	assert.NotNil(t, buf.CellAt(5, 0).Style.Bg, "subject past the limit should be painted")
+	assert.Nil(t, buf.CellAt(3, 2).Style.Bg, "short body lines should not be painted")
+}
+
+func TestViewRect_PaintsColumnGuideOnSoftWrappedLines(t *testing.T) {
+	withDescribeConfig(t, config.DescribeConfig{SubjectWidth: 5, BodyWidth: 10})
+	originalPalette := common.DefaultPalette
+	palette := common.NewPalette()
+	palette.Update(map[string]config.Color{"inline_describe overflow": {Bg: "red"}})
+	common.DefaultPalette = palette
+	defer func() { common.DefaultPalette = originalPalette }()
+
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetDescription("change")).SetOutput([]byte("subj\n\naaaa bbbb cccc dddd\nok"))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), &jj.Commit{ChangeId: "change", CommitId: "commit"})
+	dl := render.NewDisplayContext()
+	op.ViewRect(dl, layout.NewBox(layout.Rect(0, 0, 12, 6)))
+	buf := render.NewScreenBuffer(12, 6)
+	dl.Render(buf)
+	assert.Nil(t, buf.CellAt(8, 2).Style.Bg, "the first visual row is within the limit")
+	assert.NotNil(t, buf.CellAt(0, 3).Style.Bg, "the wrapped part past the limit should be painted")
+	assert.NotNil(t, buf.CellAt(8, 3).Style.Bg)
+	assert.Nil(t, buf.CellAt(9, 3).Style.Bg)
+	assert.Nil(t, buf.CellAt(0, 4).Style.Bg, "the next line starts after the wrapped rows")
+}
@@ -0,0 +1,151 @@
+package describe
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+var (
+	conventionalPrefixPattern = regexp.MustCompile(`^([a-zA-Z]+)((?:\([^)]*\))?!?): ?`)
+	trailerPattern            = regexp.MustCompile(`^[A-Za-z0-9-]+: `)
+)
+
+type trailerValues struct {
+	user     string
+	changeId string
+	commitId string
+	// eval evaluates the Lua expression of trailers added by config.trailer
+	eval func(expr string) (string, error)
+}
+
+func (v trailerValues) expand(value string) string {
+	return strings.NewReplacer(
+		"$user", v.user,
+		"$change_id", v.changeId,
+		"$commit_id", v.commitId,
+	).Replace(value)
+}
+
+func needsIds(trailers []config.TrailerConfig, onlyAuto bool) bool {
+	for _, trailer := range trailers {
+		if onlyAuto && !trailer.Auto {
+			continue
+		}
+		if trailer.Lua == "" && strings.Contains(trailer.Value, "$change_id") || strings.Contains(trailer.Value, "$commit_id") {
+			return true
+		}
+	}
+	return false
+}
+
+func renderTrailers(trailers []config.TrailerConfig, values trailerValues, onlyAuto bool) []string {
+	var lines []string
+	for _, trailer := range trailers {
+		if onlyAuto && !trailer.Auto {
+			continue
+		}
+		if strings.TrimSpace(trailer.Key) == "" {
+			continue
+		}
+		value := values.expand(trailer.Value)
+		if trailer.Lua != "" {
+			if values.eval == nil {
+				continue
+			}
+			result, err := values.eval(trailer.Lua)
+			if err != nil || result == "" {
+				// a failing or empty hook leaves the trailer out
+				continue
+			}
+			value = result
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", trailer.Key, value))
+	}
+	return lines
+}
+
+// appendTrailers adds the trailer lines that aren't already in the message,
+// separating them from the body with a blank line unless the message already
+// ends with a trailer block.
+func appendTrailers(message string, trailers []string) string {
+	message = strings.TrimRight(message, "\n")
+	lines := strings.Split(message, "\n")
+
+	var missing []string
+	for _, trailer := range trailers {
+		found := false
+		for _, line := range lines {
+			if strings.TrimSpace(line) == trailer {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, trailer)
+		}
+	}
+	if len(missing) == 0 {
+		return message
+	}
+
+	if message == "" {
+		// leave room for the subject
+		return "\n\n" + strings.Join(missing, "\n")
+	}
+	if endsWithTrailerBlock(lines) {
+		return message + "\n" + strings.Join(missing, "\n")
+	}
+	return message + "\n\n" + strings.Join(missing, "\n")
+}
+
+func endsWithTrailerBlock(lines []string) bool {
+	if len(lines) < 2 {
+		return false
+	}
+	last := len(lines) - 1
+	start := last
+	for start >= 0 && trailerPattern.MatchString(lines[start]) {
+		start--
+	}
+	// the block must exist and be preceded by a blank line, otherwise it is the subject
+	return start < last && start >= 0 && strings.TrimSpace(lines[start]) == ""
+}
+
+// setConventionalPrefix replaces the conventional-commit type of the subject
+// line, keeping its scope and breaking-change marker, or adds one if the
+// subject has none. Only the types in types are replaced, so that a subject
+// such as "Revert: ..." gets the prefix in front of it.
+func setConventionalPrefix(message string, prefix string, types []string) string {
+	subject, rest, hasRest := strings.Cut(message, "\n")
+	if match := conventionalPrefixPattern.FindStringSubmatch(subject); match != nil && slices.Contains(types, match[1]) {
+		subject = prefix + match[2] + ": " + subject[len(match[0]):]
+	} else {
+		subject = prefix + ": " + subject
+	}
+	if hasRest {
+		return subject + "\n" + rest
+	}
+	return subject
+}
+
+// overflowColumns returns, for each line, the column after which the text
+// exceeds the configured width. -1 means the line is within bounds.
+func overflowColumns(lines []string, subjectWidth, bodyWidth int) []int {
+	result := make([]int, len(lines))
+	for i, line := range lines {
+		limit := bodyWidth
+		if i == 0 {
+			limit = subjectWidth
+		}
+		result[i] = -1
+		if limit > 0 && render.StringWidth(line) > limit {
+			result[i] = limit
+		}
+	}
+	return result
+}
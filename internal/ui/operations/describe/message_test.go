@@ -0,0 +1,67 @@
+package describe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendTrailers(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		trailers []string
+		want     string
+	}{
+		{
+			name:     "empty message leaves room for the subject",
+			message:  "",
+			trailers: []string{"Signed-off-by: Jane <jane@example.com>"},
+			want:     "\n\nSigned-off-by: Jane <jane@example.com>",
+		},
+		{
+			name:     "separates trailers from the body",
+			message:  "subject\n\nbody\n",
+			trailers: []string{"Reviewed-by: Bob"},
+			want:     "subject\n\nbody\n\nReviewed-by: Bob",
+		},
+		{
+			name:     "extends an existing trailer block",
+			message:  "subject\n\nSigned-off-by: Jane",
+			trailers: []string{"Reviewed-by: Bob"},
+			want:     "subject\n\nSigned-off-by: Jane\nReviewed-by: Bob",
+		},
+		{
+			name:     "skips trailers that are already present",
+			message:  "subject\n\nReviewed-by: Bob",
+			trailers: []string{"Reviewed-by: Bob"},
+			want:     "subject\n\nReviewed-by: Bob",
+		},
+		{
+			name:     "subject that looks like a trailer",
+			message:  "fix: something",
+			trailers: []string{"Reviewed-by: Bob"},
+			want:     "fix: something\n\nReviewed-by: Bob",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, appendTrailers(tc.message, tc.trailers))
+		})
+	}
+}
+
+func TestSetConventionalPrefix(t *testing.T) {
+	types := []string{"feat", "fix", "docs"}
+	assert.Equal(t, "feat: add thing", setConventionalPrefix("add thing", "feat", types))
+	assert.Equal(t, "fix(ui)!: add thing\n\nbody", setConventionalPrefix("feat(ui)!: add thing\n\nbody", "fix", types))
+	assert.Equal(t, "fix: add thing", setConventionalPrefix("feat:add thing", "fix", types))
+	assert.Equal(t, "docs: ", setConventionalPrefix("", "docs", types))
+	assert.Equal(t, "fix: Revert: add thing", setConventionalPrefix("Revert: add thing", "fix", types))
+}
+
+func TestOverflowColumns(t *testing.T) {
+	lines := []string{"0123456789", "short", "0123456789012"}
+	assert.Equal(t, []int{5, -1, 12}, overflowColumns(lines, 5, 12))
+	assert.Equal(t, []int{-1, -1, -1}, overflowColumns(lines, 0, 0))
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
 	"charm.land/bubbles/v2/key"
@@ -18,6 +19,7 @@ import (
 	"github.com/idursun/jjui/internal/ui/intents"
 	"github.com/idursun/jjui/internal/ui/layout"
 	"github.com/idursun/jjui/internal/ui/operations"
+	"github.com/idursun/jjui/internal/ui/operations/ace_jump"
 	"github.com/idursun/jjui/internal/ui/render"
 )
 
@@ -52,6 +54,7 @@ type Operation struct {
 	confirmation *confirmation.Model
 	filterInput  textinput.Model
 	filterState  filterState
+	jumpLabels   *ace_jump.Labels
 }
 
 func (s *Operation) IsOverlay() bool {
@@ -68,7 +71,11 @@ func (s *Operation) IsEditing() bool {
 
 func (s *Operation) Scopes() []common.Scope {
 	var ret []common.Scope
+	if s.jumpLabels.Active() {
+		ret = append(ret, s.jumpLabels.Scope())
+	}
 	if s.confirmation != nil {
+		//jjui:layer scope=revisions.details.confirmation
 		ret = append(ret, common.Scope{
 			Name:    actions.ScopeDetailsConfirmation,
 			Leak:    common.LeakNone,
@@ -80,12 +87,21 @@ func (s *Operation) Scopes() []common.Scope {
 		if s.filterState == filterEditing {
 			leak = common.LeakNone
 		}
+		//jjui:layer scope=revisions.details.filter outer=revisions.details,ui
 		ret = append(ret, common.Scope{
 			Name:    actions.ScopeDetails + ".filter",
 			Leak:    leak,
 			Handler: s,
 		})
 	}
+	if s.visual != nil {
+		//jjui:layer scope=revisions.details.visual outer=revisions.details,ui
+		ret = append(ret, common.Scope{
+			Name:    actions.ScopeDetailsVisual,
+			Leak:    common.LeakAll,
+			Handler: s,
+		})
+	}
 	ret = append(ret, common.Scope{
 		Name:    actions.ScopeDetails,
 		Leak:    common.LeakGlobal,
@@ -133,16 +149,29 @@ func (s *Operation) internalUpdate(msg tea.Msg) tea.Cmd {
 		return nil
 	case FileClickedMsg:
 		switch {
+		case msg.Shift:
+			prevCursor := s.cursor
+			s.setCursor(msg.Index)
+			if s.visual == nil {
+				s.selectRange(prevCursor, msg.Index)
+			}
 		case msg.Alt:
 			prevCursor := s.cursor
 			s.setCursor(msg.Index)
 			s.rangeSelect(prevCursor, msg.Index)
 		case msg.Ctrl:
 			s.setCursor(msg.Index)
-			if current := s.current(); current != nil {
+			if dir := s.currentDir(); dir != nil {
+				s.toggleDir(dir)
+			} else if current := s.current(); current != nil {
 				current.selected = !current.selected
 			}
 		default:
+			// clicking the directory under the cursor folds it
+			if s.cursor == msg.Index && s.currentDir() != nil {
+				s.toggleCollapsed()
+				return nil
+			}
 			s.setCursor(msg.Index)
 		}
 		return nil
@@ -184,6 +213,10 @@ func (s *Operation) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		if s.confirmation != nil {
 			return s.confirmation.Update(intent), true
 		}
+		if s.visual != nil {
+			s.cancelVisual()
+			return nil, true
+		}
 		if s.filterState != filterOff {
 			s.clearFilter()
 		}
@@ -198,6 +231,15 @@ func (s *Operation) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		return nil, true
 	case intents.DetailsClose:
 		return common.Close, true
+	case intents.StartAceJump:
+		if s.confirmation != nil {
+			return nil, true
+		}
+		s.jumpLabels = ace_jump.NewListLabels(s.listRenderer, func(index int) tea.Cmd {
+			s.setCursor(index)
+			return nil
+		})
+		return nil, true
 	case intents.DetailsOpenFilter:
 		return s.openFilter(), true
 	case intents.DetailsApplyFilter:
@@ -215,6 +257,13 @@ func (s *Operation) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 	case intents.Refresh:
 		return common.Refresh, true
 	case intents.DetailsDiff:
+		if dir := s.currentDir(); dir != nil {
+			return func() tea.Msg {
+				args := jj.DiffDirectory(s.revision.GetChangeId(), dir.path)
+				output, _ := s.context.RunCommandImmediate(args)
+				return intents.DiffShow{Content: string(output), Args: args}
+			}, true
+		}
 		selected := s.current()
 		if selected == nil {
 			return nil, true
@@ -298,13 +347,31 @@ func (s *Operation) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		)
 		s.confirmation = model
 		return s.confirmation.Init(), true
+	case intents.DetailsVisualMode:
+		s.toggleVisual()
+		return nil, true
+	case intents.DetailsCycleSort:
+		sortBy := s.cycleSort()
+		return intents.Invoke(intents.AddMessage{Text: "Sorted files by " + sortBy.String()}), true
+	case intents.DetailsToggleTree:
+		s.toggleTree()
+		return nil, true
+	case intents.DetailsToggleCollapsed:
+		s.toggleCollapsed()
+		return nil, true
 	case intents.DetailsToggleSelect:
-		if current := s.current(); current != nil {
+		if dir := s.currentDir(); dir != nil {
+			s.toggleDir(dir)
+			s.navigate(1, false)
+		} else if current := s.current(); current != nil {
 			current.selected = !current.selected
 			s.navigate(1, false)
 		}
 		return nil, true
 	case intents.DetailsRevisionsChangingFile:
+		if dir := s.currentDir(); dir != nil {
+			return tea.Batch(common.Close, common.UpdateRevSet(fmt.Sprintf("files(%s)", jj.EscapeDirName(dir.path)))), true
+		}
 		if current := s.current(); current != nil {
 			return tea.Batch(common.Close, common.UpdateRevSet(fmt.Sprintf("files(%s)", jj.EscapeFileName(current.fileName)))), true
 		}
@@ -491,7 +558,11 @@ func (s *Operation) getSelectedFiles(allowVirtualSelection bool) []string {
 		}
 	}
 	if len(selectedFiles) == 0 && allowVirtualSelection {
-		if current := s.current(); current != nil {
+		if dir := s.currentDir(); dir != nil {
+			for _, file := range s.dirFiles(dir) {
+				selectedFiles = append(selectedFiles, file.fileName)
+			}
+		} else if current := s.current(); current != nil {
 			selectedFiles = append(selectedFiles, current.fileName)
 		}
 	}
@@ -500,25 +571,42 @@ func (s *Operation) getSelectedFiles(allowVirtualSelection bool) []string {
 
 func (s *Operation) createListItems(content string, selectedFiles []string) []*item {
 	var items []*item
-	scanner := bufio.NewScanner(strings.NewReader(content))
-	scanner.Split(bufio.ScanWords)
+	header, after, _ := strings.Cut(content, "\n")
+	tokens, counts, _ := strings.Cut(header, "$")
 	var conflicts []bool
-	for scanner.Scan() {
-		field := scanner.Text()
-		if field == "$" {
-			break
+	var modes []string
+	for _, field := range strings.Fields(tokens) {
+		fields := strings.Split(field, ":")
+		conflicts = append(conflicts, fields[0] == "true")
+		mode := ""
+		if len(fields) == 3 && fields[1] != fields[2] {
+			mode = "-x"
+			if fields[2] == "true" {
+				mode = "+x"
+			}
 		}
-		conflicts = append(conflicts, field == "true")
+		modes = append(modes, mode)
 	}
 
-	_, after, _ := strings.Cut(content, "$")
-	scanner = bufio.NewScanner(strings.NewReader(after))
+	scanner := bufio.NewScanner(strings.NewReader(after))
 	index := 0
 	for scanner.Scan() {
 		file := strings.TrimSpace(scanner.Text())
 		if file == "" {
 			continue
 		}
+		// the --stat lines follow the summary lines, one per file
+		if index >= len(conflicts) {
+			if stat, ok := jj.ParseDiffStat(file); ok {
+				for _, item := range items {
+					if item.stat == nil && stat.Matches(item.fileName) {
+						item.stat = &stat
+						break
+					}
+				}
+			}
+			continue
+		}
 		summary, ok := jj.ParseSummaryFile(file)
 		if !ok {
 			continue
@@ -536,15 +624,36 @@ func (s *Operation) createListItems(content string, selectedFiles []string) []*i
 		case 'C':
 			status = Copied
 		}
+		name := summary.Name
+		if summary.Source != "" {
+			name = summary.FileName
+		}
+		mode := modes[index]
+		if status == Deleted {
+			mode = ""
+		}
 		items = append(items, &item{
 			status:   status,
-			name:     summary.Name,
+			name:     name,
 			fileName: summary.FileName,
 			selected: slices.ContainsFunc(selectedFiles, func(s string) bool { return s == summary.FileName }),
 			conflict: conflicts[index],
+			source:   summary.Source,
+			mode:     mode,
 		})
 		index++
 	}
+
+	// the line counts follow the files in the same order
+	counts, _, _ = strings.Cut(counts, "$")
+	for i, count := range strings.Fields(counts) {
+		added, removed, _ := strings.Cut(count, ":")
+		if i >= len(items) || items[i].stat == nil {
+			continue
+		}
+		items[i].stat.Added, _ = strconv.Atoi(added)
+		items[i].stat.Removed, _ = strconv.Atoi(removed)
+	}
 	return items
 }
 
@@ -1,6 +1,7 @@
 package details
 
 import (
+	"path"
 	"strings"
 	"unicode/utf8"
 
@@ -15,6 +16,7 @@ type FileClickedMsg struct {
 	Index int
 	Ctrl  bool
 	Alt   bool
+	Shift bool
 }
 
 type FileListScrollMsg struct {
@@ -42,6 +44,21 @@ type DetailsList struct {
 	filtering        bool
 	filterQuery      string
 	matches          []fileMatch
+	visual           *visualRange
+	unsorted         []*item
+	sortBy           fileSort
+	statColumns      statColumns
+	tree             bool
+	collapsed        map[string]bool
+	rows             []treeRow
+	treeTitleWidth   int
+}
+
+// visualRange selects every file between the anchor and the cursor, on top of
+// the files that were selected when it started.
+type visualRange struct {
+	anchor string
+	base   map[string]bool
 }
 
 func NewDetailsList() *DetailsList {
@@ -56,21 +73,18 @@ func NewDetailsList() *DetailsList {
 }
 
 func (d *DetailsList) setItems(files []*item) {
-	currentFile := ""
-	if current := d.current(); current != nil {
-		currentFile = current.fileName
-	}
-	d.files = files
+	currentFile := d.currentKey()
+	d.unsorted = files
+	d.statColumns = measureStats(files)
+	d.sortFiles()
+	d.visual = nil
 	d.rebuildMatches(currentFile)
 	d.listRenderer.SetScrollOffset(0)
 	d.ensureCursorView = true
 }
 
 func (d *DetailsList) setFilter(query string, enabled bool) {
-	currentFile := ""
-	if current := d.current(); current != nil {
-		currentFile = current.fileName
-	}
+	currentFile := d.currentKey()
 	d.filtering = enabled
 	d.filterQuery = query
 	d.rebuildMatches(currentFile)
@@ -91,6 +105,9 @@ func (d *DetailsList) rebuildMatches(preferredFile string) {
 	} else {
 		d.matches = nil
 	}
+	if d.tree {
+		d.buildTree()
+	}
 
 	visibleLen := d.VisibleLen()
 	if visibleLen == 0 {
@@ -99,7 +116,7 @@ func (d *DetailsList) rebuildMatches(preferredFile string) {
 	}
 	if preferredFile != "" {
 		for index := range visibleLen {
-			if candidate := d.itemAt(index); candidate != nil && candidate.fileName == preferredFile {
+			if d.rowKey(index) == preferredFile {
 				d.cursor = index
 				return
 			}
@@ -125,11 +142,7 @@ func (d *DetailsList) navigate(delta int, page bool) {
 		firstRowIndex := d.listRenderer.GetFirstRowIndex()
 		lastRowIndex := d.listRenderer.GetLastRowIndex()
 		span := max(lastRowIndex-firstRowIndex-1, 1)
-		if step < 0 {
-			step = -span
-		} else {
-			step = span
-		}
+		step = span * step
 	}
 
 	// Calculate new cursor position
@@ -148,6 +161,9 @@ func (d *DetailsList) setCursor(index int) {
 	if index >= 0 && index < d.VisibleLen() {
 		d.cursor = index
 		d.ensureCursorView = true
+		if d.visual != nil {
+			d.extendVisual()
+		}
 	}
 }
 
@@ -167,6 +183,9 @@ func (d *DetailsList) sourceIndex(index int) (int, bool) {
 	if index < 0 || index >= d.VisibleLen() {
 		return 0, false
 	}
+	if d.tree {
+		return d.rows[index].file, d.rows[index].file >= 0
+	}
 	if d.filtering {
 		return d.matches[index].index, true
 	}
@@ -188,11 +207,22 @@ func (d *DetailsList) RenderFileList(dl *render.DisplayContext, viewRect layout.
 
 	// Render function - renders each visible item
 	renderItem := func(dl *render.DisplayContext, index int, rect layout.Rectangle) {
+		isSelected := index == d.cursor
+		if dir := d.currentDirAt(index); dir != nil {
+			style := detailsPaletteStyle("text", isSelected)
+			if !isSelected {
+				style = style.Background(textStyle.GetBackground())
+			}
+			dl.AddFill(rect, ' ', lipgloss.NewStyle().Background(style.GetBackground()), 0)
+			tb := dl.Text(rect.Min.X, rect.Min.Y, 0)
+			d.renderDirContent(tb, d.rows[index], isSelected)
+			tb.Done()
+			return
+		}
 		item := d.itemAt(index)
 		if item == nil {
 			return
 		}
-		isSelected := index == d.cursor
 
 		baseStyle := d.getStatusStyle(item.status, isSelected)
 		if !isSelected {
@@ -203,7 +233,11 @@ func (d *DetailsList) RenderFileList(dl *render.DisplayContext, viewRect layout.
 
 		tb := dl.Text(rect.Min.X, rect.Min.Y, 0)
 		var match *fileMatch
-		if d.filtering {
+		if d.tree {
+			if row := d.rows[index]; row.match >= 0 {
+				match = &d.matches[row.match]
+			}
+		} else if d.filtering {
 			match = &d.matches[index]
 		}
 		d.renderItemContent(tb, item, index, match, baseStyle, isSelected)
@@ -215,6 +249,7 @@ func (d *DetailsList) RenderFileList(dl *render.DisplayContext, viewRect layout.
 			Index: index,
 			Ctrl:  mouse.Mod&tea.ModCtrl != 0,
 			Alt:   mouse.Mod&tea.ModAlt != 0,
+			Shift: mouse.Mod&tea.ModShift != 0,
 		}
 	}
 
@@ -235,22 +270,32 @@ func (d *DetailsList) RenderFileList(dl *render.DisplayContext, viewRect layout.
 func (d *DetailsList) renderItemContent(tb *render.TextBuilder, item *item, index int, match *fileMatch, style lipgloss.Style, selected bool) {
 	// Build title with checkbox
 	title := item.Title()
+	name := item.name
+	titleWidth := d.statColumns.titleWidth
+	if d.tree {
+		title = d.treeTitle(item, d.rows[index].depth)
+		name = path.Base(item.fileName)
+		titleWidth = d.treeTitleWidth
+	}
 	if item.selected {
 		tb.Styled("✓", style)
 	} else {
 		tb.Styled(" ", style)
 	}
-	if match == nil || match.start == match.end {
+	// the tree view only shows the base name, so the match may start before it
+	hidden := len(item.name) - len(name)
+	if match == nil || match.start == match.end || match.end <= hidden {
 		tb.Styled(title, style)
 	} else {
-		offset := len(title) - len(item.name)
-		start := offset + match.start
-		end := offset + match.end
+		offset := len(title) - len(name)
+		start := offset + max(match.start-hidden, 0)
+		end := offset + match.end - hidden
 		matchStyle := detailsPaletteStyle("matched", selected)
 		tb.Styled(title[:start], style)
 		tb.Styled(title[start:end], matchStyle)
 		tb.Styled(title[end:], style)
 	}
+	d.renderStats(tb, item, titleWidth-lipgloss.Width(title), style, selected)
 	tb.Styled(" ", style)
 
 	// Add conflict marker
@@ -290,6 +335,7 @@ func (d *DetailsList) getStatusStyle(s status, selected bool) lipgloss.Style {
 	return detailsPaletteStyle(role, selected)
 }
 
+//jjui:palette scope=revisions component=details role=text|added|deleted|modified|renamed|copied|matched|conflict|dimmed selected=true|false
 func detailsPaletteStyle(role string, selected bool) lipgloss.Style {
 	if selected {
 		return common.DefaultPalette.GetBlended("revisions", "details", role, true)
@@ -303,6 +349,61 @@ func (d *DetailsList) Scroll(delta int) {
 	d.listRenderer.SetScrollOffset(d.listRenderer.GetScrollOffset() + delta)
 }
 
+func (d *DetailsList) toggleVisual() {
+	if d.visual != nil {
+		d.visual = nil
+		return
+	}
+	current := d.current()
+	if current == nil {
+		return
+	}
+	base := make(map[string]bool)
+	for _, file := range d.files {
+		if file.selected {
+			base[file.fileName] = true
+		}
+	}
+	d.visual = &visualRange{anchor: current.fileName, base: base}
+	d.extendVisual()
+}
+
+// cancelVisual drops the range, restoring the selection from before it.
+func (d *DetailsList) cancelVisual() {
+	for _, file := range d.files {
+		file.selected = d.visual.base[file.fileName]
+	}
+	d.visual = nil
+}
+
+func (d *DetailsList) extendVisual() {
+	anchor := -1
+	for index := range d.VisibleLen() {
+		if file := d.itemAt(index); file != nil && file.fileName == d.visual.anchor {
+			anchor = index
+			break
+		}
+	}
+	if anchor == -1 {
+		d.visual = nil
+		return
+	}
+	for _, file := range d.files {
+		file.selected = d.visual.base[file.fileName]
+	}
+	d.selectRange(anchor, d.cursor)
+}
+
+// selectRange selects every file between from and to, unlike rangeSelect
+// which toggles them.
+func (d *DetailsList) selectRange(from, to int) {
+	for i := min(from, to); i <= max(from, to); i++ {
+		if item := d.itemAt(i); item != nil {
+			item.selected = true
+		}
+	}
+}
+
 func (d *DetailsList) rangeSelect(from, to int) {
 	lo := min(from, to)
 	hi := max(from, to)
@@ -321,6 +422,14 @@ func (d *DetailsList) Len() int {
 }
 
 func (d *DetailsList) VisibleLen() int {
+	if d.tree {
+		return len(d.rows)
+	}
+	return d.visibleFlatLen()
+}
+
+// visibleFlatLen is the number of files shown when the tree view is off.
+func (d *DetailsList) visibleFlatLen() int {
 	if d.filtering {
 		return len(d.matches)
 	}
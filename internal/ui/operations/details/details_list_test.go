@@ -6,6 +6,7 @@ import (
 
 	"charm.land/lipgloss/v2"
 	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/ui/common"
 	"github.com/idursun/jjui/internal/ui/layout"
 	"github.com/idursun/jjui/internal/ui/render"
@@ -107,3 +108,49 @@ func TestDetailsList_FilterWithNoMatchesHasNoCurrentItem(t *testing.T) {
 	assert.Zero(t, list.VisibleLen())
 	assert.Nil(t, list.current())
 }
+
+func TestDetailsList_VisualRangeFollowsCursorAndCancelRestoresSelection(t *testing.T) {
+	list := NewDetailsList()
+	list.setItems([]*item{
+		{status: Modified, name: "a.go", fileName: "a.go"},
+		{status: Modified, name: "b.go", fileName: "b.go"},
+		{status: Modified, name: "c.go", fileName: "c.go"},
+		{status: Modified, name: "d.go", fileName: "d.go", selected: true},
+	})
+
+	list.toggleVisual()
+	list.setCursor(2)
+	assert.Equal(t, []bool{true, true, true, true}, selectedFlags(list))
+
+	list.setCursor(1)
+	assert.Equal(t, []bool{true, true, false, true}, selectedFlags(list))
+
+	list.cancelVisual()
+	assert.Nil(t, list.visual)
+	assert.Equal(t, []bool{false, false, false, true}, selectedFlags(list))
+}
+
+func selectedFlags(list *DetailsList) []bool {
+	var flags []bool
+	for _, file := range list.files {
+		flags = append(flags, file.selected)
+	}
+	return flags
+}
+
+func TestDetailsList_RenderFileListShowsStatsModeAndSource(t *testing.T) {
+	list := NewDetailsList()
+	list.setItems([]*item{
+		{status: Modified, name: "main.go", fileName: "main.go", mode: "+x", stat: &jj.DiffStat{FileName: "main.go", Changes: 3, Added: 2, Removed: 1}},
+		{status: Renamed, name: "new.go", fileName: "new.go", source: "old.go", stat: &jj.DiffStat{FileName: "new.go"}},
+		{status: Added, name: "logo.png", fileName: "logo.png", stat: &jj.DiffStat{FileName: "logo.png", Binary: true}},
+	})
+
+	dl := render.NewDisplayContext()
+	list.RenderFileList(dl, layout.NewBox(layout.Rect(0, 0, 60, 3)))
+	lines := strings.Split(dl.RenderToString(60, 3), "\n")
+
+	assert.Contains(t, lines[0], "M main.go    3 ++- +x")
+	assert.Contains(t, lines[1], "R new.go     0 ← old.go")
+	assert.Contains(t, lines[2], "A logo.png bin")
+}
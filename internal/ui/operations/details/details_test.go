@@ -199,20 +199,110 @@ func TestOperation_FilterNoMatchesRendersInsideDetails(t *testing.T) {
 
 func TestOperation_createListItems(t *testing.T) {
 	operation := NewOperation(test.NewTestContext(test.NewTestCommandRunner(t)), commit)
-	content := `true false true false true $
+	content := `true:false:true false:true:false true:true:false false:false:false true:false:false $ 3:0 0:2 30:10 0:0 0:0 $
 A added.txt
 D deleted.txt
 M modified.txt
 R src/{old => renamed}.txt
-C copied.txt`
+C copied.txt
+added.txt           |  3 +++
+deleted.txt         |  2 --
+modified.txt        | 40 ++--
+src/{old => renamed}.txt | 0
+copied.txt          | (binary) +120 bytes
+5 files changed, 33 insertions(+), 12 deletions(-)`
 
 	got := operation.createListItems(content, []string{"deleted.txt"})
 
 	assert.Equal(t, []*item{
-		{status: Added, name: "added.txt", fileName: "added.txt", conflict: true},
-		{status: Deleted, name: "deleted.txt", fileName: "deleted.txt", selected: true},
-		{status: Modified, name: "modified.txt", fileName: "modified.txt", conflict: true},
-		{status: Renamed, name: "src/{old => renamed}.txt", fileName: "src/renamed.txt"},
-		{status: Copied, name: "copied.txt", fileName: "copied.txt", conflict: true},
+		{status: Added, name: "added.txt", fileName: "added.txt", conflict: true, mode: "+x",
+			stat: &jj.DiffStat{FileName: "added.txt", Changes: 3, Added: 3}},
+		{status: Deleted, name: "deleted.txt", fileName: "deleted.txt", selected: true,
+			stat: &jj.DiffStat{FileName: "deleted.txt", Changes: 2, Removed: 2}},
+		{status: Modified, name: "modified.txt", fileName: "modified.txt", conflict: true, mode: "-x",
+			stat: &jj.DiffStat{FileName: "modified.txt", Changes: 40, Added: 30, Removed: 10}},
+		{status: Renamed, name: "src/renamed.txt", fileName: "src/renamed.txt", source: "src/old.txt",
+			stat: &jj.DiffStat{FileName: "src/renamed.txt"}},
+		{status: Copied, name: "copied.txt", fileName: "copied.txt", conflict: true,
+			stat: &jj.DiffStat{FileName: "copied.txt", Binary: true}},
 	}, got)
 }
+
+func TestOperation_SortCyclesBetweenPathSizeAndStatus(t *testing.T) {
+	operation := NewOperation(test.NewTestContext(test.NewTestCommandRunner(t)), commit)
+	operation.setItems(operation.createListItems(`false false false $
+M a.txt
+A b.txt
+M c.txt
+a.txt | 1 +
+b.txt | 9 +++++++++
+c.txt | 4 ++--`, nil))
+
+	names := func() []string {
+		var names []string
+		for _, file := range operation.files {
+			names = append(names, file.fileName)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"a.txt", "b.txt", "c.txt"}, names())
+	assert.Equal(t, sortBySize, operation.cycleSort())
+	assert.Equal(t, []string{"b.txt", "c.txt", "a.txt"}, names())
+	assert.Equal(t, "a.txt", operation.current().fileName, "the cursor should stay on the same file")
+	assert.Equal(t, sortByStatus, operation.cycleSort())
+	assert.Equal(t, []string{"b.txt", "a.txt", "c.txt"}, names())
+	assert.Equal(t, sortByPath, operation.cycleSort())
+	assert.Equal(t, []string{"a.txt", "b.txt", "c.txt"}, names())
+}
+
+const treeStatusOutput = `false false false false $
+M README.md
+A internal/ui/tree.go
+M internal/ui/list.go
+M internal/jj/summary.go
+`
+
+func TestOperation_TreeViewGroupsFilesByDirectory(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	t.Cleanup(commandRunner.Verify)
+	operation := loadOperation(t, commandRunner, treeStatusOutput)
+
+	operation.HandleIntent(intents.DetailsToggleTree{})
+
+	output := test.Stripped(test.RenderImmediate(operation, 60, 7))
+	assert.Contains(t, output, "~ ▾ internal/ 3 files")
+	assert.Contains(t, output, "M   ▾ jj/ 1 file\n")
+	assert.Contains(t, output, "M     summary.go")
+	assert.Contains(t, output, "~   ▾ ui/ 2 files")
+	assert.Contains(t, output, "M README.md")
+	assert.Equal(t, "README.md", operation.currentKey(), "the cursor should stay on the same file")
+
+	operation.setCursor(1)
+	operation.HandleIntent(intents.DetailsToggleCollapsed{})
+	assert.Equal(t, 6, operation.VisibleLen(), "collapsing jj/ should hide its file")
+	assert.Equal(t, "internal/jj/", operation.currentKey())
+
+	operation.HandleIntent(intents.DetailsToggleTree{})
+	assert.Equal(t, 4, operation.VisibleLen())
+}
+
+func TestOperation_TreeViewSelectsAndDiffsDirectories(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	t.Cleanup(commandRunner.Verify)
+	operation := loadOperation(t, commandRunner, treeStatusOutput)
+	operation.HandleIntent(intents.DetailsToggleTree{})
+	// internal/, jj/, summary.go, ui/
+	operation.setCursor(3)
+
+	assert.Equal(t, []string{"internal/ui/tree.go", "internal/ui/list.go"}, operation.getSelectedFiles(true))
+
+	operation.HandleIntent(intents.DetailsToggleSelect{})
+	assert.Equal(t, []string{"internal/ui/tree.go", "internal/ui/list.go"}, operation.getSelectedFiles(false))
+
+	commandRunner.Expect(jj.DiffDirectory(revision, "internal"))
+	operation.setCursor(0)
+	cmd, handled := operation.HandleIntent(intents.DetailsDiff{})
+	require.True(t, handled)
+	msg := cmd()
+	assert.Equal(t, intents.DiffShow{Args: jj.DiffDirectory(revision, "internal")}, msg)
+}
@@ -2,6 +2,8 @@ package details
 
 import (
 	"fmt"
+
+	"github.com/idursun/jjui/internal/jj"
 )
 
 type status uint8
@@ -20,6 +22,11 @@ type item struct {
 	fileName string
 	selected bool
 	conflict bool
+	// source is the path a renamed or copied file came from
+	source string
+	// mode is "+x" or "-x" when the executable bit was set or cleared
+	mode string
+	stat *jj.DiffStat
 }
 
 func (f item) Title() string {
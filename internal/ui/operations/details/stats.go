@@ -0,0 +1,131 @@
+package details
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+// maxStatBar is the widest the +/- bar graph of a file can get.
+const maxStatBar = 10
+
+type fileSort uint8
+
+const (
+	sortByPath fileSort = iota
+	sortBySize
+	sortByStatus
+)
+
+func (s fileSort) String() string {
+	switch s {
+	case sortBySize:
+		return "size"
+	case sortByStatus:
+		return "status"
+	default:
+		return "path"
+	}
+}
+
+// cycleSort switches to the next sort order, keeping the cursor on the same
+// file.
+func (d *DetailsList) cycleSort() fileSort {
+	d.sortBy = (d.sortBy + 1) % (sortByStatus + 1)
+	currentFile := ""
+	if current := d.current(); current != nil {
+		currentFile = current.fileName
+	}
+	d.sortFiles()
+	d.rebuildMatches(currentFile)
+	d.ensureCursorView = true
+	return d.sortBy
+}
+
+// sortFiles orders files by the current sort. Sorting by path keeps the
+// order jj lists them in.
+func (d *DetailsList) sortFiles() {
+	d.files = slices.Clone(d.unsorted)
+	switch d.sortBy {
+	case sortBySize:
+		slices.SortStableFunc(d.files, func(a, b *item) int {
+			return b.changes() - a.changes()
+		})
+	case sortByStatus:
+		slices.SortStableFunc(d.files, func(a, b *item) int {
+			return int(a.status) - int(b.status)
+		})
+	}
+}
+
+func statCount(s *jj.DiffStat) string {
+	if s.Binary {
+		return "bin"
+	}
+	return fmt.Sprint(s.Changes)
+}
+
+func (f *item) changes() int {
+	if f.stat == nil {
+		return 0
+	}
+	return f.stat.Changes
+}
+
+// statColumns holds the widths that make the stats of all files line up.
+type statColumns struct {
+	titleWidth int
+	countWidth int
+	maxChanges int
+}
+
+func measureStats(files []*item) statColumns {
+	var columns statColumns
+	for _, file := range files {
+		if file.stat == nil {
+			continue
+		}
+		columns.titleWidth = max(columns.titleWidth, lipgloss.Width(file.Title()))
+		columns.countWidth = max(columns.countWidth, len(statCount(file.stat)))
+		columns.maxChanges = max(columns.maxChanges, file.stat.Changes)
+	}
+	return columns
+}
+
+// renderStats renders the change count with a bar graph, the executable bit
+// change and the source of a rename or copy after the title of file. padding
+// is the number of spaces that line the count up with the other files.
+func (d *DetailsList) renderStats(tb *render.TextBuilder, file *item, padding int, style lipgloss.Style, selected bool) {
+	columns := d.statColumns
+	if stat := file.stat; stat != nil {
+		tb.Styled(strings.Repeat(" ", max(padding, 0)), style)
+		tb.Styled(fmt.Sprintf(" %*s", columns.countWidth, statCount(stat)), style)
+
+		width := stat.Changes
+		if columns.maxChanges > maxStatBar {
+			width = stat.Changes * maxStatBar / columns.maxChanges
+			if stat.Changes > 0 {
+				width = max(width, 1)
+			}
+		}
+		added := 0
+		if width > 0 {
+			tb.Styled(" ", style)
+		}
+		if stat.Changes > 0 {
+			added = (width*stat.Added + stat.Changes/2) / stat.Changes
+		}
+		tb.Styled(strings.Repeat("+", added), detailsPaletteStyle("added", selected))
+		tb.Styled(strings.Repeat("-", width-added), detailsPaletteStyle("deleted", selected))
+	}
+	if file.mode != "" {
+		tb.Styled(" "+file.mode, detailsPaletteStyle("modified", selected))
+	}
+	if file.source != "" {
+		tb.Styled(" ← "+file.source, detailsPaletteStyle("dimmed", selected))
+	}
+}
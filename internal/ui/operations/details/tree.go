@@ -0,0 +1,239 @@
+package details
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+// treeRow is a visible row of the tree view: either a directory or the file
+// at index file of DetailsList.files.
+type treeRow struct {
+	file  int
+	match int
+	dir   *treeDir
+	depth int
+}
+
+// treeDir is a directory of the tree view. Directories with a single
+// subdirectory and no files are merged into it, so name can span several
+// path segments.
+type treeDir struct {
+	path  string
+	name  string
+	dirs  []*treeDir
+	files []int
+}
+
+// buildTree lays out the visible files as a directory tree, skipping the
+// contents of collapsed directories.
+func (d *DetailsList) buildTree() {
+	root := &treeDir{}
+	lookup := map[string]*treeDir{"": root}
+	var dirOf func(dir string) *treeDir
+	dirOf = func(dir string) *treeDir {
+		if node, ok := lookup[dir]; ok {
+			return node
+		}
+		parent := dirOf(parentDir(dir))
+		node := &treeDir{path: dir, name: path.Base(dir)}
+		parent.dirs = append(parent.dirs, node)
+		lookup[dir] = node
+		return node
+	}
+
+	matches := map[int]int{}
+	for index := range d.visibleFlatLen() {
+		file := index
+		if d.filtering {
+			file = d.matches[index].index
+			matches[file] = index
+		}
+		node := dirOf(parentDir(d.files[file].fileName))
+		node.files = append(node.files, file)
+	}
+
+	d.rows = nil
+	d.treeTitleWidth = 0
+	var walk func(node *treeDir, depth int)
+	walk = func(node *treeDir, depth int) {
+		slices.SortFunc(node.dirs, func(a, b *treeDir) int { return strings.Compare(a.name, b.name) })
+		for _, dir := range node.dirs {
+			for len(dir.dirs) == 1 && len(dir.files) == 0 {
+				child := dir.dirs[0]
+				dir.name += "/" + child.name
+				dir.path, dir.dirs, dir.files = child.path, child.dirs, child.files
+			}
+			d.rows = append(d.rows, treeRow{file: -1, match: -1, dir: dir, depth: depth})
+			if !d.collapsed[dir.path] {
+				walk(dir, depth+1)
+			}
+		}
+		for _, file := range node.files {
+			match := -1
+			if index, ok := matches[file]; ok {
+				match = index
+			}
+			d.rows = append(d.rows, treeRow{file: file, match: match, depth: depth})
+			d.treeTitleWidth = max(d.treeTitleWidth, lipgloss.Width(d.treeTitle(d.files[file], depth)))
+		}
+	}
+	walk(root, 0)
+}
+
+func parentDir(fileName string) string {
+	dir := path.Dir(fileName)
+	if dir == "." || dir == "/" {
+		return ""
+	}
+	return dir
+}
+
+// treeTitle is the title of a file in the tree view, where only the base name
+// is shown under the directory rows.
+func (d *DetailsList) treeTitle(file *item, depth int) string {
+	title := file.Title()
+	return title[:len(title)-len(file.name)] + strings.Repeat("  ", depth) + path.Base(file.fileName)
+}
+
+// toggleTree switches between the flat list and the tree view.
+func (d *DetailsList) toggleTree() {
+	key := d.currentKey()
+	d.tree = !d.tree
+	d.rebuildMatches(key)
+	d.ensureCursorView = true
+}
+
+// toggleCollapsed collapses or expands the directory under the cursor. On a
+// file it collapses the directory containing it.
+func (d *DetailsList) toggleCollapsed() {
+	if !d.tree || d.cursor < 0 || d.cursor >= len(d.rows) {
+		return
+	}
+	row := d.rows[d.cursor]
+	if row.dir == nil {
+		// find the directory row the file is listed under
+		for index := d.cursor - 1; index >= 0; index-- {
+			if d.rows[index].dir != nil && d.rows[index].depth < row.depth {
+				d.setCursor(index)
+				d.toggleCollapsed()
+				return
+			}
+		}
+		return
+	}
+	if d.collapsed == nil {
+		d.collapsed = map[string]bool{}
+	}
+	d.collapsed[row.dir.path] = !d.collapsed[row.dir.path]
+	d.rebuildMatches(d.currentKey())
+}
+
+// currentDir returns the directory under the cursor in the tree view.
+func (d *DetailsList) currentDir() *treeDir {
+	return d.currentDirAt(d.cursor)
+}
+
+func (d *DetailsList) currentDirAt(index int) *treeDir {
+	if !d.tree || index < 0 || index >= len(d.rows) {
+		return nil
+	}
+	return d.rows[index].dir
+}
+
+// dirFiles returns every file beneath dir that passes the filter, including
+// the ones hidden by collapsed directories.
+func (d *DetailsList) dirFiles(dir *treeDir) []*item {
+	var files []*item
+	prefix := dir.path + "/"
+	for index := range d.visibleFlatLen() {
+		file := d.files[index]
+		if d.filtering {
+			file = d.files[d.matches[index].index]
+		}
+		if strings.HasPrefix(file.fileName, prefix) {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// toggleDir checks every file beneath dir, or unchecks them all when they are
+// already checked.
+func (d *DetailsList) toggleDir(dir *treeDir) {
+	files := d.dirFiles(dir)
+	selected := !allSelected(files)
+	for _, file := range files {
+		file.selected = selected
+	}
+}
+
+func allSelected(files []*item) bool {
+	for _, file := range files {
+		if !file.selected {
+			return false
+		}
+	}
+	return len(files) > 0
+}
+
+// currentKey identifies the row under the cursor so that it can be found
+// again after the rows are rebuilt. Directories end with a slash.
+func (d *DetailsList) currentKey() string {
+	return d.rowKey(d.cursor)
+}
+
+func (d *DetailsList) rowKey(index int) string {
+	if d.tree && index >= 0 && index < len(d.rows) && d.rows[index].dir != nil {
+		return d.rows[index].dir.path + "/"
+	}
+	if file := d.itemAt(index); file != nil {
+		return file.fileName
+	}
+	return ""
+}
+
+// renderDirContent renders a directory row with a checkbox and status that
+// summarise the files beneath it.
+func (d *DetailsList) renderDirContent(tb *render.TextBuilder, row treeRow, selected bool) {
+	files := d.dirFiles(row.dir)
+	style := detailsPaletteStyle("text", selected)
+	check := " "
+	if allSelected(files) {
+		check = "✓"
+	} else if slices.ContainsFunc(files, func(file *item) bool { return file.selected }) {
+		check = "~"
+	}
+	tb.Styled(check, style)
+
+	status := "~"
+	statusStyle := detailsPaletteStyle("modified", selected)
+	if len(files) > 0 && !slices.ContainsFunc(files, func(file *item) bool { return file.status != files[0].status }) {
+		status = files[0].Title()[:1]
+		statusStyle = d.getStatusStyle(files[0].status, selected)
+	}
+	tb.Styled(status+" ", statusStyle)
+
+	arrow := "▾ "
+	if d.collapsed[row.dir.path] {
+		arrow = "▸ "
+	}
+	tb.Styled(strings.Repeat("  ", row.depth)+arrow+row.dir.name+"/", style)
+
+	changes := 0
+	for _, file := range files {
+		changes += file.changes()
+	}
+	summary := fmt.Sprintf(" %d files", len(files))
+	if len(files) == 1 {
+		summary = " 1 file"
+	}
+	if changes > 0 {
+		summary += fmt.Sprintf(", %d lines", changes)
+	}
+	tb.Styled(summary, detailsPaletteStyle("dimmed", selected))
+}
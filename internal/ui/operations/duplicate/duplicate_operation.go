@@ -26,6 +26,7 @@ var (
 )
 
 var _ operations.Operation = (*Operation)(nil)
+var _ operations.Repeatable = (*Operation)(nil)
 var _ common.Focusable = (*Operation)(nil)
 var _ common.ScopeProvider = (*Operation)(nil)
 
@@ -75,6 +76,13 @@ func (r *Operation) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// Repeat duplicates selected to the target of the applied duplicate.
+func (r *Operation) Repeat(selected jj.SelectedRevisions) tea.Cmd {
+	r.From = selected
+	cmd, _ := r.HandleIntent(intents.Apply{})
+	return cmd
+}
+
 func (r *Operation) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 	switch intent := intent.(type) {
 	case intents.StartAceJump:
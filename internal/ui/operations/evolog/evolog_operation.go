@@ -193,11 +193,7 @@ func (o *Operation) navigate(delta int, page bool) tea.Cmd {
 		firstRowIndex := o.dlRenderer.GetFirstRowIndex()
 		lastRowIndex := o.dlRenderer.GetLastRowIndex()
 		span := max(lastRowIndex-firstRowIndex-1, 1)
-		if step < 0 {
-			step = -span
-		} else {
-			step = span
-		}
+		step = span * step
 	}
 
 	// Calculate new cursor position
@@ -0,0 +1,254 @@
+package metaedit
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/actions"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/input"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/operations"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+var (
+	_ operations.Operation         = (*Operation)(nil)
+	_ operations.EmbeddedOperation = (*Operation)(nil)
+	_ common.Editable              = (*Operation)(nil)
+	_ common.ScopeProvider         = (*Operation)(nil)
+)
+
+const (
+	fieldAuthorName = iota
+	fieldAuthorEmail
+	fieldAuthorTimestamp
+)
+
+const timestampLayout = time.RFC3339
+
+type Operation struct {
+	context   *context.MainContext
+	revisions jj.SelectedRevisions
+	revision  *jj.Commit
+	form      *input.Form
+	loading   bool
+}
+
+type authorLoadedMsg struct {
+	name      string
+	email     string
+	timestamp string
+}
+
+// metaEditsMsg carries one metaedit per revision, prepared by applyEach.
+type metaEditsMsg struct {
+	edits []jj.CommandArgs
+}
+
+func (o *Operation) IsEditing() bool {
+	return true
+}
+
+func (o *Operation) IsFocused() bool {
+	return true
+}
+
+func (o *Operation) Scopes() []common.Scope {
+	return []common.Scope{
+		{
+			Name:    actions.ScopeMetaedit,
+			Leak:    common.LeakNone,
+			Handler: o,
+		},
+	}
+}
+
+// Init loads revision's current author as the initial values of the form.
+func (o *Operation) Init() tea.Cmd {
+	changeId := o.revision.GetChangeId()
+	return func() tea.Msg {
+		var msg authorLoadedMsg
+		if output, err := o.context.RunCommandImmediate(jj.GetAuthor(changeId)); err == nil {
+			msg.name, msg.email, msg.timestamp = parseAuthor(output)
+		}
+		return msg
+	}
+}
+
+func (o *Operation) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case intents.Intent:
+		cmd, _ := o.HandleIntent(msg)
+		return cmd
+	case authorLoadedMsg:
+		o.loading = false
+		o.form.SetInitialValue(fieldAuthorName, msg.name)
+		o.form.SetInitialValue(fieldAuthorEmail, msg.email)
+		o.form.SetInitialValue(fieldAuthorTimestamp, msg.timestamp)
+		return nil
+	case metaEditsMsg:
+		var cmds []tea.Cmd
+		for _, edit := range msg.edits {
+			cmds = append(cmds, o.context.RunCommand(edit))
+		}
+		cmds = append(cmds, common.CloseApplied, common.Refresh)
+		return tea.Sequence(cmds...)
+	}
+	return o.form.Update(msg)
+}
+
+func (o *Operation) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
+	switch intent := intent.(type) {
+	case intents.MetaEditFocusField:
+		return o.form.FocusNext(intent.Delta), true
+	case intents.MetaEditResetAuthor:
+		return o.context.RunCommand(jj.ResetAuthor(o.revisions, intent.Force), common.CloseApplied, common.Refresh), true
+	case intents.Apply:
+		return o.apply(intent.Force), true
+	case intents.Cancel:
+		return common.Close, true
+	}
+	return nil, false
+}
+
+func (o *Operation) apply(force bool) tea.Cmd {
+	if o.loading {
+		return nil
+	}
+	if err := o.form.Validate(); err != nil {
+		return nil
+	}
+	var options jj.MetaEditOptions
+	nameChanged, emailChanged := o.form.Changed(fieldAuthorName), o.form.Changed(fieldAuthorEmail)
+	name := strings.TrimSpace(o.form.Value(fieldAuthorName))
+	email := strings.TrimSpace(o.form.Value(fieldAuthorEmail))
+	if nameChanged || emailChanged {
+		options.Author = fmt.Sprintf("%s <%s>", name, email)
+	}
+	if o.form.Changed(fieldAuthorTimestamp) {
+		options.AuthorTimestamp = strings.TrimSpace(o.form.Value(fieldAuthorTimestamp))
+	}
+	if options == (jj.MetaEditOptions{}) {
+		return common.Close
+	}
+	if nameChanged != emailChanged && len(o.revisions.Revisions) > 1 {
+		return o.applyEach(options, force, nameChanged, name, email)
+	}
+	return o.context.RunCommand(jj.MetaEdit(o.revisions, options, force), common.CloseApplied, common.Refresh)
+}
+
+// applyEach rewrites the revisions one by one when only the author's name or
+// email was edited. jj only accepts the whole author, so each revision keeps
+// its own value for the part that wasn't edited.
+func (o *Operation) applyEach(options jj.MetaEditOptions, force bool, nameChanged bool, name string, email string) tea.Cmd {
+	revisions := o.revisions.Revisions
+	return func() tea.Msg {
+		var edits []jj.CommandArgs
+		for _, revision := range revisions {
+			output, err := o.context.RunCommandImmediate(jj.GetAuthor(revision.GetChangeId()))
+			if err != nil {
+				return common.CommandCompletedMsg{Err: err}
+			}
+			ownName, ownEmail, _ := parseAuthor(output)
+			revisionOptions := options
+			if nameChanged {
+				revisionOptions.Author = fmt.Sprintf("%s <%s>", name, ownEmail)
+			} else {
+				revisionOptions.Author = fmt.Sprintf("%s <%s>", ownName, email)
+			}
+			edits = append(edits, jj.MetaEdit(jj.NewSelectedRevisions(revision), revisionOptions, force))
+		}
+		return metaEditsMsg{edits: edits}
+	}
+}
+
+func (o *Operation) Render(commit *jj.Commit, pos operations.RenderPosition) string {
+	// only mark the other revisions; the form itself is embedded under o.revision
+	if pos != operations.RenderBeforeChangeId || len(o.revisions.Revisions) < 2 || !o.revisions.Contains(commit) {
+		return ""
+	}
+	return common.DefaultPalette.Get("metaedit", "", "source_marker", false).Render("<< metaedit >>")
+}
+
+func (o *Operation) CanEmbed(commit *jj.Commit, pos operations.RenderPosition) bool {
+	return pos == operations.RenderPositionAfter && commit.GetChangeId() == o.revision.GetChangeId()
+}
+
+func (o *Operation) EmbeddedHeight(commit *jj.Commit, pos operations.RenderPosition, _ int) int {
+	if !o.CanEmbed(commit, pos) {
+		return 0
+	}
+	return o.form.Height()
+}
+
+func (o *Operation) ViewRect(dl *render.DisplayContext, box layout.Box) {
+	o.form.ViewRect(dl, box)
+}
+
+func (o *Operation) Name() string {
+	return "metaedit"
+}
+
+func validateName(value string) error {
+	if strings.TrimSpace(value) == "" {
+		return errors.New("name cannot be empty")
+	}
+	if strings.ContainsAny(value, "<>") {
+		return errors.New("name cannot contain '<' or '>'")
+	}
+	return nil
+}
+
+func validateEmail(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return errors.New("email cannot be empty")
+	}
+	address, err := mail.ParseAddress("<" + value + ">")
+	if err != nil || address.Address != value {
+		return fmt.Errorf("%q is not a valid email address", value)
+	}
+	return nil
+}
+
+func validateTimestamp(value string) error {
+	if _, err := time.Parse(timestampLayout, strings.TrimSpace(value)); err != nil {
+		return fmt.Errorf("%q is not a valid timestamp, expected a format like %s", value, timestampLayout)
+	}
+	return nil
+}
+
+// parseAuthor splits the output of jj.GetAuthor into its name, email and
+// timestamp.
+func parseAuthor(output []byte) (string, string, string) {
+	lines := strings.SplitN(strings.TrimSpace(string(output)), "\n", 3)
+	for len(lines) < 3 {
+		lines = append(lines, "")
+	}
+	return lines[0], lines[1], lines[2]
+}
+
+// NewOperation edits the metadata of revisions. Init fills the form with
+// revision's current author.
+func NewOperation(context *context.MainContext, revisions jj.SelectedRevisions, revision *jj.Commit) *Operation {
+	form := input.NewForm("metaedit",
+		input.Field{Label: "Author", Placeholder: "name", Validate: validateName},
+		input.Field{Label: "Email", Placeholder: "user@example.com", Validate: validateEmail},
+		input.Field{Label: "Date", Placeholder: "2006-01-02T15:04:05+00:00", Validate: validateTimestamp},
+	)
+	return &Operation{
+		context:   context,
+		revisions: revisions,
+		revision:  revision,
+		form:      form,
+		loading:   true,
+	}
+}
@@ -0,0 +1,110 @@
+package metaedit
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	revision = &jj.Commit{ChangeId: "abc", CommitId: "123"}
+	author   = []byte("Jane Doe\njane@example.com\n2024-05-01T10:00:00+02:00\n")
+)
+
+// newOperation creates the operation and loads the author like the revisions
+// view does when it starts the operation.
+func newOperation(commandRunner *test.CommandRunner, revisions ...*jj.Commit) *Operation {
+	op := NewOperation(test.NewTestContext(commandRunner), jj.NewSelectedRevisions(revisions...), revisions[0])
+	test.SimulateModel(op, op.Init())
+	return op
+}
+
+func TestOperation_LoadsCurrentAuthor(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetAuthor("abc")).SetOutput(author)
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), jj.NewSelectedRevisions(revision), revision)
+	assert.Empty(t, op.form.Value(fieldAuthorName), "the author is loaded by Init")
+	test.SimulateModel(op, op.Init())
+	assert.False(t, op.form.Changed(fieldAuthorName))
+	assert.Equal(t, "Jane Doe", op.form.Value(fieldAuthorName))
+	assert.Equal(t, "jane@example.com", op.form.Value(fieldAuthorEmail))
+	assert.Equal(t, "2024-05-01T10:00:00+02:00", op.form.Value(fieldAuthorTimestamp))
+}
+
+func TestOperation_ApplyPassesOnlyChangedFields(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetAuthor("abc")).SetOutput(author)
+	commandRunner.Expect(jj.MetaEdit(jj.NewSelectedRevisions(revision), jj.MetaEditOptions{Author: "Jane Doe <jane@work.example>"}, false))
+	defer commandRunner.Verify()
+
+	op := newOperation(commandRunner, revision)
+	test.SimulateModel(op, func() tea.Msg { return intents.MetaEditFocusField{Delta: 1} })
+	op.form.SetValue(fieldAuthorEmail, "jane@work.example")
+	test.SimulateModel(op, func() tea.Msg { return intents.Apply{} })
+}
+
+func TestOperation_ApplyKeepsEachRevisionsNameWhenOnlyTheEmailChanged(t *testing.T) {
+	other := &jj.Commit{ChangeId: "def", CommitId: "456"}
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetAuthor("abc")).SetOutput(author)
+	commandRunner.Expect(jj.GetAuthor("def")).SetOutput([]byte("John Roe\njohn@example.com\n2024-05-02T10:00:00+02:00\n"))
+	commandRunner.Expect(jj.MetaEdit(jj.NewSelectedRevisions(revision), jj.MetaEditOptions{Author: "Jane Doe <team@work.example>"}, false))
+	commandRunner.Expect(jj.MetaEdit(jj.NewSelectedRevisions(other), jj.MetaEditOptions{Author: "John Roe <team@work.example>"}, false))
+	defer commandRunner.Verify()
+
+	op := newOperation(commandRunner, revision, other)
+	op.form.SetValue(fieldAuthorEmail, "team@work.example")
+	test.SimulateModel(op, func() tea.Msg { return intents.Apply{} })
+}
+
+func TestOperation_ApplyRejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		field int
+		value string
+	}{
+		{name: "empty name", field: fieldAuthorName, value: " "},
+		{name: "invalid email", field: fieldAuthorEmail, value: "not an email"},
+		{name: "invalid timestamp", field: fieldAuthorTimestamp, value: "yesterday"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			commandRunner := test.NewTestCommandRunner(t)
+			commandRunner.Expect(jj.GetAuthor("abc")).SetOutput(author)
+			defer commandRunner.Verify()
+
+			op := newOperation(commandRunner, revision)
+			op.form.SetValue(tc.field, tc.value)
+			test.SimulateModel(op, func() tea.Msg { return intents.Apply{} })
+			assert.Error(t, op.form.Err())
+			assert.Equal(t, tc.field, op.form.Focused(), "invalid field should receive focus")
+		})
+	}
+}
+
+func TestOperation_ResetAuthor(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetAuthor("abc")).SetOutput(author)
+	commandRunner.Expect(jj.ResetAuthor(jj.NewSelectedRevisions(revision), false))
+	defer commandRunner.Verify()
+
+	op := newOperation(commandRunner, revision)
+	test.SimulateModel(op, func() tea.Msg { return intents.MetaEditResetAuthor{} })
+}
+
+func TestOperation_ApplyWaitsForTheAuthor(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), jj.NewSelectedRevisions(revision), revision)
+	op.form.SetValue(fieldAuthorName, "Jane Doe")
+	op.form.SetValue(fieldAuthorEmail, "jane@example.com")
+	op.form.SetValue(fieldAuthorTimestamp, "2024-05-01T10:00:00+02:00")
+	assert.Nil(t, op.Update(intents.Apply{}))
+}
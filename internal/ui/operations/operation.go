@@ -26,6 +26,13 @@ type Operation interface {
 	Name() string
 }
 
+// Repeatable is implemented by operations that can apply their change again
+// to another selection once they have been applied.
+type Repeatable interface {
+	Operation
+	Repeat(selected jj.SelectedRevisions) tea.Cmd
+}
+
 type EmbeddedOperation interface {
 	Operation
 	CanEmbed(commit *jj.Commit, pos RenderPosition) bool
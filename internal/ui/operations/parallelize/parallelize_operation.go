@@ -0,0 +1,166 @@
+package parallelize
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/actions"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/operations"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+var (
+	_ operations.Operation = (*Operation)(nil)
+	_ common.Focusable     = (*Operation)(nil)
+	_ common.ScopeProvider = (*Operation)(nil)
+)
+
+const debounceDuration = 250 * time.Millisecond
+
+type Operation struct {
+	context *context.MainContext
+	From    jj.SelectedRevisions
+	current *jj.Commit
+	parents []string
+}
+
+type updateParentsMsg struct {
+	ids []string
+}
+
+func (p *Operation) IsFocused() bool {
+	return true
+}
+
+func (p *Operation) Scopes() []common.Scope {
+	return []common.Scope{
+		{
+			Name:    actions.ScopeParallelize,
+			Leak:    common.LeakAll,
+			Handler: p,
+		},
+	}
+}
+
+func (p *Operation) Init() tea.Cmd {
+	return p.refreshParents()
+}
+
+func (p *Operation) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case updateParentsMsg:
+		p.parents = msg.ids
+		return nil
+	case common.SelectionChangedMsg:
+		selected, ok := msg.Item.(common.SelectedRevision)
+		if !ok {
+			return nil
+		}
+		return p.setSelectedRevision(&jj.Commit{ChangeId: selected.ChangeId, CommitId: selected.CommitId})
+	case intents.Intent:
+		cmd, _ := p.HandleIntent(msg)
+		return cmd
+	}
+	return nil
+}
+
+func (p *Operation) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
+	switch intent := intent.(type) {
+	case intents.StartAceJump:
+		return common.StartAceJump(), true
+	case intents.ParallelizeToggleSelect:
+		if p.current == nil {
+			return nil, true
+		}
+		p.From = p.From.Toggle(p.current)
+		return p.refreshParents(), true
+	case intents.Apply:
+		// parallelizing a single revision is a no-op in jj
+		if len(p.From.Revisions) < 2 {
+			return common.Close, true
+		}
+		return p.context.RunCommand(jj.Parallelize(p.From, intent.Force), common.RefreshAndSelect(p.From.Last()), common.CloseApplied), true
+	case intents.Cancel:
+		return common.Close, true
+	}
+	return nil, false
+}
+
+func (p *Operation) setSelectedRevision(commit *jj.Commit) tea.Cmd {
+	if p.current.Equal(commit) {
+		return nil
+	}
+	p.current = commit
+	return nil
+}
+
+// refreshParents resolves the parents of the roots of the selected revisions.
+// After parallelizing, every selected revision becomes a child of these.
+func (p *Operation) refreshParents() tea.Cmd {
+	if len(p.From.Revisions) == 0 {
+		p.parents = nil
+		return nil
+	}
+	identifier := fmt.Sprintf("parallelize-parents-%p", p)
+	revset := fmt.Sprintf("roots(%s)-", strings.Join(p.From.GetIds(), "|"))
+	return common.Debounce(identifier, debounceDuration, func() tea.Msg {
+		output, err := p.context.RunCommandImmediate(jj.GetIdsFromRevset(revset))
+		if err != nil {
+			return nil
+		}
+		return updateParentsMsg{ids: strings.Fields(string(output))}
+	})
+}
+
+func (p *Operation) Render(commit *jj.Commit, pos operations.RenderPosition) string {
+	sourceMarkerStyle := common.DefaultPalette.Get("parallelize", "", "source_marker", false)
+	targetMarkerStyle := common.DefaultPalette.Get("parallelize", "", "target_marker", false)
+	changeIdStyle := common.DefaultPalette.Get("parallelize", "", "change_id", false)
+	dimmedStyle := common.DefaultPalette.Get("parallelize", "", "dimmed", false)
+
+	changeId := commit.GetChangeId()
+	if pos == operations.RenderBeforeChangeId {
+		if p.From.Contains(commit) {
+			return sourceMarkerStyle.Render("<< parallelize >>")
+		}
+		if slices.Contains(p.parents, changeId) {
+			return dimmedStyle.Render("<< new parent >>")
+		}
+		return ""
+	}
+
+	if pos != operations.RenderPositionBefore || len(p.parents) == 0 || changeId != p.parents[0] {
+		return ""
+	}
+	return lipgloss.JoinHorizontal(
+		lipgloss.Left,
+		targetMarkerStyle.Render("<< siblings >>"),
+		dimmedStyle.Render(" parallelize "),
+		changeIdStyle.Render(strings.Join(p.From.GetIds(), " ")),
+		dimmedStyle.Render(" as children of "),
+		changeIdStyle.Render(strings.Join(p.parents, " ")),
+	)
+}
+
+func (p *Operation) Name() string {
+	return "parallelize"
+}
+
+func (p *Operation) ViewRect(_ *render.DisplayContext, _ layout.Box) {}
+
+func NewOperation(context *context.MainContext, from jj.SelectedRevisions, current *jj.Commit) *Operation {
+	return &Operation{
+		context: context,
+		From:    from,
+		current: current,
+	}
+}
@@ -0,0 +1,59 @@
+package parallelize
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/operations"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	a        = &jj.Commit{ChangeId: "a"}
+	b        = &jj.Commit{ChangeId: "b"}
+	selected = jj.NewSelectedRevisions(a, b)
+)
+
+func Test_InitMarksNewParents(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetIdsFromRevset("roots(a|b)-")).SetOutput([]byte("p\n"))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), selected, a)
+	test.SimulateModel(op, op.Init())
+
+	assert.Contains(t, op.Render(a, operations.RenderBeforeChangeId), "<< parallelize >>")
+	assert.Contains(t, op.Render(&jj.Commit{ChangeId: "p"}, operations.RenderBeforeChangeId), "<< new parent >>")
+	assert.Contains(t, op.Render(&jj.Commit{ChangeId: "p"}, operations.RenderPositionBefore), "as children of")
+}
+
+func Test_Apply(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.Parallelize(selected, false))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), selected, a)
+	test.SimulateModel(op, func() tea.Msg { return intents.Apply{} })
+}
+
+func Test_ApplyWithSingleRevisionCloses(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), jj.NewSelectedRevisions(a), a)
+	test.SimulateModel(op, func() tea.Msg { return intents.Apply{} })
+}
+
+func Test_ToggleSelectRemovesRevision(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetIdsFromRevset("roots(a)-")).SetOutput([]byte("p\n"))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), jj.NewSelectedRevisions(a, b), b)
+	test.SimulateModel(op, func() tea.Msg { return intents.ParallelizeToggleSelect{} })
+
+	assert.Equal(t, []string{"a"}, op.From.GetIds())
+}
@@ -42,9 +42,10 @@ var (
 )
 
 var (
-	_ operations.Operation = (*Operation)(nil)
-	_ common.Focusable     = (*Operation)(nil)
-	_ common.ScopeProvider = (*Operation)(nil)
+	_ operations.Operation  = (*Operation)(nil)
+	_ operations.Repeatable = (*Operation)(nil)
+	_ common.Focusable      = (*Operation)(nil)
+	_ common.ScopeProvider  = (*Operation)(nil)
 )
 
 type Operation struct {
@@ -105,6 +106,13 @@ func (r *Operation) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// Repeat rebases selected the same way as the applied rebase.
+func (r *Operation) Repeat(selected jj.SelectedRevisions) tea.Cmd {
+	r.From = selected
+	cmd, _ := r.HandleIntent(intents.Apply{})
+	return cmd
+}
+
 func (r *Operation) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 	switch msg := intent.(type) {
 	case intents.StartAceJump:
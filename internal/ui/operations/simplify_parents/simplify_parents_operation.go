@@ -0,0 +1,189 @@
+package simplify_parents
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/actions"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/operations"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+var (
+	_ operations.Operation = (*Operation)(nil)
+	_ common.Focusable     = (*Operation)(nil)
+	_ common.ScopeProvider = (*Operation)(nil)
+)
+
+const debounceDuration = 250 * time.Millisecond
+
+type Operation struct {
+	context     *context.MainContext
+	From        jj.SelectedRevisions
+	Descendants bool
+	current     *jj.Commit
+	affected    []string
+	redundant   []string
+}
+
+type updatePreviewMsg struct {
+	affected  []string
+	redundant []string
+}
+
+func (s *Operation) IsFocused() bool {
+	return true
+}
+
+func (s *Operation) Scopes() []common.Scope {
+	return []common.Scope{
+		{
+			Name:    actions.ScopeSimplifyParents,
+			Leak:    common.LeakAll,
+			Handler: s,
+		},
+	}
+}
+
+func (s *Operation) Init() tea.Cmd {
+	return s.refreshPreview()
+}
+
+func (s *Operation) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case updatePreviewMsg:
+		s.affected = msg.affected
+		s.redundant = msg.redundant
+		return nil
+	case common.SelectionChangedMsg:
+		selected, ok := msg.Item.(common.SelectedRevision)
+		if !ok {
+			return nil
+		}
+		return s.setSelectedRevision(&jj.Commit{ChangeId: selected.ChangeId, CommitId: selected.CommitId})
+	case intents.Intent:
+		cmd, _ := s.HandleIntent(msg)
+		return cmd
+	}
+	return nil
+}
+
+func (s *Operation) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
+	switch intent := intent.(type) {
+	case intents.StartAceJump:
+		return common.StartAceJump(), true
+	case intents.SimplifyParentsToggleSelect:
+		if s.current == nil {
+			return nil, true
+		}
+		s.From = s.From.Toggle(s.current)
+		return s.refreshPreview(), true
+	case intents.SimplifyParentsToggleDescendants:
+		s.Descendants = !s.Descendants
+		return s.refreshPreview(), true
+	case intents.Apply:
+		if len(s.From.Revisions) == 0 {
+			return common.Close, true
+		}
+		return s.context.RunCommand(jj.SimplifyParents(s.From, s.Descendants, intent.Force), common.RefreshAndSelect(s.From.Last()), common.CloseApplied), true
+	case intents.Cancel:
+		return common.Close, true
+	}
+	return nil, false
+}
+
+func (s *Operation) setSelectedRevision(commit *jj.Commit) tea.Cmd {
+	if s.current.Equal(commit) {
+		return nil
+	}
+	s.current = commit
+	return nil
+}
+
+// refreshPreview resolves the revisions that will be rewritten and the
+// parents that will be dropped because they are ancestors of another parent.
+func (s *Operation) refreshPreview() tea.Cmd {
+	if len(s.From.Revisions) == 0 {
+		s.affected = nil
+		s.redundant = nil
+		return nil
+	}
+	identifier := fmt.Sprintf("simplify-parents-preview-%p", s)
+	ids := s.From.GetIds()
+	affectedRevset := strings.Join(ids, "|")
+	if s.Descendants {
+		affectedRevset = fmt.Sprintf("(%s)::", affectedRevset)
+	}
+	redundantRevset := redundantParentsRevset(ids)
+
+	return common.Debounce(identifier, debounceDuration, func() tea.Msg {
+		affected, err := s.context.RunCommandImmediate(jj.GetIdsFromRevset(affectedRevset))
+		if err != nil {
+			return nil
+		}
+		redundant, err := s.context.RunCommandImmediate(jj.GetIdsFromRevset(redundantRevset))
+		if err != nil {
+			return nil
+		}
+		return updatePreviewMsg{
+			affected:  strings.Fields(string(affected)),
+			redundant: strings.Fields(string(redundant)),
+		}
+	})
+}
+
+// redundantParentsRevset builds a revset matching, for each revision, the
+// parents that are reachable from one of its other parents.
+func redundantParentsRevset(ids []string) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprintf("(parents(%s) ~ heads(parents(%s)))", id, id))
+	}
+	return strings.Join(parts, " | ")
+}
+
+func (s *Operation) Render(commit *jj.Commit, pos operations.RenderPosition) string {
+	if pos != operations.RenderBeforeChangeId {
+		return ""
+	}
+	sourceMarkerStyle := common.DefaultPalette.Get("simplify_parents", "", "source_marker", false)
+	targetMarkerStyle := common.DefaultPalette.Get("simplify_parents", "", "target_marker", false)
+	dimmedStyle := common.DefaultPalette.Get("simplify_parents", "", "dimmed", false)
+
+	changeId := commit.GetChangeId()
+	if slices.Contains(s.redundant, changeId) {
+		return targetMarkerStyle.Render("<< drop parent >>")
+	}
+	if s.From.Contains(commit) {
+		if s.Descendants {
+			return sourceMarkerStyle.Render("<< simplify descendants of >>")
+		}
+		return sourceMarkerStyle.Render("<< simplify >>")
+	}
+	if slices.Contains(s.affected, changeId) {
+		return dimmedStyle.Render("<< simplify >>")
+	}
+	return ""
+}
+
+func (s *Operation) Name() string {
+	return "simplify parents"
+}
+
+func (s *Operation) ViewRect(_ *render.DisplayContext, _ layout.Box) {}
+
+func NewOperation(context *context.MainContext, from jj.SelectedRevisions, current *jj.Commit) *Operation {
+	return &Operation{
+		context: context,
+		From:    from,
+		current: current,
+	}
+}
@@ -0,0 +1,52 @@
+package simplify_parents
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/operations"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	commit   = &jj.Commit{ChangeId: "a"}
+	selected = jj.NewSelectedRevisions(commit)
+)
+
+func Test_InitMarksRedundantParents(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetIdsFromRevset("a")).SetOutput([]byte("a\n"))
+	commandRunner.Expect(jj.GetIdsFromRevset("(parents(a) ~ heads(parents(a)))")).SetOutput([]byte("p\n"))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), selected, commit)
+	test.SimulateModel(op, op.Init())
+
+	assert.Contains(t, op.Render(commit, operations.RenderBeforeChangeId), "<< simplify >>")
+	assert.Contains(t, op.Render(&jj.Commit{ChangeId: "p"}, operations.RenderBeforeChangeId), "<< drop parent >>")
+}
+
+func Test_ApplyWithDescendants(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetIdsFromRevset("(a)::")).SetOutput([]byte("a\nb\n"))
+	commandRunner.Expect(jj.GetIdsFromRevset("(parents(a) ~ heads(parents(a)))"))
+	commandRunner.Expect(jj.SimplifyParents(selected, true, false))
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), selected, commit)
+	test.SimulateModel(op, func() tea.Msg { return intents.SimplifyParentsToggleDescendants{} })
+	assert.Contains(t, op.Render(&jj.Commit{ChangeId: "b"}, operations.RenderBeforeChangeId), "<< simplify >>")
+
+	test.SimulateModel(op, func() tea.Msg { return intents.Apply{} })
+}
+
+func Test_Cancel(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	defer commandRunner.Verify()
+
+	op := NewOperation(test.NewTestContext(commandRunner), selected, commit)
+	test.SimulateModel(op, func() tea.Msg { return intents.Cancel{} })
+}
@@ -18,9 +18,10 @@ import (
 )
 
 var (
-	_ operations.Operation = (*Operation)(nil)
-	_ common.Focusable     = (*Operation)(nil)
-	_ common.ScopeProvider = (*Operation)(nil)
+	_ operations.Operation  = (*Operation)(nil)
+	_ operations.Repeatable = (*Operation)(nil)
+	_ common.Focusable      = (*Operation)(nil)
+	_ common.ScopeProvider  = (*Operation)(nil)
 )
 
 type Operation struct {
@@ -71,6 +72,13 @@ func (s *Operation) Update(msg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// Repeat squashes selected into the target of the applied squash.
+func (s *Operation) Repeat(selected jj.SelectedRevisions) tea.Cmd {
+	s.from = selected
+	cmd, _ := s.HandleIntent(intents.Apply{})
+	return cmd
+}
+
 func (s *Operation) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 	switch intent := intent.(type) {
 	case intents.StartAceJump:
@@ -14,6 +14,7 @@ import (
 	"github.com/idursun/jjui/internal/ui/context"
 	"github.com/idursun/jjui/internal/ui/intents"
 	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/operations/ace_jump"
 	"github.com/idursun/jjui/internal/ui/render"
 )
 
@@ -44,6 +45,7 @@ type Model struct {
 	cursor           int
 	ensureCursorView bool
 	quickSearch      string
+	jumpLabels       *ace_jump.Labels
 }
 
 func (m *Model) Len() int {
@@ -70,7 +72,11 @@ func (m *Model) HasQuickSearch() bool {
 
 func (m *Model) Scopes() []common.Scope {
 	var ret []common.Scope
+	if m.jumpLabels.Active() {
+		ret = append(ret, m.jumpLabels.Scope())
+	}
 	if m.HasQuickSearch() {
+		//jjui:layer scope=oplog.quick_search
 		ret = append(ret, common.Scope{
 			Name:    actions.ScopeOplogQuickSearch,
 			Leak:    common.LeakNone,
@@ -143,6 +149,12 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		}
 		m.SetCursor(m.search(m.cursor+offset, intent.Reverse))
 		return nil, true
+	case intents.StartAceJump:
+		m.jumpLabels = ace_jump.NewListLabels(m.listRenderer, func(index int) tea.Cmd {
+			m.SetCursor(index)
+			return nil
+		})
+		return nil, true
 	case intents.OpLogQuickSearchClear:
 		m.quickSearch = ""
 		return nil, true
@@ -161,11 +173,7 @@ func (m *Model) navigate(delta int, page bool) tea.Cmd {
 		firstRowIndex := m.listRenderer.GetFirstRowIndex()
 		lastRowIndex := m.listRenderer.GetLastRowIndex()
 		span := max(lastRowIndex-firstRowIndex-1, 1)
-		if step < 0 {
-			step = -span
-		} else {
-			step = span
-		}
+		step = span * step
 	}
 
 	// Calculate new cursor position
@@ -91,3 +91,21 @@ func TestScopes_ExposeQuickSearchScopeWhenSearchActive(t *testing.T) {
 	assert.Equal(t, bindings.ScopeName(actions.ScopeOplogQuickSearch), scopes[0].Name)
 	assert.Equal(t, bindings.ScopeName(actions.ScopeOplog), scopes[1].Name)
 }
+
+func TestAceJump_MovesCursorToLabelledOperation(t *testing.T) {
+	m := &Model{
+		context: &context.MainContext{},
+		rows:    []row{{OperationId: "op1"}, {OperationId: "op2"}, {OperationId: "op3"}},
+	}
+	m.listRenderer = render.NewListRenderer(OpLogScrollMsg{})
+	m.listRenderer.FirstRowIndex, m.listRenderer.LastRowIndex = 0, 2
+
+	m.Update(intents.StartAceJump{})
+	scopes := m.Scopes()
+	require.Equal(t, bindings.ScopeName(actions.ScopeJumpLabels), scopes[0].Name)
+
+	scopes[0].Handler.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+
+	assert.Equal(t, 2, m.cursor)
+	assert.Equal(t, bindings.ScopeName(actions.ScopeOplog), m.Scopes()[0].Name)
+}
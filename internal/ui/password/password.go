@@ -33,6 +33,7 @@ func New(msg common.TogglePasswordMsg) *Model {
 
 func (m *Model) Scopes() []common.Scope {
 	return []common.Scope{
+		//jjui:layer scope=password
 		{
 			Name:    actions.ScopePassword,
 			Leak:    common.LeakNone,
@@ -22,6 +22,7 @@ type Model struct {
 
 func (m *Model) Scopes() []common.Scope {
 	return []common.Scope{
+		//jjui:layer scope=redo
 		{
 			Name:    actions.ScopeRedo,
 			Leak:    common.LeakNone,
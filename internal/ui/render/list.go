@@ -42,12 +42,20 @@ type ClickMessage = tea.Msg
 
 type ClickMessageFunc func(index int, mouse tea.Mouse) ClickMessage
 
+// ItemOverlay draws on top of each visible item after the item itself has
+// been rendered, e.g. the jump labels of ace jump.
+type ItemOverlay interface {
+	RenderItemOverlay(dl *DisplayContext, index int, rect layout.Rectangle, z int)
+}
+
 type ListRenderer struct {
 	StartLine     int
 	ScrollMsg     tea.Msg
 	Z             int // Interaction z-index; default is 0.
 	FirstRowIndex int
 	LastRowIndex  int
+	Overlay       ItemOverlay
+	visibleSpans  []span
 }
 
 func NewListRenderer(scrollMsg tea.Msg) *ListRenderer {
@@ -104,6 +112,7 @@ func (r *ListRenderer) Render(
 	}
 
 	spans, _ := layoutAll(viewport, itemCount, measureAdapter)
+	r.visibleSpans = spans
 	if len(spans) > 0 {
 		r.FirstRowIndex = spans[0].Index
 		r.LastRowIndex = spans[len(spans)-1].Index
@@ -120,6 +129,9 @@ func (r *ListRenderer) Render(
 		renderVisibleSpan(dl, span, itemHeight, r.Z, func(itemDL *DisplayContext, rect layout.Rectangle) {
 			render(itemDL, span.Index, rect)
 		})
+		if r.Overlay != nil {
+			r.Overlay.RenderItemOverlay(dl, span.Index, span.Rect, r.Z+1)
+		}
 		idx := span.Index
 		dl.AddInteractionFn(span.Rect, func(mouseMsg tea.MouseMsg) tea.Msg {
 			return clickMsg(idx, mouseMsg.Mouse())
@@ -157,6 +169,17 @@ func (r *ListRenderer) ensureCursorVisible(
 	}
 }
 
+// ItemAt returns the index and the on-screen rectangle of the item that was
+// rendered at the given position by the last Render.
+func (r *ListRenderer) ItemAt(x, y int) (int, layout.Rectangle, bool) {
+	for _, span := range r.visibleSpans {
+		if x >= span.Rect.Min.X && x < span.Rect.Max.X && y >= span.Rect.Min.Y && y < span.Rect.Max.Y {
+			return span.Index, span.Rect, true
+		}
+	}
+	return -1, layout.Rectangle{}, false
+}
+
 func (r *ListRenderer) SetScrollOffset(offset int) {
 	r.StartLine = offset
 }
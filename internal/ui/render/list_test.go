@@ -124,3 +124,21 @@ func TestListRendererRenderUsesVisibleHeightForBottomClippedItem(t *testing.T) {
 
 	assert.Equal(t, 3, seenHeight)
 }
+
+func TestListRenderer_ItemAtReturnsRenderedItem(t *testing.T) {
+	r := NewListRenderer(nil)
+	dl := NewDisplayContext()
+	r.Render(dl, layout.NewBox(layout.Rect(0, 2, 10, 4)), 5, 0, false,
+		func(int) int { return 2 },
+		func(*DisplayContext, int, layout.Rectangle) {},
+		func(index int, _ tea.Mouse) ClickMessage { return index },
+	)
+
+	index, rect, ok := r.ItemAt(3, 5)
+	assert.True(t, ok)
+	assert.Equal(t, 1, index)
+	assert.Equal(t, layout.Rect(0, 4, 10, 2), rect)
+
+	_, _, ok = r.ItemAt(3, 6)
+	assert.False(t, ok)
+}
@@ -135,6 +135,7 @@ func (r *DisplayContextRenderer) Render(
 			Index: index,
 			Ctrl:  mouse.Mod&tea.ModCtrl != 0,
 			Alt:   mouse.Mod&tea.ModAlt != 0,
+			Shift: mouse.Mod&tea.ModShift != 0,
 		}
 	}
 
@@ -702,3 +703,8 @@ func (r *DisplayContextRenderer) GetFirstRowIndex() int {
 func (r *DisplayContextRenderer) GetLastRowIndex() int {
 	return r.listRenderer.GetLastRowIndex()
 }
+
+// ItemAt returns the row rendered at the given screen position.
+func (r *DisplayContextRenderer) ItemAt(x, y int) (int, layout.Rectangle, bool) {
+	return r.listRenderer.ItemAt(x, y)
+}
@@ -0,0 +1,143 @@
+package revisions
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/operations"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+// dragState tracks a revision being dragged with the mouse. The drag starts
+// with a plain click on a revision and turns into a rebase once the revision
+// is dropped on another row.
+type dragState struct {
+	source int
+	target int
+	mode   intents.ModeTarget
+	from   intents.RebaseSource
+	x, y   int
+}
+
+func (d *dragState) active() bool {
+	return d.target >= 0 && d.target != d.source
+}
+
+// IsDragging reports whether mouse motion and release events should be sent
+// to the revisions list.
+func (m *Model) IsDragging() bool {
+	return m.drag != nil
+}
+
+func (m *Model) startDrag(index int) {
+	if _, ok := m.baseOperation().(*operations.Default); !ok || len(m.layers) > 0 {
+		return
+	}
+	if index < 0 || index >= len(m.rows) || m.rows[index].Commit == nil {
+		return
+	}
+	m.drag = &dragState{source: index, target: -1}
+}
+
+// dragTo updates the drop target under the mouse.
+func (m *Model) dragTo(mouse tea.Mouse) {
+	m.drag.x, m.drag.y = mouse.X, mouse.Y
+	m.drag.from = dragSource(mouse.Mod)
+	index, rect, ok := m.displayContextRenderer.ItemAt(mouse.X, mouse.Y)
+	if !ok || index >= len(m.rows) || m.rows[index].Commit == nil {
+		m.drag.target = -1
+		return
+	}
+	m.drag.target = index
+	m.drag.mode = dropMode(mouse.Y-rect.Min.Y, rect.Dy())
+}
+
+// drop starts a rebase of the dragged revision, or of all checked revisions
+// when the dragged one is checked, onto the drop target.
+func (m *Model) drop(mouse tea.Mouse) tea.Cmd {
+	m.dragTo(mouse)
+	drag := m.drag
+	m.drag = nil
+	if !drag.active() || drag.source >= len(m.rows) {
+		return nil
+	}
+	source := m.rows[drag.source].Commit
+	selected := jj.NewSelectedRevisions(source)
+	if _, checked := m.checkedRevisions[source.CommitId]; checked {
+		selected = m.SelectedRevisions()
+	}
+	m.SetCursor(drag.target)
+	return m.startRebase(intents.OpenRebase{Selected: selected, Source: drag.from, Target: drag.mode})
+}
+
+// dragSource picks the rebase source flag from the modifier keys held while
+// dragging: none for -r, alt for -s and ctrl for -b.
+func dragSource(mod tea.KeyMod) intents.RebaseSource {
+	switch {
+	case mod&tea.ModAlt != 0:
+		return intents.RebaseSourceDescendants
+	case mod&tea.ModCtrl != 0:
+		return intents.RebaseSourceBranch
+	default:
+		return intents.RebaseSourceRevision
+	}
+}
+
+// dropMode picks where to place the revision from the line of the target row
+// it is dropped on. The first line of a taller row inserts after the target,
+// the last line inserts before it and anything in between rebases onto it.
+// Rows of two lines have no room for all three, so their first line is onto
+// and the second inserts before; the gap above a row is still reachable as
+// the second line of the row above.
+func dropMode(line int, height int) intents.ModeTarget {
+	switch {
+	case height >= 3 && line == 0:
+		return intents.ModeTargetAfter
+	case height >= 2 && line == height-1:
+		return intents.ModeTargetBefore
+	default:
+		return intents.ModeTargetDestination
+	}
+}
+
+func (m *Model) updateDrag(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case tea.MouseMotionMsg:
+		m.dragTo(msg.Mouse())
+	case tea.MouseReleaseMsg:
+		return m.drop(msg.Mouse())
+	}
+	return nil
+}
+
+// renderDragGhost draws a line describing the pending rebase next to the
+// mouse pointer.
+func (m *Model) renderDragGhost(dl *render.DisplayContext, box layout.Box) {
+	if m.drag == nil || !m.drag.active() || max(m.drag.source, m.drag.target) >= len(m.rows) {
+		return
+	}
+	if m.drag.y < box.R.Min.Y || m.drag.y >= box.R.Max.Y {
+		return
+	}
+	flags := map[intents.RebaseSource]string{
+		intents.RebaseSourceRevision:    "-r",
+		intents.RebaseSourceDescendants: "-s",
+		intents.RebaseSourceBranch:      "-b",
+	}
+	places := map[intents.ModeTarget]string{
+		intents.ModeTargetDestination: "onto",
+		intents.ModeTargetAfter:       "after",
+		intents.ModeTargetBefore:      "before",
+	}
+	source := m.rows[m.drag.source].Commit
+	target := m.rows[m.drag.target].Commit
+	text := fmt.Sprintf(" rebase %s %s %s %s ", flags[m.drag.from], source.GetChangeId(), places[m.drag.mode], target.GetChangeId())
+
+	style := common.DefaultPalette.Get("revisions", "", "drag", false)
+	x := min(max(m.drag.x+1, box.R.Min.X), box.R.Max.X-1)
+	dl.AddDraw(layout.Rect(x, m.drag.y, box.R.Max.X-x, 1), style.Render(text), 1)
+}
@@ -13,16 +13,22 @@ import (
 
 	"github.com/idursun/jjui/internal/ui/actions"
 	"github.com/idursun/jjui/internal/ui/bindings"
+	"github.com/idursun/jjui/internal/ui/choose"
+	"github.com/idursun/jjui/internal/ui/fix"
 	"github.com/idursun/jjui/internal/ui/intents"
 	"github.com/idursun/jjui/internal/ui/layout"
 	"github.com/idursun/jjui/internal/ui/operations/ace_jump"
 	"github.com/idursun/jjui/internal/ui/operations/diff_range"
 	"github.com/idursun/jjui/internal/ui/operations/duplicate"
+	"github.com/idursun/jjui/internal/ui/operations/metaedit"
 	"github.com/idursun/jjui/internal/ui/operations/new_between"
+	"github.com/idursun/jjui/internal/ui/operations/parallelize"
 	"github.com/idursun/jjui/internal/ui/operations/revert"
 	"github.com/idursun/jjui/internal/ui/operations/set_parents"
+	"github.com/idursun/jjui/internal/ui/operations/simplify_parents"
 	"github.com/idursun/jjui/internal/ui/operations/target_picker"
 	"github.com/idursun/jjui/internal/ui/render"
+	"github.com/idursun/jjui/internal/ui/revset"
 
 	"github.com/idursun/jjui/internal/parser"
 	"github.com/idursun/jjui/internal/screen"
@@ -73,6 +79,17 @@ type Model struct {
 	ensureCursorView       bool
 	requestInFlight        bool
 	checkedRevisions       map[string]appContext.SelectedRevision
+	pendingMove            *pendingMove
+	drag                   *dragState
+	visual                 *visualMode
+	// lastApplied is the operation that "." applies again
+	lastApplied operations.Repeatable
+}
+
+// pendingMove remembers a next/prev request waiting for the user to pick one
+// of several candidate revisions.
+type pendingMove struct {
+	edit bool
 }
 
 type revisionReloadState struct {
@@ -95,6 +112,7 @@ type ItemClickedMsg struct {
 	Index int
 	Ctrl  bool
 	Alt   bool
+	Shift bool
 }
 
 type ViewportScrollMsg struct {
@@ -108,6 +126,20 @@ func (v ViewportScrollMsg) SetDelta(delta int, horizontal bool) tea.Msg {
 	return v
 }
 
+// navigateTargetMsg carries the revision a parent or child jump resolved to.
+type navigateTargetMsg struct {
+	revision   string
+	ensureView bool
+}
+
+// moveCandidatesMsg carries the revisions a next/prev request could move to.
+type moveCandidatesMsg struct {
+	args       jj.CommandArgs
+	title      string
+	edit       bool
+	candidates []string
+}
+
 type updateRevisionsMsg struct {
 	rows []parser.Row
 	tag  uint64
@@ -134,6 +166,9 @@ func (m *Model) SetCursor(index int) {
 	if index >= 0 && index < len(m.rows) {
 		m.cursor = index
 		m.ensureCursorView = true
+		if m.visual != nil {
+			m.extendVisual()
+		}
 	}
 }
 
@@ -197,6 +232,7 @@ func (m *Model) resetOperations() {
 func (m *Model) setBaseOperation(op operations.Operation) tea.Cmd {
 	m.baseOp = op
 	m.layers = nil
+	m.visual = nil
 
 	return op.Init()
 }
@@ -292,6 +328,7 @@ func (m *Model) Scopes() []common.Scope {
 	}
 
 	if m.quickSearch != "" {
+		//jjui:layer scope=revisions.quick_search outer=revisions,ui
 		ret = append(ret, common.Scope{
 			Name:    actions.ScopeQuickSearch,
 			Leak:    leak,
@@ -302,6 +339,13 @@ func (m *Model) Scopes() []common.Scope {
 	var scope bindings.ScopeName = actions.ScopeRevisions
 	if !m.InNormalMode() {
 		scope = ""
+	} else if m.visual != nil {
+		//jjui:layer scope=revisions.visual outer=revisions,ui
+		ret = append(ret, common.Scope{
+			Name:    actions.ScopeVisual,
+			Leak:    leak,
+			Handler: m,
+		})
 	}
 
 	ret = append(ret, common.Scope{
@@ -391,6 +435,7 @@ func (m *Model) toggleCheckedRevision(item appContext.SelectedRevision) {
 
 func (m *Model) clearCheckedRevisions() {
 	clear(m.checkedRevisions)
+	m.visual = nil
 }
 
 func (m *Model) Init() tea.Cmd {
@@ -420,6 +465,8 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			return nil
 		}
 		switch {
+		case msg.Shift:
+			m.selectRange(msg.Index)
 		case msg.Alt:
 			m.rangeSelect(msg.Index)
 		case msg.Ctrl:
@@ -430,18 +477,29 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			}
 		default:
 			m.SetCursor(msg.Index)
+			m.startDrag(msg.Index)
 		}
 		return nil
+	case tea.MouseMotionMsg, tea.MouseReleaseMsg:
+		if m.drag == nil {
+			return nil
+		}
+		return m.updateDrag(msg)
 	case ViewportScrollMsg:
 		if msg.Horizontal {
 			return nil
 		}
 		return m.Scroll(msg.Delta)
+	case revsetSelectionMsg:
+		return m.checkCommitIds(msg)
 
 	case common.CloseViewMsg:
 		if len(m.layers) > 0 {
 			return m.popLayer()
 		}
+		if op, ok := m.baseOperation().(operations.Repeatable); ok && msg.Applied {
+			m.lastApplied = op
+		}
 		m.resetOperations()
 		return nil
 	case common.RestoreOperationMsg:
@@ -452,6 +510,14 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		}
 		m.resetOperations()
 		return nil
+	case choose.SelectedMsg:
+		if m.pendingMove != nil {
+			return m.completeMove(msg.Value)
+		}
+		return m.activeModel().Update(msg)
+	case choose.CancelledMsg:
+		m.pendingMove = nil
+		return m.activeModel().Update(msg)
 	case common.StartAceJumpMsg:
 		cmd, _ := m.HandleIntent(intents.StartAceJump{})
 		return cmd
@@ -487,6 +553,18 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 			KeepSelections:   msg.KeepSelections,
 			SelectedRevision: msg.SelectedRevision,
 		}), m.activeModel().Update(msg))
+	case moveCandidatesMsg:
+		if len(msg.candidates) > 1 {
+			m.pendingMove = &pendingMove{edit: msg.edit}
+			return choose.ShowWithTitle(msg.candidates, msg.title)
+		}
+		return m.context.RunCommand(msg.args, common.RefreshAndSelect("@"))
+	case navigateTargetMsg:
+		if idx := m.selectRevisionExact(msg.revision); idx != -1 {
+			m.SetCursor(idx)
+		}
+		m.ensureCursorView = msg.ensureView
+		return nil
 	case updateRevisionsMsg:
 		if msg.tag != m.tag.Load() {
 			return nil
@@ -685,6 +763,10 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 				return cmd, true
 			}
 		}
+		if m.visual != nil && m.InNormalMode() {
+			m.cancelVisualMode()
+			return nil, true
+		}
 		// Clear checked items and reset to default operation
 		if len(m.checkedRevisions) > 0 || !m.InNormalMode() {
 			m.clearCheckedRevisions()
@@ -726,6 +808,19 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		return m.startSquash(intent), true
 	case intents.OpenInlineDescribe:
 		return m.startInlineDescribe(intent), true
+	case intents.OpenMetaEdit:
+		return m.startMetaEdit(intent), true
+	case intents.Fix:
+		return m.startFix(intent), true
+	case intents.NarrowToSelection:
+		selected := intent.Selected
+		if len(selected.Revisions) == 0 {
+			selected = m.SelectedRevisions()
+		}
+		if len(selected.Revisions) == 0 {
+			return nil, true
+		}
+		return common.UpdateRevSet(revset.SelectionRevset(intents.SelectionRevsetSelected, selected.GetIds())), true
 	case intents.OpenAbsorb:
 		return m.startAbsorb(intent), true
 	case intents.OpenAbandon:
@@ -738,12 +833,20 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		return m.startEdit(intent), true
 	case intents.DiffEdit:
 		return m.startDiffEdit(intent), true
+	case intents.NextRevision:
+		return m.move(false, intent.Count, intent.Edit, intent.Conflict), true
+	case intents.PrevRevision:
+		return m.move(true, intent.Count, intent.Edit, intent.Conflict), true
 	case intents.OpenRevert:
 		return m.startRevert(intent), true
 	case intents.OpenDuplicate:
 		return m.startDuplicate(intent), true
 	case intents.OpenSetParents:
 		return m.startSetParents(intent), true
+	case intents.OpenParallelize:
+		return m.startParallelize(intent), true
+	case intents.OpenSimplifyParents:
+		return m.startSimplifyParents(intent), true
 	case intents.OpenSetBookmark:
 		return m.startBookmarkSet(intent), true
 	case intents.RevisionsToggleSelect:
@@ -755,6 +858,13 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		item := appContext.SelectedRevision{ChangeId: changeId, CommitId: commit.CommitId}
 		m.toggleCheckedRevision(item)
 		return nil, true
+	case intents.RevisionsVisualMode:
+		m.toggleVisualMode()
+		return nil, true
+	case intents.RevisionsSelectRevset:
+		return m.selectRevset(intent.Revset), true
+	case intents.RepeatLastOperation:
+		return m.repeatLastOperation(), true
 	case intents.Navigate:
 		return m.navigate(intent), true
 	case intents.GoToTop:
@@ -843,6 +953,22 @@ func (m *Model) startSquash(intent intents.OpenSquash) tea.Cmd {
 	return m.setBaseOperation(squash.NewOperation(m.context, selected, m.SelectedRevision(), squash.WithFiles(intent.Files)))
 }
 
+// repeatLastOperation applies the last applied rebase, squash, duplicate or
+// revert again, to the current selection and with the same target.
+func (m *Model) repeatLastOperation() tea.Cmd {
+	if !m.InNormalMode() {
+		return nil
+	}
+	if m.lastApplied == nil {
+		return intents.Invoke(intents.AddMessage{Text: "Nothing to repeat"})
+	}
+	selected := m.SelectedRevisions()
+	if len(selected.Revisions) == 0 {
+		return nil
+	}
+	return m.lastApplied.Repeat(selected)
+}
+
 func (m *Model) startRebase(intent intents.OpenRebase) tea.Cmd {
 	selected := intent.Selected
 	if len(selected.Revisions) == 0 {
@@ -903,6 +1029,28 @@ func (m *Model) startSetParents(intent intents.OpenSetParents) tea.Cmd {
 	return m.setBaseOperation(set_parents.NewModel(m.context, commit, m.SelectedRevision()))
 }
 
+func (m *Model) startParallelize(intent intents.OpenParallelize) tea.Cmd {
+	selected := intent.Selected
+	if len(selected.Revisions) == 0 {
+		selected = m.SelectedRevisions()
+	}
+	if len(selected.Revisions) == 0 {
+		return nil
+	}
+	return m.setBaseOperation(parallelize.NewOperation(m.context, selected, m.SelectedRevision()))
+}
+
+func (m *Model) startSimplifyParents(intent intents.OpenSimplifyParents) tea.Cmd {
+	selected := intent.Selected
+	if len(selected.Revisions) == 0 {
+		selected = m.SelectedRevisions()
+	}
+	if len(selected.Revisions) == 0 {
+		return nil
+	}
+	return m.setBaseOperation(simplify_parents.NewOperation(m.context, selected, m.SelectedRevision()))
+}
+
 func (m *Model) startNew(intent intents.StartNew) tea.Cmd {
 	selected := intent.Selected
 	if len(selected.Revisions) == 0 {
@@ -926,6 +1074,59 @@ func (m *Model) startEdit(intent intents.StartEdit) tea.Cmd {
 	return m.context.RunCommand(jj.Edit(commit.GetChangeId(), intent.IgnoreImmutable), common.Refresh)
 }
 
+// move runs jj next/prev. jj cannot prompt when the target is ambiguous, so
+// the candidates are resolved up front in the returned command and offered in
+// a chooser instead.
+func (m *Model) move(backward bool, count int, edit bool, conflict bool) tea.Cmd {
+	count = max(count, 1)
+	msg := moveCandidatesMsg{args: jj.Next(count, edit, conflict), title: "Choose the next revision", edit: edit}
+	if backward {
+		msg.args = jj.Prev(count, edit, conflict)
+		msg.title = "Choose the previous revision"
+	}
+	runner := m.context
+	revset := movementRevset(backward, count, edit, conflict)
+	return func() tea.Msg {
+		if output, err := runner.RunCommandImmediate(jj.GetRevisionLabels(revset)); err == nil {
+			msg.candidates = strings.Split(strings.TrimSpace(string(output)), "\n")
+		}
+		return msg
+	}
+}
+
+func (m *Model) completeMove(choice string) tea.Cmd {
+	move := m.pendingMove
+	m.pendingMove = nil
+	changeId, _, _ := strings.Cut(choice, " ")
+	if changeId == "" {
+		return nil
+	}
+	if move.edit {
+		return m.context.RunCommand(jj.Edit(changeId, false), common.RefreshAndSelect("@"))
+	}
+	target := jj.NewSelectedRevisions(&jj.Commit{ChangeId: changeId})
+	return m.context.RunCommand(jj.New(target), common.RefreshAndSelect("@"))
+}
+
+// movementRevset mirrors how jj next/prev pick their targets: starting from
+// the working copy when editing, or from its parents otherwise.
+func movementRevset(backward bool, count int, edit bool, conflict bool) string {
+	start := "@-"
+	if edit {
+		start = "@"
+	}
+	switch {
+	case conflict && backward:
+		return fmt.Sprintf("heads(conflicts() & ::parents(%s))", start)
+	case conflict:
+		return fmt.Sprintf("roots(conflicts() & children(%s)::) ~ @", start)
+	case backward:
+		return fmt.Sprintf("(%s)%s", start, strings.Repeat("-", count))
+	default:
+		return fmt.Sprintf("(%s)%s ~ @", start, strings.Repeat("+", count))
+	}
+}
+
 func (m *Model) startDiffEdit(intent intents.DiffEdit) tea.Cmd {
 	commit := intent.Selected
 	if commit == nil {
@@ -1004,11 +1205,10 @@ func (m *Model) navigate(intent intents.Navigate) tea.Cmd {
 		return nil
 	}
 
+	count := max(intent.Count, 1)
 	switch intent.Target {
 	case intents.TargetParent:
-		m.jumpToParent(m.SelectedRevisions())
-		m.ensureCursorView = ensureView
-		return nil
+		return m.navigateTo(jj.GetAncestor(m.SelectedRevisions(), count), ensureView)
 	case intents.TargetWorkingCopy:
 		if idx := m.selectRevisionExact("@"); idx != -1 {
 			m.SetCursor(idx)
@@ -1016,12 +1216,7 @@ func (m *Model) navigate(intent intents.Navigate) tea.Cmd {
 		m.ensureCursorView = ensureView
 		return nil
 	case intents.TargetChild:
-		immediate, _ := m.context.RunCommandImmediate(jj.GetFirstChild(m.SelectedRevision()))
-		if idx := m.selectRevisionExact(string(immediate)); idx != -1 {
-			m.SetCursor(idx)
-		}
-		m.ensureCursorView = ensureView
-		return nil
+		return m.navigateTo(jj.GetDescendant(m.SelectedRevision(), count), ensureView)
 	}
 
 	delta := intent.Delta
@@ -1035,11 +1230,7 @@ func (m *Model) navigate(intent intents.Navigate) tea.Cmd {
 		firstRowIndex := m.displayContextRenderer.GetFirstRowIndex()
 		lastRowIndex := m.displayContextRenderer.GetLastRowIndex()
 		span := max(lastRowIndex-firstRowIndex-1, 1)
-		if step < 0 {
-			step = -span
-		} else {
-			step = span
-		}
+		step = span * step
 	}
 
 	// Calculate new cursor position
@@ -1066,6 +1257,19 @@ func (m *Model) navigate(intent intents.Navigate) tea.Cmd {
 	return nil
 }
 
+// navigateTo resolves the revision to jump to with args and moves the cursor
+// to it once the command returns.
+func (m *Model) navigateTo(args jj.CommandArgs, ensureView bool) tea.Cmd {
+	runner := m.context
+	return func() tea.Msg {
+		output, err := runner.RunCommandImmediate(args)
+		if err != nil {
+			return nil
+		}
+		return navigateTargetMsg{revision: strings.TrimSpace(string(output)), ensureView: ensureView}
+	}
+}
+
 func (m *Model) resolveNavigationRevision(revision string) int {
 	output, err := m.context.RunCommandImmediate(jj.ResolveRevisionID(revision))
 	if err != nil {
@@ -1115,6 +1319,29 @@ func (m *Model) startInlineDescribe(intent intents.OpenInlineDescribe) tea.Cmd {
 	return m.setBaseOperation(describe.NewOperation(m.context, commit))
 }
 
+func (m *Model) startMetaEdit(intent intents.OpenMetaEdit) tea.Cmd {
+	selected := intent.Selected
+	if len(selected.Revisions) == 0 {
+		selected = m.SelectedRevisions()
+	}
+	commit := m.SelectedRevision()
+	if len(selected.Revisions) == 0 || commit == nil {
+		return nil
+	}
+	return m.setBaseOperation(metaedit.NewOperation(m.context, selected, commit))
+}
+
+func (m *Model) startFix(intent intents.Fix) tea.Cmd {
+	selected := intent.Selected
+	if len(selected.Revisions) == 0 {
+		selected = m.SelectedRevisions()
+	}
+	if len(selected.Revisions) == 0 {
+		return nil
+	}
+	return fix.Run(m.context, selected, intent.IgnoreImmutable, common.Refresh)
+}
+
 func (m *Model) showDiff(intent intents.ShowDiff) tea.Cmd {
 	commit := intent.Selected
 	if commit == nil {
@@ -1258,6 +1485,7 @@ func (m *Model) ViewRect(dl *render.DisplayContext, box layout.Box) {
 	for _, layer := range m.layers {
 		layer.ViewRect(dl, box)
 	}
+	m.renderDragGhost(dl, box)
 
 	// Reset the flag after ensuring cursor is visible
 	m.ensureCursorView = false
@@ -1393,11 +1621,3 @@ func (m *Model) rangeSelect(to int) {
 	}
 	m.SetCursor(to)
 }
-
-func (m *Model) jumpToParent(revisions jj.SelectedRevisions) {
-	immediate, _ := m.context.RunCommandImmediate(jj.GetParent(revisions))
-	parentIndex := m.selectRevisionExact(string(immediate))
-	if parentIndex != -1 {
-		m.SetCursor(parentIndex)
-	}
-}
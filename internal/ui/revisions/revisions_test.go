@@ -1,19 +1,23 @@
 package revisions
 
 import (
+	"slices"
 	"testing"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/parser"
 	"github.com/idursun/jjui/internal/screen"
+	"github.com/idursun/jjui/internal/ui/choose"
 	"github.com/idursun/jjui/internal/ui/common"
 	"github.com/idursun/jjui/internal/ui/intents"
 	"github.com/idursun/jjui/internal/ui/layout"
 	"github.com/idursun/jjui/internal/ui/operations"
+	"github.com/idursun/jjui/internal/ui/operations/rebase"
 	"github.com/idursun/jjui/internal/ui/render"
 	"github.com/idursun/jjui/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestModel_highlightChanges(t *testing.T) {
@@ -494,3 +498,295 @@ func TestModel_TargetPickerCancelClosesEditing(t *testing.T) {
 	test.SimulateModel(model, model.Update(intents.TargetPickerCancel{}))
 	assert.False(t, model.IsEditing(), "target picker cancel should exit editing mode")
 }
+
+type msgRecorder struct {
+	msgs []tea.Msg
+}
+
+func (r *msgRecorder) Update(msg tea.Msg) tea.Cmd {
+	r.msgs = append(r.msgs, msg)
+	return nil
+}
+
+func TestModel_NextRunsJJNextAndSelectsWorkingCopy(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetRevisionLabels("(@-)+ ~ @")).SetOutput([]byte("b second\n"))
+	commandRunner.Expect(jj.Next(1, false, false))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.updateGraphRows(rows, "a", true)
+
+	cmd := model.Update(intents.NextRevision{})
+	recorder := &msgRecorder{}
+	test.SimulateModel(recorder, model.Update(cmd()))
+	assert.Contains(t, recorder.msgs, common.RefreshMsg{SelectedRevision: "@"})
+}
+
+func TestModel_FixHighlightsRewrittenRevisions(t *testing.T) {
+	selected := jj.NewSelectedRevisions(&jj.Commit{ChangeId: "b", CommitId: "9"})
+	commandRunner := test.NewTestCommandRunner(t)
+	commitIds := commandRunner.Expect(jj.GetCommitIds(jj.FixRevset(selected, ""))).SetOutput([]byte("b 9\na 8"))
+	commandRunner.Expect(jj.Fix(selected, false))
+	commandRunner.Expect(jj.DiffSummary("9", "10")).SetOutput([]byte("M file.go"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.updateGraphRows(rows, "b", true)
+
+	cmd := model.Update(intents.Fix{})
+	recorder := &msgRecorder{}
+	test.SimulateModel(recorder, cmd, func(msg tea.Msg) {
+		// the refresh runs between jj fix and the report
+		if _, ok := msg.(common.RefreshMsg); ok {
+			commitIds.SetOutput([]byte("b 10\na 8"))
+		}
+	})
+
+	for _, msg := range recorder.msgs {
+		if completed, ok := msg.(common.CommandCompletedMsg); ok {
+			model.Update(completed)
+		}
+	}
+	_ = model.highlightChanges()
+	assert.False(t, model.rows[0].IsAffected)
+	assert.True(t, model.rows[1].IsAffected)
+	assert.Contains(t, recorder.msgs, common.RefreshMsg{})
+}
+
+func TestModel_NarrowToSelectionSetsRevset(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.updateGraphRows(rows, "a", true)
+
+	cmd := model.Update(intents.NarrowToSelection{})
+	require.NotNil(t, cmd)
+	assert.Equal(t, common.UpdateRevSetMsg("a"), cmd())
+
+	cmd = model.Update(intents.NarrowToSelection{Selected: jj.NewSelectedRevisions(&jj.Commit{ChangeId: "a"}, &jj.Commit{ChangeId: "b"})})
+	assert.Equal(t, common.UpdateRevSetMsg("(a|b)"), cmd())
+}
+
+func TestModel_PrevPassesCount(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetRevisionLabels("(@)--")).SetOutput([]byte("b second\n"))
+	commandRunner.Expect(jj.Prev(2, true, false))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.updateGraphRows(rows, "a", true)
+
+	cmd := model.Update(intents.PrevRevision{Count: 2, Edit: true})
+	test.SimulateModel(&msgRecorder{}, model.Update(cmd()))
+}
+
+func TestModel_NavigateResolvesCountWithOneCommand(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetAncestor(jj.NewSelectedRevisions(rows[0].Commit), 3)).SetOutput([]byte("9\n"))
+	commandRunner.Expect(jj.GetDescendant(rows[1].Commit, 5)).SetOutput([]byte("8\n"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.updateGraphRows(rows, "a", true)
+
+	test.SimulateModel(model, model.Update(intents.Navigate{Target: intents.TargetParent, Count: 3}))
+	assert.Equal(t, 1, model.Cursor())
+	test.SimulateModel(model, model.Update(intents.Navigate{Target: intents.TargetChild, Count: 5}))
+	assert.Equal(t, 0, model.Cursor())
+}
+
+func TestModel_NextShowsChooserWhenAmbiguous(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetRevisionLabels("(@)+ ~ @")).SetOutput([]byte("a first\nb second\n"))
+	commandRunner.Expect(jj.Edit("b", false))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.updateGraphRows(rows, "a", true)
+
+	cmd := model.Update(intents.NextRevision{Edit: true})
+	require.NotNil(t, cmd)
+	cmd = model.Update(cmd())
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(common.ShowChooseMsg)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a first", "b second"}, msg.Options)
+
+	recorder := &msgRecorder{}
+	test.SimulateModel(recorder, model.Update(choose.SelectedMsg{Value: "b second"}))
+	assert.Contains(t, recorder.msgs, common.RefreshMsg{SelectedRevision: "@"})
+	assert.Nil(t, model.pendingMove)
+}
+
+func TestModel_NextChooserCancelClearsPendingMove(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetRevisionLabels("(@-)+ ~ @")).SetOutput([]byte("a first\nb second\n"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.updateGraphRows(rows, "a", true)
+
+	_ = model.Update(model.Update(intents.NextRevision{})())
+	assert.NotNil(t, model.pendingMove)
+	_ = model.Update(choose.CancelledMsg{})
+	assert.Nil(t, model.pendingMove)
+}
+
+func TestModel_DragAndDropStartsRebase(t *testing.T) {
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	model := New(ctx)
+	model.updateGraphRows(rows, "a", true)
+	model.ViewRect(render.NewDisplayContext(), layout.NewBox(layout.Rect(0, 0, 80, 10)))
+
+	model.Update(ItemClickedMsg{Index: 0})
+	require.True(t, model.IsDragging())
+	model.Update(tea.MouseMotionMsg{X: 2, Y: 1, Button: tea.MouseLeft, Mod: tea.ModAlt})
+	model.Update(tea.MouseReleaseMsg{X: 2, Y: 1, Button: tea.MouseLeft, Mod: tea.ModAlt})
+
+	assert.False(t, model.IsDragging())
+	op, ok := model.baseOperation().(*rebase.Operation)
+	require.True(t, ok, "dropping on another revision should start a rebase")
+	assert.Equal(t, rebase.SourceDescendants, op.Source)
+	assert.Equal(t, intents.ModeTargetDestination, op.Target)
+	assert.Equal(t, "a", op.From.Last())
+	assert.Equal(t, "b", op.To.GetChangeId())
+}
+
+func TestModel_DropOnDraggedRevisionDoesNothing(t *testing.T) {
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	model := New(ctx)
+	model.updateGraphRows(rows, "a", true)
+	model.ViewRect(render.NewDisplayContext(), layout.NewBox(layout.Rect(0, 0, 80, 10)))
+
+	model.Update(ItemClickedMsg{Index: 0})
+	cmd := model.Update(tea.MouseReleaseMsg{X: 2, Y: 0, Button: tea.MouseLeft})
+
+	assert.Nil(t, cmd)
+	assert.False(t, model.IsDragging())
+	assert.IsType(t, &operations.Default{}, model.baseOperation())
+}
+
+func TestDropMode(t *testing.T) {
+	assert.Equal(t, intents.ModeTargetDestination, dropMode(0, 1))
+	assert.Equal(t, intents.ModeTargetDestination, dropMode(0, 2))
+	assert.Equal(t, intents.ModeTargetBefore, dropMode(1, 2))
+	assert.Equal(t, intents.ModeTargetAfter, dropMode(0, 3))
+	assert.Equal(t, intents.ModeTargetDestination, dropMode(1, 3))
+	assert.Equal(t, intents.ModeTargetBefore, dropMode(2, 3))
+}
+
+func TestModel_VisualModeChecksRangeBetweenAnchorAndCursor(t *testing.T) {
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	model := New(ctx)
+	threeRows := append(append([]parser.Row{}, rows...), parser.Row{Commit: &jj.Commit{ChangeId: "c", CommitId: "7"}})
+	model.updateGraphRows(threeRows, "a", true)
+
+	model.Update(intents.RevisionsVisualMode{})
+	require.True(t, model.InVisualMode())
+	model.SetCursor(2)
+	assert.Len(t, model.checkedRevisions, 3)
+
+	model.SetCursor(1)
+	assert.Len(t, model.checkedRevisions, 2, "moving back should shrink the range")
+	assert.NotContains(t, model.checkedRevisions, "7")
+
+	model.Update(intents.Cancel{})
+	assert.False(t, model.InVisualMode())
+	assert.Empty(t, model.checkedRevisions, "cancel should drop the range")
+}
+
+func TestModel_VisualModeKeepsRangeWhenToggledOff(t *testing.T) {
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	model := New(ctx)
+	model.updateGraphRows(rows, "a", true)
+
+	model.Update(intents.RevisionsVisualMode{})
+	model.SetCursor(1)
+	model.Update(intents.RevisionsVisualMode{})
+
+	assert.False(t, model.InVisualMode())
+	assert.Len(t, model.checkedRevisions, 2)
+}
+
+func TestModel_ShiftClickChecksRange(t *testing.T) {
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	model := New(ctx)
+	model.updateGraphRows(rows, "a", true)
+
+	model.Update(ItemClickedMsg{Index: 1, Shift: true})
+
+	assert.Equal(t, 1, model.Cursor())
+	assert.Len(t, model.checkedRevisions, 2)
+}
+
+func TestModel_SelectRevsetChecksMatchingRevisions(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.GetShortestCommitIds("b | x")).SetOutput([]byte("9\n95\n"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.updateGraphRows(rows, "a", true)
+
+	var messages []intents.AddMessage
+	test.SimulateModel(model, model.selectRevset("b | x"), func(msg tea.Msg) {
+		if message, ok := msg.(intents.AddMessage); ok {
+			messages = append(messages, message)
+		}
+	})
+
+	assert.Contains(t, model.checkedRevisions, "9")
+	assert.Len(t, model.checkedRevisions, 1)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Selected 1 revisions matching b | x (1 not in view)", messages[0].Text)
+}
+
+func TestModel_RepeatAppliesLastRebaseToNewSelection(t *testing.T) {
+	threeRows := append(slices.Clone(rows), parser.Row{
+		Commit: &jj.Commit{ChangeId: "c", CommitId: "10"},
+		Lines: []*parser.GraphRowLine{
+			{
+				Gutter:   parser.GraphGutter{Segments: []*screen.Segment{{Text: "|"}}},
+				Segments: []*screen.Segment{{Text: "c"}},
+				Flags:    parser.Revision,
+			},
+		},
+	})
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.Rebase(jj.NewSelectedRevisions(threeRows[0].Commit), "--revisions", "b", "--onto", false, false))
+	commandRunner.Expect(jj.Rebase(jj.NewSelectedRevisions(threeRows[2].Commit), "--revisions", "b", "--onto", false, false))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.updateGraphRows(threeRows, "b", true)
+
+	model.Update(intents.OpenRebase{Selected: jj.NewSelectedRevisions(threeRows[0].Commit)})
+	recorder := &msgRecorder{}
+	test.SimulateModel(recorder, model.Update(intents.Apply{}))
+	require.Contains(t, recorder.msgs, common.CloseViewMsg{Applied: true})
+	model.Update(common.CloseViewMsg{Applied: true})
+	require.True(t, model.InNormalMode())
+
+	model.SetCursor(2)
+	test.SimulateModel(recorder, model.Update(intents.RepeatLastOperation{}))
+}
+
+func TestModel_RepeatWithoutAppliedOperation(t *testing.T) {
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	model := New(ctx)
+	model.updateGraphRows(rows, "a", true)
+
+	recorder := &msgRecorder{}
+	test.SimulateModel(recorder, model.Update(intents.RepeatLastOperation{}))
+	assert.Contains(t, recorder.msgs, intents.AddMessage{Text: "Nothing to repeat"})
+}
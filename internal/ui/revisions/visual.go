@@ -0,0 +1,139 @@
+package revisions
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	appContext "github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/intents"
+)
+
+// visualMode checks every revision between the anchor and the cursor. The
+// revisions checked before it started are kept in base so that the range can
+// shrink again, or be dropped altogether on cancel.
+type visualMode struct {
+	anchor string
+	base   map[string]appContext.SelectedRevision
+}
+
+type revsetSelectionMsg struct {
+	revset    string
+	commitIds []string
+	err       error
+}
+
+func (m *Model) InVisualMode() bool {
+	return m.visual != nil
+}
+
+func (m *Model) toggleVisualMode() {
+	if m.visual != nil {
+		m.visual = nil
+		return
+	}
+	commit := m.SelectedRevision()
+	if commit == nil {
+		return
+	}
+	m.visual = &visualMode{anchor: commit.CommitId, base: maps.Clone(m.checkedRevisions)}
+	m.extendVisual()
+}
+
+// cancelVisualMode drops the range, restoring the revisions checked before
+// visual mode started.
+func (m *Model) cancelVisualMode() {
+	m.checkedRevisions = m.visual.base
+	if m.checkedRevisions == nil {
+		m.checkedRevisions = make(map[string]appContext.SelectedRevision)
+	}
+	m.visual = nil
+}
+
+func (m *Model) extendVisual() {
+	anchor := -1
+	for i, row := range m.rows {
+		if row.Commit != nil && row.Commit.CommitId == m.visual.anchor {
+			anchor = i
+			break
+		}
+	}
+	if anchor == -1 {
+		// the anchor is gone after a refresh
+		m.visual = nil
+		return
+	}
+	m.checkedRevisions = make(map[string]appContext.SelectedRevision, len(m.visual.base))
+	maps.Copy(m.checkedRevisions, m.visual.base)
+	m.checkRange(anchor, m.cursor)
+}
+
+func (m *Model) checkRange(from, to int) {
+	for i := min(from, to); i <= max(from, to); i++ {
+		if i < 0 || i >= len(m.rows) {
+			continue
+		}
+		if commit := m.rows[i].Commit; commit != nil {
+			m.checkedRevisions[commit.CommitId] = appContext.SelectedRevision{ChangeId: commit.GetChangeId(), CommitId: commit.CommitId}
+		}
+	}
+}
+
+// selectRange checks every revision between the cursor and index, unlike
+// rangeSelect which toggles them.
+func (m *Model) selectRange(index int) {
+	if m.visual == nil {
+		m.checkRange(m.cursor, index)
+	}
+	m.SetCursor(index)
+}
+
+func (m *Model) selectRevset(revset string) tea.Cmd {
+	revset = strings.TrimSpace(revset)
+	if revset == "" {
+		return func() tea.Msg { return common.PromptSelectRevsetMsg{} }
+	}
+	runner := m.context.CommandRunner
+	return func() tea.Msg {
+		output, err := runner.RunCommandImmediate(jj.GetShortestCommitIds(revset))
+		if err != nil {
+			if message := strings.TrimSpace(string(output)); message != "" {
+				err = errors.New(message)
+			}
+			return revsetSelectionMsg{revset: revset, err: err}
+		}
+		var commitIds []string
+		for line := range strings.Lines(string(output)) {
+			if commitId := strings.TrimSpace(line); commitId != "" {
+				commitIds = append(commitIds, commitId)
+			}
+		}
+		return revsetSelectionMsg{revset: revset, commitIds: commitIds}
+	}
+}
+
+// checkCommitIds checks the loaded revisions whose commit id is one of the
+// given ids.
+func (m *Model) checkCommitIds(msg revsetSelectionMsg) tea.Cmd {
+	if msg.err != nil {
+		return intents.Invoke(intents.AddMessage{Text: msg.err.Error(), Err: msg.err})
+	}
+	count := 0
+	for _, row := range m.rows {
+		if row.Commit == nil || !slices.Contains(msg.commitIds, row.Commit.CommitId) {
+			continue
+		}
+		m.checkedRevisions[row.Commit.CommitId] = appContext.SelectedRevision{ChangeId: row.Commit.GetChangeId(), CommitId: row.Commit.CommitId}
+		count++
+	}
+	text := fmt.Sprintf("Selected %d revisions matching %s", count, msg.revset)
+	if hidden := len(msg.commitIds) - count; hidden > 0 {
+		text += fmt.Sprintf(" (%d not in view)", hidden)
+	}
+	return intents.Invoke(intents.AddMessage{Text: text})
+}
@@ -145,6 +145,27 @@ func (p *CompletionProvider) GetSignatureHelp(input string) string {
 	return ""
 }
 
+// IsFunction reports whether name is a built-in revset function or an alias
+// that can be called.
+func (p *CompletionProvider) IsFunction(name string) bool {
+	if _, ok := p.functions[name]; ok {
+		return true
+	}
+	p.ensureStaticLoaded()
+	for _, item := range p.items {
+		if item.Kind == KindAlias && item.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse tokenizes input and validates function names against the known
+// functions and aliases.
+func (p *CompletionProvider) Parse(input string) ParseResult {
+	return Parse(input, p.IsFunction)
+}
+
 func (p *CompletionProvider) GetLastToken(input string) (int, string) {
 	return lastTokenInfo(input)
 }
@@ -294,10 +315,6 @@ func namedArgument(fn source.FunctionDefinition, name string) (source.FunctionAr
 	return source.FunctionArgument{}, false
 }
 
-func isValidFunctionNameChar(r rune) bool {
-	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
-}
-
 func lastTokenInfo(input string) (int, string) {
 	lastIndex := strings.LastIndexFunc(input, func(r rune) bool {
 		return unicode.IsSpace(r) || r == ',' || r == '|' || r == '&' || r == '~' || r == '(' || r == '.' || r == ':'
@@ -316,48 +333,31 @@ func lastTokenInfo(input string) (int, string) {
 
 func analyzeCompletionContext(input string) (CompletionContext, bool) {
 	frames := make([]functionFrame, 0)
-	var quote rune
-	escaped := false
 
-	for i, r := range input {
-		if quote != 0 {
-			if escaped {
-				escaped = false
-				continue
+	tokens := Tokenize(input)
+	for i, token := range tokens {
+		switch token.Kind {
+		case TokenOpenParen:
+			name := ""
+			if i > 0 && tokens[i-1].Kind == TokenFunction {
+				name = tokens[i-1].Text
 			}
-			if r == '\\' {
-				escaped = true
-				continue
-			}
-			if r == quote {
-				quote = 0
-			}
-			continue
-		}
-
-		if r == '\'' || r == '"' {
-			quote = r
-			continue
-		}
-
-		switch r {
-		case '(':
 			frames = append(frames, functionFrame{
-				name:      functionNameBefore(input, i),
+				name:      name,
 				argIndex:  0,
-				argStart:  i + len(string(r)),
+				argStart:  token.End,
 				usedNamed: make(map[string]bool),
 			})
-		case ')':
+		case TokenCloseParen:
 			if len(frames) > 0 {
 				frames = frames[:len(frames)-1]
 			}
-		case ',':
+		case TokenComma:
 			if len(frames) > 0 {
 				top := &frames[len(frames)-1]
-				recordNamedArgument(input[top.argStart:i], top.usedNamed)
+				recordNamedArgument(input[top.argStart:token.Start], top.usedNamed)
 				top.argIndex++
-				top.argStart = i + len(string(r))
+				top.argStart = token.End
 			}
 		}
 	}
@@ -411,19 +411,6 @@ func recordNamedArgument(segment string, used map[string]bool) {
 	}
 }
 
-func functionNameBefore(input string, openParen int) string {
-	end := openParen
-	start := end
-	for start > 0 {
-		r := rune(input[start-1])
-		if !isValidFunctionNameChar(r) {
-			break
-		}
-		start--
-	}
-	return input[start:end]
-}
-
 func leadingSpaceLen(input string) int {
 	for i, r := range input {
 		if !unicode.IsSpace(r) {
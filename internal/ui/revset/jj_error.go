@@ -0,0 +1,85 @@
+package revset
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+var (
+	errorLocationPattern = regexp.MustCompile(`-->\s*(\d+):(\d+)`)
+	errorCaretPattern    = regexp.MustCompile(`^\s*\|\s?(\s*)(\^[-^]*)`)
+)
+
+// revsetError is a jj revset error pinned beneath the input. start and end
+// are byte offsets of the offending part of the revset, or -1 when jj didn't
+// report a position.
+type revsetError struct {
+	message string
+	start   int
+	end     int
+}
+
+// parseRevsetError extracts the message and the offending range from jj's
+// error output for input.
+func parseRevsetError(output string, input string) revsetError {
+	result := revsetError{start: -1, end: -1}
+	lines := strings.Split(strings.TrimSpace(ansi.Strip(output)), "\n")
+
+	var detail string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case result.message == "" && trimmed != "":
+			result.message = strings.TrimPrefix(trimmed, "Error: ")
+		case strings.HasPrefix(trimmed, "= "):
+			detail = strings.TrimPrefix(trimmed, "= ")
+		}
+	}
+	if detail != "" && !strings.Contains(result.message, detail) {
+		result.message += ": " + detail
+	}
+
+	column := -1
+	for i, line := range lines {
+		if match := errorLocationPattern.FindStringSubmatch(line); match != nil {
+			if row, _ := strconv.Atoi(match[1]); row != 1 {
+				break
+			}
+			column, _ = strconv.Atoi(match[2])
+			width := 1
+			for _, next := range lines[i+1:] {
+				if caret := errorCaretPattern.FindStringSubmatch(next); caret != nil {
+					width = len(caret[2])
+					break
+				}
+			}
+			result.start, result.end = runeRange(input, column-1, width)
+			break
+		}
+	}
+	return result
+}
+
+// runeRange converts a character column and width into byte offsets of input.
+func runeRange(input string, column int, width int) (int, int) {
+	start, end := -1, len(input)
+	index := 0
+	for offset := range input {
+		if index == column {
+			start = offset
+		}
+		if index == column+width {
+			end = offset
+			break
+		}
+		index++
+	}
+	if start == -1 {
+		// jj points just past the end of the input for incomplete revsets
+		return len(input), len(input)
+	}
+	return start, end
+}
@@ -0,0 +1,226 @@
+package revset
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type TokenKind int
+
+const (
+	TokenWhitespace TokenKind = iota
+	TokenSymbol
+	TokenFunction
+	TokenArgumentName
+	TokenPatternKind
+	TokenString
+	TokenOperator
+	TokenOpenParen
+	TokenCloseParen
+	TokenComma
+	TokenInvalid
+)
+
+// Token is a lexical element of a revset. Start and End are byte offsets
+// into the parsed input.
+type Token struct {
+	Kind  TokenKind
+	Start int
+	End   int
+	Text  string
+}
+
+// Diagnostic points at a range of the input that jj is going to reject.
+type Diagnostic struct {
+	Start   int
+	End     int
+	Message string
+}
+
+// ParseResult holds the tokens of a revset along with the problems found
+// while parsing it.
+type ParseResult struct {
+	Tokens      []Token
+	Diagnostics []Diagnostic
+}
+
+// DiagnosticAt returns the first diagnostic covering the byte offset.
+func (r ParseResult) DiagnosticAt(offset int) (Diagnostic, bool) {
+	for _, d := range r.Diagnostics {
+		if offset >= d.Start && offset < d.End {
+			return d, true
+		}
+	}
+	return Diagnostic{}, false
+}
+
+// operators are matched longest first
+var operators = []string{"::", "..", ":", "-", "+", "~", "&", "|", "=", "!"}
+
+// Tokenize splits input into tokens without validating it. It never fails;
+// characters that can't start a token become TokenInvalid.
+func Tokenize(input string) []Token {
+	var tokens []Token
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		start := i
+		kind := TokenInvalid
+		switch {
+		case unicode.IsSpace(r):
+			kind = TokenWhitespace
+			i = scanWhile(input, i, unicode.IsSpace)
+		case r == '"' || r == '\'':
+			kind = TokenString
+			i = scanString(input, i)
+		case r == '(':
+			kind = TokenOpenParen
+			i += size
+		case r == ')':
+			kind = TokenCloseParen
+			i += size
+		case r == ',':
+			kind = TokenComma
+			i += size
+		case isIdentifierPart(r):
+			kind = TokenSymbol
+			i = scanIdentifier(input, i)
+		default:
+			i += size
+			for _, op := range operators {
+				if strings.HasPrefix(input[start:], op) {
+					kind = TokenOperator
+					i = start + len(op)
+					break
+				}
+			}
+		}
+		tokens = append(tokens, Token{Kind: kind, Start: start, End: i, Text: input[start:i]})
+	}
+	classifySymbols(tokens)
+	return tokens
+}
+
+// classifySymbols refines symbols by looking at the token that follows them.
+func classifySymbols(tokens []Token) {
+	depth := 0
+	for i := range tokens {
+		switch tokens[i].Kind {
+		case TokenOpenParen:
+			depth++
+		case TokenCloseParen:
+			depth = max(depth-1, 0)
+		case TokenSymbol:
+			if i+1 >= len(tokens) {
+				continue
+			}
+			next := tokens[i+1]
+			switch {
+			case next.Kind == TokenOpenParen:
+				tokens[i].Kind = TokenFunction
+			case next.Kind == TokenOperator && next.Text == "=" && depth > 0:
+				tokens[i].Kind = TokenArgumentName
+			case next.Kind == TokenOperator && next.Text == ":" && i+2 < len(tokens) &&
+				(tokens[i+2].Kind == TokenString || tokens[i+2].Kind == TokenSymbol):
+				tokens[i].Kind = TokenPatternKind
+			}
+		}
+	}
+}
+
+// Parse tokenizes input and reports unbalanced parentheses, unterminated
+// strings and calls to functions for which isFunction returns false.
+func Parse(input string, isFunction func(name string) bool) ParseResult {
+	tokens := Tokenize(input)
+	var diagnostics []Diagnostic
+	var open []Token
+	for _, token := range tokens {
+		switch token.Kind {
+		case TokenOpenParen:
+			open = append(open, token)
+		case TokenCloseParen:
+			if len(open) == 0 {
+				diagnostics = append(diagnostics, Diagnostic{Start: token.Start, End: token.End, Message: "unmatched ')'"})
+				continue
+			}
+			open = open[:len(open)-1]
+		case TokenFunction:
+			if isFunction != nil && !isFunction(token.Text) {
+				diagnostics = append(diagnostics, Diagnostic{Start: token.Start, End: token.End, Message: fmt.Sprintf("unknown function or alias `%s`", token.Text)})
+			}
+		case TokenString:
+			if !isTerminatedString(token.Text) {
+				diagnostics = append(diagnostics, Diagnostic{Start: token.Start, End: token.End, Message: "unterminated string"})
+			}
+		case TokenInvalid:
+			diagnostics = append(diagnostics, Diagnostic{Start: token.Start, End: token.End, Message: fmt.Sprintf("unexpected %q", token.Text)})
+		}
+	}
+	for _, token := range open {
+		diagnostics = append(diagnostics, Diagnostic{Start: token.Start, End: token.End, Message: "unclosed '('"})
+	}
+	return ParseResult{Tokens: tokens, Diagnostics: diagnostics}
+}
+
+func scanWhile(input string, i int, predicate func(rune) bool) int {
+	for i < len(input) {
+		r, size := utf8.DecodeRuneInString(input[i:])
+		if !predicate(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// scanIdentifier follows jj's identifier rule: parts joined by '.', '-' or
+// '+' as long as another part follows, so that `foo-bar` is a symbol while
+// `foo-` is the parent of foo.
+func scanIdentifier(input string, i int) int {
+	i = scanWhile(input, i, isIdentifierPart)
+	for i+1 < len(input) && strings.IndexByte(".-+", input[i]) >= 0 {
+		r, _ := utf8.DecodeRuneInString(input[i+1:])
+		if !isIdentifierPart(r) {
+			break
+		}
+		i = scanWhile(input, i+1, isIdentifierPart)
+	}
+	return i
+}
+
+func scanString(input string, i int) int {
+	quote := input[i]
+	i++
+	for i < len(input) {
+		switch input[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i + 1
+		}
+		i++
+	}
+	return min(i, len(input))
+}
+
+func isTerminatedString(text string) bool {
+	if len(text) < 2 || text[len(text)-1] != text[0] {
+		return false
+	}
+	if text[0] == '\'' {
+		return true
+	}
+	// a trailing quote preceded by an odd number of backslashes is escaped
+	backslashes := 0
+	for i := len(text) - 2; i > 0 && text[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 0
+}
+
+func isIdentifierPart(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '/' || r == '@' || r > unicode.MaxASCII
+}
@@ -0,0 +1,94 @@
+package revset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func tokenKinds(tokens []Token) map[string]TokenKind {
+	kinds := make(map[string]TokenKind)
+	for _, token := range tokens {
+		if token.Kind != TokenWhitespace {
+			kinds[token.Text] = token.Kind
+		}
+	}
+	return kinds
+}
+
+func TestTokenize(t *testing.T) {
+	tokens := Tokenize(`ancestors(main@origin, depth=3) & description(exact:"fix \"it\"") ~ foo-bar-`)
+
+	kinds := tokenKinds(tokens)
+	assert.Equal(t, TokenFunction, kinds["ancestors"])
+	assert.Equal(t, TokenSymbol, kinds["main@origin"])
+	assert.Equal(t, TokenArgumentName, kinds["depth"])
+	assert.Equal(t, TokenSymbol, kinds["3"])
+	assert.Equal(t, TokenFunction, kinds["description"])
+	assert.Equal(t, TokenPatternKind, kinds["exact"])
+	assert.Equal(t, TokenString, kinds[`"fix \"it\""`])
+	assert.Equal(t, TokenSymbol, kinds["foo-bar"], "dashes between identifier parts belong to the symbol")
+	assert.Equal(t, TokenOperator, kinds["-"], "a trailing dash is the parents operator")
+	assert.Equal(t, TokenOperator, kinds["~"])
+}
+
+func TestTokenize_CoversInput(t *testing.T) {
+	input := "(@-|trunk()..) :: 'raw'"
+	var rebuilt string
+	for _, token := range Tokenize(input) {
+		assert.Equal(t, input[token.Start:token.End], token.Text)
+		rebuilt += token.Text
+	}
+	assert.Equal(t, input, rebuilt)
+}
+
+func TestParse_Diagnostics(t *testing.T) {
+	isFunction := func(name string) bool { return name == "mine" || name == "heads" }
+	tests := []struct {
+		input    string
+		expected []Diagnostic
+	}{
+		{"heads(mine())", nil},
+		{"heads(mine()", []Diagnostic{{Start: 5, End: 6, Message: "unclosed '('"}}},
+		{"mine())", []Diagnostic{{Start: 6, End: 7, Message: "unmatched ')'"}}},
+		{"nope() | mine()", []Diagnostic{{Start: 0, End: 4, Message: "unknown function or alias `nope`"}}},
+		{`description("abc`, []Diagnostic{
+			{Start: 0, End: 11, Message: "unknown function or alias `description`"},
+			{Start: 12, End: 16, Message: "unterminated string"},
+			{Start: 11, End: 12, Message: "unclosed '('"},
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.expected, Parse(test.input, isFunction).Diagnostics)
+		})
+	}
+}
+
+func TestCompletionProvider_IsFunction(t *testing.T) {
+	provider := NewCompletionProvider(map[string]string{"my_heads(x)": "heads(x)", "wip": "description(wip)"})
+	assert.True(t, provider.IsFunction("ancestors"))
+	assert.True(t, provider.IsFunction("my_heads"))
+	assert.False(t, provider.IsFunction("madeup"))
+}
+
+func TestParseRevsetError(t *testing.T) {
+	output := `Error: Failed to parse revset: Syntax error
+Caused by:  --> 1:7
+  |
+1 | heads(
+  |       ^---
+  |
+  = expected <expression>`
+
+	err := parseRevsetError(output, "heads(")
+	assert.Equal(t, "Failed to parse revset: Syntax error: expected <expression>", err.message)
+	assert.Equal(t, 6, err.start, "positions past the end point at the end of the input")
+	assert.Equal(t, 6, err.end)
+}
+
+func TestParseRevsetError_WithoutPosition(t *testing.T) {
+	err := parseRevsetError("Error: Revision `nope` doesn't exist\n", "nope")
+	assert.Equal(t, "Revision `nope` doesn't exist", err.message)
+	assert.Equal(t, -1, err.start)
+}
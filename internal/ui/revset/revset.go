@@ -1,10 +1,14 @@
 package revset
 
 import (
+	"fmt"
 	"strings"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/ui/actions"
 	"github.com/idursun/jjui/internal/ui/common"
@@ -29,8 +33,19 @@ type revsetMsg struct {
 const (
 	maxCompletionItems = 10
 	pillWidth          = 10
+
+	countDebounce = 300 * time.Millisecond
+	// countLimit caps the count query so that broad revsets stay cheap
+	countLimit = 1000
 )
 
+// countMsg carries the number of revisions matched by value.
+type countMsg struct {
+	value string
+	count int
+	err   error
+}
+
 type completionScrollMsg struct {
 	Delta      int
 	Horizontal bool
@@ -57,6 +72,11 @@ type Model struct {
 	completionItems    []CompletionItem
 	selectedIndex      int
 	userInput          string // tracks what the user actually typed (separate from preview)
+	err                *revsetError
+	count              *countMsg
+	countedValue       string // the value the last count was requested for
+	originalRevset     string // the revset shown before editing, restored on cancel
+	graphPreviewed     bool
 }
 
 func (m *Model) IsEditing() bool {
@@ -73,6 +93,7 @@ func (m *Model) Scopes() []common.Scope {
 			},
 		}
 	}
+	//jjui:layer scope=revset
 	return []common.Scope{
 		{
 			Name:    actions.ScopeRevset,
@@ -187,6 +208,8 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	case EditRevSetMsg:
 		cmd, _ := m.HandleIntent(intents.Edit{})
 		return cmd
+	case countMsg:
+		return m.updateCount(msg)
 	}
 
 	prevValue := m.autoComplete.Value()
@@ -196,12 +219,74 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	// update userInput, reset selection, and re-filter completions
 	newValue := m.autoComplete.Value()
 	if newValue != prevValue {
+		m.err = nil
 		m.userInput = newValue
 		m.selectedIndex = -1 // reset to no selection
 		m.updateCompletionItems()
 	}
 
-	return cmd
+	return tea.Batch(cmd, m.scheduleCount())
+}
+
+// scheduleCount counts the revisions matched by the current value once the
+// user stops typing.
+func (m *Model) scheduleCount() tea.Cmd {
+	value := m.autoComplete.Value()
+	if !m.editing || value == m.countedValue {
+		return nil
+	}
+	m.countedValue = value
+	revset := value
+	if strings.TrimSpace(revset) == "" {
+		revset = m.context.DefaultRevset
+	}
+	return common.Debounce("revset-count", countDebounce, func() tea.Msg {
+		output, err := m.context.RunCommandImmediate(jj.RevsetCount(revset, countLimit))
+		return countMsg{value: value, count: strings.Count(string(output), "x"), err: err}
+	})
+}
+
+func (m *Model) updateCount(msg countMsg) tea.Cmd {
+	if !m.editing || msg.value != m.autoComplete.Value() {
+		return nil
+	}
+	m.count = &msg
+	if msg.err != nil || !config.Current.Revset.LiveGraph {
+		return nil
+	}
+	m.graphPreviewed = true
+	return common.PreviewRevSet(msg.value)
+}
+
+// countText describes the last count, e.g. "12 revisions", or the error jj
+// reported for the revset.
+func (m *Model) countText() string {
+	if m.count == nil {
+		return ""
+	}
+	if m.count.err != nil {
+		return parseRevsetError(m.count.err.Error(), m.count.value).message
+	}
+	switch {
+	case m.count.count >= countLimit:
+		return fmt.Sprintf("%d+ revisions", countLimit)
+	case m.count.count == 1:
+		return "1 revision"
+	default:
+		return fmt.Sprintf("%d revisions", m.count.count)
+	}
+}
+
+func (m *Model) insertAtCursor(text string) {
+	value := []rune(m.autoComplete.Value())
+	position := min(m.autoComplete.TextInput.Position(), len(value))
+	inserted := string(value[:position]) + text + string(value[position:])
+	m.autoComplete.SetValue(inserted)
+	m.autoComplete.TextInput.SetCursor(position + len([]rune(text)))
+	m.userInput = inserted
+	m.err = nil
+	m.selectedIndex = -1
+	m.updateCompletionItems()
 }
 
 func (m *Model) selectCompletionItem(item CompletionItem) {
@@ -244,6 +329,7 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 	switch intent := intent.(type) {
 	case intents.Set:
 		m.editing = false
+		m.graphPreviewed = false
 		m.autoComplete.Blur()
 		value := intent.Value
 		if strings.TrimSpace(value) == "" {
@@ -252,10 +338,12 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		return tea.Batch(common.Close, common.UpdateRevSet(value)), true
 	case intents.Reset:
 		m.editing = false
+		m.graphPreviewed = false
 		m.autoComplete.Blur()
 		return tea.Batch(common.Close, common.UpdateRevSet(m.context.DefaultRevset)), true
 	case intents.Edit:
 		m.editing = true
+		m.err = nil
 		m.autoComplete.Focus()
 		m.completionProvider.Load(m.context.RunCommandImmediate)
 		if intent.Clear {
@@ -266,13 +354,23 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		}
 		m.selectedIndex = -1 // no selection initially
 		m.updateCompletionItems()
-		return m.autoComplete.Init(), true
+		m.originalRevset = m.context.CurrentRevset
+		m.graphPreviewed = false
+		m.count = nil
+		m.countedValue = ""
+		return tea.Batch(m.autoComplete.Init(), m.scheduleCount()), true
 	case intents.Cancel:
 		if !m.editing {
 			return nil, false
 		}
 		m.editing = false
+		m.err = nil
+		m.count = nil
 		m.autoComplete.Blur()
+		if m.graphPreviewed {
+			m.graphPreviewed = false
+			return common.PreviewRevSet(m.originalRevset), true
+		}
 		return nil, true
 	case intents.Apply:
 		if !m.editing {
@@ -286,15 +384,33 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 			value = m.context.DefaultRevset
 		}
 
-		// Validate the revset before applying
+		// Validate the revset before applying, keeping jj's error under the input
 		_, err := m.context.RunCommandImmediate(jj.RevsetValidate(value))
 		if err != nil {
-			return intents.Invoke(intents.AddMessage{Text: err.Error(), Err: err}), true
+			revsetErr := parseRevsetError(err.Error(), value)
+			if value != m.autoComplete.Value() {
+				revsetErr.start, revsetErr.end = -1, -1
+			}
+			m.err = &revsetErr
+			return nil, true
 		}
 
 		m.editing = false
+		m.err = nil
+		m.count = nil
+		m.graphPreviewed = false
 		m.autoComplete.Blur()
 		return tea.Batch(common.Close, common.UpdateRevSet(value)), true
+	case intents.InsertSelectionRevset:
+		if !m.editing {
+			return nil, false
+		}
+		expression := SelectionRevset(intent.Kind, selectedChangeIds(m.context))
+		if expression == "" {
+			return nil, true
+		}
+		m.insertAtCursor(expression)
+		return m.scheduleCount(), true
 	case intents.CompletionCycle:
 		if !m.editing {
 			return nil, false
@@ -361,7 +477,17 @@ func (m *Model) ViewRect(dl *render.DisplayContext, box layout.Box) {
 	tb.Styled("revset: ", titleStyle)
 	if m.editing {
 		// Only render the text input part, not the completions from autoComplete.View()
-		tb.Write(m.autoComplete.TextInput.View())
+		value := m.autoComplete.Value()
+		fieldWidth := box.R.Dx() - render.StringWidth("revset: ")
+		if value != "" && render.StringWidth(value) < fieldWidth {
+			m.renderHighlighted(tb, value)
+		} else {
+			// the text input keeps the placeholder and scrolls values wider
+			// than the field, which the highlighted rendering can't do
+			m.autoComplete.TextInput.SetWidth(max(fieldWidth-1, 1))
+			m.autoComplete.TextInput.SetCursor(m.autoComplete.TextInput.Position())
+			tb.Write(m.autoComplete.TextInput.View())
+		}
 		dl.SetCursorInRect(m.autoComplete.TextInput.Cursor(), box.R, render.StringWidth("revset: "), 0)
 	} else {
 		tb.Styled(m.context.CurrentRevset, textStyle)
@@ -372,6 +498,17 @@ func (m *Model) ViewRect(dl *render.DisplayContext, box layout.Box) {
 		return
 	}
 
+	m.renderCount(dl, box)
+
+	if m.err != nil {
+		errorStyle := common.DefaultPalette.Get("revset", "", "error", false)
+		errRect := layout.Rect(box.R.Min.X, box.R.Max.Y, box.R.Dx(), 1)
+		dl.AddFill(errRect, ' ', common.DefaultPalette.Get("revset", "completion", "", false), render.ZRevsetOverlay-1)
+		dl.AddDraw(errRect, errorStyle.Render(m.err.message), render.ZRevsetOverlay)
+		// completions and signature help go below the pinned error
+		box = layout.NewBox(layout.Rect(box.R.Min.X, box.R.Min.Y+1, box.R.Dx(), box.R.Dy()))
+	}
+
 	// Check if we have completions to show or signature help
 	items := m.completionItems
 	signatureHelp := m.autoComplete.SignatureHelp
@@ -450,6 +587,71 @@ func (m *Model) ViewRect(dl *render.DisplayContext, box layout.Box) {
 	m.listRenderer.RegisterScroll(dl, outerBox)
 }
 
+// renderCount right-aligns the live revision count on the input line, as long
+// as it doesn't cover the input.
+func (m *Model) renderCount(dl *render.DisplayContext, box layout.Box) {
+	if m.count == nil || m.count.value != m.autoComplete.Value() {
+		return
+	}
+	style := common.DefaultPalette.Get("revset", "", "dimmed", false)
+	if m.count.err != nil {
+		style = common.DefaultPalette.Get("revset", "", "error", false)
+	}
+	inputWidth := render.StringWidth("revset: "+m.autoComplete.Value()) + 2
+	available := box.R.Dx() - inputWidth
+	if available <= 1 {
+		return
+	}
+	text := ansi.Truncate(m.countText(), available-1, "…")
+	width := render.StringWidth(text)
+	rect := layout.Rect(box.R.Max.X-width, box.R.Min.Y, width, 1)
+	dl.AddDraw(rect, style.Render(text), render.ZFuzzyInput)
+}
+
+// renderHighlighted writes value token by token. Parts the parser rejects are
+// underlined and the range jj reported an error for is marked.
+func (m *Model) renderHighlighted(tb *render.TextBuilder, value string) {
+	invalidStyle := common.DefaultPalette.Get("revset", "", "invalid", false)
+	markerStyle := common.DefaultPalette.Get("revset", "", "error_marker", false)
+
+	parsed := m.completionProvider.Parse(value)
+	for _, token := range parsed.Tokens {
+		style := tokenStyle(token.Kind)
+		if _, ok := parsed.DiagnosticAt(token.Start); ok {
+			style = invalidStyle.Inherit(style)
+		}
+		if m.err == nil || m.err.start < 0 || m.err.end <= token.Start || m.err.start >= token.End {
+			tb.Styled(token.Text, style)
+			continue
+		}
+		start := max(m.err.start, token.Start)
+		end := min(m.err.end, token.End)
+		tb.Styled(value[token.Start:start], style)
+		tb.Styled(value[start:end], markerStyle.Inherit(style))
+		tb.Styled(value[end:token.End], style)
+	}
+	if m.err != nil && m.err.start >= 0 && m.err.start == len(value) {
+		// jj expected more input
+		tb.Styled(" ", markerStyle)
+	}
+}
+
+//jjui:palette scope=revset role=text|function|operator|string|symbol
+func tokenStyle(kind TokenKind) lipgloss.Style {
+	role := "text"
+	switch kind {
+	case TokenFunction, TokenArgumentName:
+		role = "function"
+	case TokenOperator, TokenOpenParen, TokenCloseParen, TokenComma:
+		role = "operator"
+	case TokenString, TokenPatternKind:
+		role = "string"
+	case TokenSymbol:
+		role = "symbol"
+	}
+	return common.DefaultPalette.Get("revset", "", role, false)
+}
+
 func pillLabel(kind CompletionKind) string {
 	switch kind {
 	case KindFunction:
@@ -6,6 +6,7 @@ import (
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/jj"
 	"github.com/idursun/jjui/internal/ui/common"
@@ -258,18 +259,121 @@ func TestModel_Update_ApplyValidationErrorAndCancel(t *testing.T) {
 	model.autoComplete.SetValue("invalid")
 
 	cmd := model.Update(intents.Apply{})
-	require.NotNil(t, cmd)
-	msg := cmd()
-	addMessage, ok := msg.(intents.AddMessage)
-	require.True(t, ok, "apply should report invalid revset as flash message")
-	assert.Equal(t, "invalid revset", addMessage.Text)
+	assert.Nil(t, cmd, "invalid revset should not be reported as a flash message")
 	assert.True(t, model.editing, "invalid apply should keep editing mode")
+	assert.Contains(t, renderEditing(model), "invalid revset")
 
 	cancelCmd := model.Update(intents.Cancel{})
 	assert.Nil(t, cancelCmd)
 	assert.False(t, model.editing, "cancel should exit editing mode")
 }
 
+func TestModel_Update_ApplyValidationErrorIsPinnedUntilInputChanges(t *testing.T) {
+	const jjError = `Error: Failed to parse revset: Function ` + "`foo`" + ` doesn't exist
+Caused by:  --> 1:8
+  |
+1 | mine | foo()
+  |        ^-^
+  |
+  = Function ` + "`foo`" + ` doesn't exist`
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.RevsetValidate("mine | foo()")).SetError(errors.New(jjError))
+	commandRunner.Expect(jj.RevsetCount("mine | foo()x", countLimit)).SetError(errors.New(jjError))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.editing = true
+	model.autoComplete.SetValue("mine | foo()")
+
+	model.Update(intents.Apply{})
+	require.NotNil(t, model.err)
+	assert.Equal(t, 7, model.err.start)
+	assert.Equal(t, 10, model.err.end)
+	rendered := renderEditing(model)
+	assert.Contains(t, rendered, "revset: mine | foo()")
+	assert.Contains(t, rendered, "Failed to parse revset: Function `foo` doesn't exist")
+
+	test.SimulateModel(model, test.Type("x"))
+	assert.Nil(t, model.err, "editing the revset should clear the error")
+}
+
+func TestModel_Update_ShowsLiveRevisionCount(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.BookmarkListAll())
+	commandRunner.Expect(jj.TagList())
+	commandRunner.Expect(jj.RevsetCount("mine", countLimit)).SetOutput([]byte("xxx"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.Update(intents.Edit{Clear: true})
+	test.SimulateModel(model, test.Type("mine"))
+
+	assert.Contains(t, renderEditing(model), "3 revisions")
+}
+
+func TestModel_Update_LiveGraphRevertsOnCancel(t *testing.T) {
+	original := config.Current.Revset.LiveGraph
+	t.Cleanup(func() { config.Current.Revset.LiveGraph = original })
+	config.Current.Revset.LiveGraph = true
+
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.BookmarkListAll())
+	commandRunner.Expect(jj.TagList())
+	commandRunner.Expect(jj.RevsetCount("mine", countLimit)).SetOutput([]byte("x"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.CurrentRevset = "all()"
+	model := New(ctx)
+
+	var previewed []string
+	observe := func(msg tea.Msg) {
+		if preview, ok := msg.(common.PreviewRevSetMsg); ok {
+			previewed = append(previewed, string(preview))
+		}
+	}
+	model.Update(intents.Edit{Clear: true})
+	test.SimulateModel(model, test.Type("mine"), observe)
+	assert.Equal(t, []string{"mine"}, previewed)
+	assert.Contains(t, renderEditing(model), "1 revision")
+
+	test.SimulateModel(model, intents.Invoke(intents.Cancel{}), observe)
+	assert.Equal(t, []string{"mine", "all()"}, previewed, "cancel should restore the previous revset")
+}
+
+func TestModel_View_UnderlinesUnknownFunction(t *testing.T) {
+	originalPalette := common.DefaultPalette
+	t.Cleanup(func() { common.DefaultPalette = originalPalette })
+	common.DefaultPalette = common.NewPalette()
+	common.DefaultPalette.Update(map[string]config.Color{
+		"revset invalid":  {Underline: boolPtr(true)},
+		"revset function": {Fg: "cyan"},
+	})
+
+	commandRunner := test.NewTestCommandRunner(t)
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.editing = true
+	model.autoComplete.SetValue("mine() | nope()")
+
+	dl := render.NewDisplayContext()
+	model.ViewRect(dl, layout.NewBox(layout.Rect(0, 0, 40, 1)))
+	buf := render.NewScreenBuffer(40, 1)
+	dl.Render(buf)
+
+	offset := len("revset: ")
+	known := buf.CellAt(offset, 0)
+	unknown := buf.CellAt(offset+len("mine() | "), 0)
+	require.NotNil(t, known)
+	require.NotNil(t, unknown)
+	assert.Zero(t, known.Style.Underline, "known functions should not be underlined")
+	assert.NotZero(t, unknown.Style.Underline, "unknown functions should be underlined")
+}
+
 func TestModel_Update_ApplyEmptyUsesDefaultRevset(t *testing.T) {
 	commandRunner := test.NewTestCommandRunner(t)
 	commandRunner.Expect(jj.RevsetValidate("assume-passed-from-cli"))
@@ -296,6 +400,15 @@ func TestModel_Update_ApplyEmptyUsesDefaultRevset(t *testing.T) {
 
 func boolPtr(v bool) *bool { return &v }
 
+// renderEditing renders the input line along with the overlays drawn below it.
+func renderEditing(model *Model) string {
+	dl := render.NewDisplayContext()
+	model.ViewRect(dl, layout.NewBox(layout.Rect(0, 0, 100, 1)))
+	buf := render.NewScreenBuffer(100, 5)
+	dl.Render(buf)
+	return buf.Render()
+}
+
 func renderExpectedCellColors(t *testing.T, content string) (any, any) {
 	t.Helper()
 	dl := render.NewDisplayContext()
@@ -305,3 +418,96 @@ func renderExpectedCellColors(t *testing.T, content string) (any, any) {
 	cell := buf.CellAt(0, 0)
 	return cell.Style.Fg, cell.Style.Bg
 }
+
+func TestSelectionRevset(t *testing.T) {
+	ids := []string{"abc", "def"}
+	tests := []struct {
+		kind     intents.SelectionRevsetKind
+		expected string
+	}{
+		{intents.SelectionRevsetSelected, "(abc|def)"},
+		{intents.SelectionRevsetDescendants, "(abc|def)::"},
+		{intents.SelectionRevsetAncestors, "::(abc|def)"},
+		{intents.SelectionRevsetRange, "(abc|def)::(abc|def)"},
+		{intents.SelectionRevsetHeads, "heads(abc|def)"},
+		{intents.SelectionRevsetRoots, "roots(abc|def)"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, SelectionRevset(test.kind, ids))
+	}
+	assert.Equal(t, "abc::", SelectionRevset(intents.SelectionRevsetDescendants, ids[:1]))
+	assert.Empty(t, SelectionRevset(intents.SelectionRevsetHeads, nil))
+}
+
+func TestModel_InsertSelectionRevsetAtCursor(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.SelectedItem = common.SelectedRevision{ChangeId: "xyz"}
+	ctx.CheckedItems = []common.SelectedItem{
+		common.SelectedRevision{ChangeId: "abc"},
+		common.SelectedRevision{ChangeId: "def"},
+	}
+	model := New(ctx)
+	model.editing = true
+	model.autoComplete.SetValue("mine() &  ~ immutable()")
+	model.autoComplete.TextInput.SetCursor(len("mine() & "))
+
+	cmd := model.Update(intents.InsertSelectionRevset{Kind: intents.SelectionRevsetDescendants})
+	assert.NotNil(t, cmd, "inserting should schedule a new count")
+	assert.Equal(t, "mine() & (abc|def):: ~ immutable()", model.autoComplete.Value())
+	assert.Equal(t, len("mine() & (abc|def)::"), model.autoComplete.TextInput.Position())
+	assert.Equal(t, model.autoComplete.Value(), model.userInput)
+}
+
+func TestModel_InsertSelectionAfterIntersectionKeepsUnionTogether(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.CheckedItems = []common.SelectedItem{
+		common.SelectedRevision{ChangeId: "abc"},
+		common.SelectedRevision{ChangeId: "def"},
+	}
+	model := New(ctx)
+	model.editing = true
+	model.autoComplete.SetValue("mine() & ")
+	model.autoComplete.CursorEnd()
+
+	model.Update(intents.InsertSelectionRevset{Kind: intents.SelectionRevsetSelected})
+	assert.Equal(t, "mine() & (abc|def)", model.autoComplete.Value())
+}
+
+func TestModel_InsertSelectionRevsetFallsBackToCursorRevision(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.SelectedItem = common.SelectedRevision{ChangeId: "xyz"}
+	model := New(ctx)
+	model.editing = true
+	model.autoComplete.SetValue("")
+
+	model.Update(intents.InsertSelectionRevset{Kind: intents.SelectionRevsetHeads})
+	assert.Equal(t, "heads(xyz)", model.autoComplete.Value())
+}
+
+func TestModel_View_ScrollsValuesWiderThanTheField(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	model := New(ctx)
+	model.editing = true
+	model.autoComplete.Focus()
+	model.autoComplete.SetValue("description(substring:\"a long description\") | mine()")
+	model.autoComplete.CursorEnd()
+
+	dl := render.NewDisplayContext()
+	model.ViewRect(dl, layout.NewBox(layout.Rect(0, 0, 30, 1)))
+	buf := render.NewScreenBuffer(30, 1)
+	dl.Render(buf)
+
+	assert.Contains(t, ansi.Strip(buf.Render()), "mine()", "the end of the value should scroll into view")
+}
@@ -0,0 +1,57 @@
+package revset
+
+import (
+	"strings"
+
+	"github.com/idursun/jjui/internal/ui/common"
+	appContext "github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/intents"
+)
+
+// SelectionRevset builds a revset expression of the given kind out of change
+// ids. Unions are parenthesised so that the expression keeps its meaning when
+// it is inserted next to other operators. It returns an empty string when
+// there is nothing selected.
+func SelectionRevset(kind intents.SelectionRevsetKind, changeIds []string) string {
+	if len(changeIds) == 0 {
+		return ""
+	}
+	set := strings.Join(changeIds, "|")
+	operand := set
+	if len(changeIds) > 1 {
+		operand = "(" + set + ")"
+	}
+	switch kind {
+	case intents.SelectionRevsetDescendants:
+		return operand + "::"
+	case intents.SelectionRevsetAncestors:
+		return "::" + operand
+	case intents.SelectionRevsetRange:
+		// connects the selected revisions whichever way round they were checked
+		return operand + "::" + operand
+	case intents.SelectionRevsetHeads:
+		return "heads(" + set + ")"
+	case intents.SelectionRevsetRoots:
+		return "roots(" + set + ")"
+	default:
+		return operand
+	}
+}
+
+// selectedChangeIds returns the checked revisions, falling back to the
+// revision under the cursor.
+func selectedChangeIds(ctx *appContext.MainContext) []string {
+	var ids []string
+	for _, item := range ctx.CheckedItems {
+		if rev, ok := item.(common.SelectedRevision); ok {
+			ids = append(ids, rev.ChangeId)
+		}
+	}
+	if len(ids) > 0 {
+		return ids
+	}
+	if rev, ok := ctx.SelectedItem.(common.SelectedRevision); ok && rev.ChangeId != "" {
+		return []string{rev.ChangeId}
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+package status
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/spinner"
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/scripting"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/context"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+// minContentWidth is the room segments leave for the help bar or the input.
+const minContentWidth = 20
+
+const shortOpIdLength = 12
+
+// luaRefreshInterval is how often Lua segments are evaluated again between
+// revision updates.
+const luaRefreshInterval = time.Second
+
+type luaTickMsg struct{}
+
+// repoInfoMsg carries the repository state shown by the op_id, working_copy,
+// conflicts and unpushed_bookmarks segments.
+type repoInfoMsg struct {
+	opId              string
+	workingCopy       string
+	conflicts         int
+	unpushedBookmarks int
+}
+
+type renderedSegment struct {
+	text     string
+	right    bool
+	priority int
+}
+
+// statusline keeps the state behind the [ui.statusline] segments.
+type statusline struct {
+	context  *context.MainContext
+	info     repoInfoMsg
+	running  map[int]bool
+	finished map[int]bool
+	spinner  spinner.Model
+	// luaText caches what each Lua segment returned, keyed by its expression.
+	luaText    map[string]string
+	luaError   map[string]string
+	luaTicking bool
+}
+
+func newStatusline(context *context.MainContext) statusline {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return statusline{
+		context:  context,
+		running:  make(map[int]bool),
+		finished: make(map[int]bool),
+		spinner:  s,
+		luaText:  make(map[string]string),
+		luaError: make(map[string]string),
+	}
+}
+
+func (s *statusline) update(msg tea.Msg) tea.Cmd {
+	segments := config.Current.UI.Statusline.Segments
+	if len(segments) == 0 {
+		return nil
+	}
+	switch msg := msg.(type) {
+	case common.UpdateRevisionsSuccessMsg:
+		var cmds []tea.Cmd
+		if needsRepoInfo(segments) {
+			cmds = append(cmds, s.loadRepoInfo(segments))
+		}
+		if s.evalLua(segments) && !s.luaTicking {
+			s.luaTicking = true
+			cmds = append(cmds, luaTick())
+		}
+		return tea.Batch(cmds...)
+	case luaTickMsg:
+		if s.evalLua(segments) {
+			return luaTick()
+		}
+		s.luaTicking = false
+	case repoInfoMsg:
+		s.info = msg
+	case common.CommandRunningMsg:
+		// the completion can arrive before the command is reported as running
+		if s.finished[msg.ID] {
+			delete(s.finished, msg.ID)
+			return nil
+		}
+		s.running[msg.ID] = true
+		if hasSegment(segments, config.SegmentSpinner) && len(s.running) == 1 {
+			return s.spinner.Tick
+		}
+	case common.CommandCompletedMsg:
+		if msg.ID == 0 {
+			return nil
+		}
+		if !s.running[msg.ID] {
+			s.finished[msg.ID] = true
+		}
+		delete(s.running, msg.ID)
+	case spinner.TickMsg:
+		if len(s.running) > 0 {
+			var cmd tea.Cmd
+			s.spinner, cmd = s.spinner.Update(msg)
+			return cmd
+		}
+	}
+	return nil
+}
+
+func luaTick() tea.Cmd {
+	return tea.Tick(luaRefreshInterval, func(time.Time) tea.Msg { return luaTickMsg{} })
+}
+
+// evalLua refreshes the cached text of the Lua segments and reports whether
+// there are any.
+func (s *statusline) evalLua(segments []config.StatuslineSegment) bool {
+	found := false
+	for _, segment := range segments {
+		if segment.Lua == "" {
+			continue
+		}
+		found = true
+		text, err := scripting.EvalSegment(s.context, segment.Lua)
+		if err != nil {
+			// only log when the error changes, this runs on every tick
+			if s.luaError[segment.Lua] != err.Error() {
+				s.luaError[segment.Lua] = err.Error()
+				log.Printf("statusline segment %q: %v", segment.Name, err)
+			}
+			text = ""
+		} else {
+			delete(s.luaError, segment.Lua)
+		}
+		s.luaText[segment.Lua] = text
+	}
+	return found
+}
+
+func (s *statusline) loadRepoInfo(segments []config.StatuslineSegment) tea.Cmd {
+	runner := s.context
+	return func() tea.Msg {
+		var info repoInfoMsg
+		if hasSegment(segments, config.SegmentOpId) {
+			if out, err := runner.RunCommandImmediate(jj.OpLogId(false)); err == nil {
+				info.opId = strings.TrimSpace(string(out))
+				if len(info.opId) > shortOpIdLength {
+					info.opId = info.opId[:shortOpIdLength]
+				}
+			}
+		}
+		if hasSegment(segments, config.SegmentWorkingCopy) || hasSegment(segments, config.SegmentConflicts) {
+			if out, err := runner.RunCommandImmediate(jj.WorkingCopyAndConflicts()); err == nil {
+				for line := range strings.SplitSeq(string(out), "\n") {
+					switch {
+					case strings.HasPrefix(line, "@"):
+						info.workingCopy = strings.TrimPrefix(line, "@")
+					case line == "!":
+						info.conflicts++
+					}
+				}
+			}
+		}
+		if hasSegment(segments, config.SegmentUnpushedBookmarks) {
+			if out, err := runner.RunCommandImmediate(jj.BookmarkListAll()); err == nil {
+				for _, bookmark := range jj.ParseBookmarkListOutput(string(out)) {
+					if bookmark.IsUnpushed() {
+						info.unpushedBookmarks++
+					}
+				}
+			}
+		}
+		return info
+	}
+}
+
+// text returns what a segment shows, or "" when it has nothing to show.
+func (s *statusline) text(segment config.StatuslineSegment) string {
+	if segment.Lua != "" {
+		return s.luaText[segment.Lua]
+	}
+	switch segment.Name {
+	case config.SegmentRevset:
+		return s.context.CurrentRevset
+	case config.SegmentOpId:
+		if s.info.opId != "" {
+			return "op " + s.info.opId
+		}
+	case config.SegmentWorkingCopy:
+		if s.info.workingCopy != "" {
+			return "@ " + s.info.workingCopy
+		}
+	case config.SegmentConflicts:
+		return count(s.info.conflicts, "conflict", "conflicts")
+	case config.SegmentChecked:
+		return count(len(s.context.CheckedItems), "checked", "checked")
+	case config.SegmentUnpushedBookmarks:
+		return count(s.info.unpushedBookmarks, "unpushed bookmark", "unpushed bookmarks")
+	case config.SegmentSpinner:
+		if len(s.running) > 0 {
+			return s.spinner.View()
+		}
+	}
+	return ""
+}
+
+func (s *statusline) render() []renderedSegment {
+	var segments []renderedSegment
+	for _, segment := range config.Current.UI.Statusline.Segments {
+		text := s.text(segment)
+		if text == "" {
+			continue
+		}
+		role := segment.Name
+		if segment.Lua != "" {
+			role = "lua"
+		}
+		//jjui:palette scope=status component=segment role=revset|op_id|working_copy|conflicts|checked|unpushed_bookmarks|spinner|lua
+		style := common.DefaultPalette.Get("status", "segment", role, false).PaddingLeft(1).PaddingRight(1)
+		segments = append(segments, renderedSegment{
+			text:     style.Render(text),
+			right:    segment.Align == "right",
+			priority: segment.Priority,
+		})
+	}
+	return segments
+}
+
+// fitSegments drops the lowest priority segments, the later one first on a
+// tie, until the rest fit in width, and joins what is left on each side.
+func fitSegments(segments []renderedSegment, width int) (left string, right string) {
+	order := make([]int, len(segments))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return segments[b].priority - segments[a].priority
+	})
+	keep := make([]bool, len(segments))
+	used := 0
+	for _, i := range order {
+		w := render.StringWidth(segments[i].text)
+		if used+w > width {
+			continue
+		}
+		used += w
+		keep[i] = true
+	}
+
+	var l, r strings.Builder
+	for i, segment := range segments {
+		if !keep[i] {
+			continue
+		}
+		if segment.right {
+			r.WriteString(segment.text)
+		} else {
+			l.WriteString(segment.text)
+		}
+	}
+	return l.String(), r.String()
+}
+
+func needsRepoInfo(segments []config.StatuslineSegment) bool {
+	return slices.ContainsFunc(segments, func(s config.StatuslineSegment) bool {
+		switch s.Name {
+		case config.SegmentOpId, config.SegmentWorkingCopy, config.SegmentConflicts, config.SegmentUnpushedBookmarks:
+			return s.Lua == ""
+		}
+		return false
+	})
+}
+
+func hasSegment(segments []config.StatuslineSegment, name string) bool {
+	return slices.ContainsFunc(segments, func(s config.StatuslineSegment) bool {
+		return s.Name == name && s.Lua == ""
+	})
+}
+
+func count(n int, singular, plural string) string {
+	switch n {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
@@ -0,0 +1,102 @@
+package status
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/jj"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+	lua "github.com/yuin/gopher-lua"
+)
+
+func withSegments(t *testing.T, segments ...config.StatuslineSegment) {
+	previous := config.Current.UI.Statusline.Segments
+	config.Current.UI.Statusline.Segments = segments
+	t.Cleanup(func() { config.Current.UI.Statusline.Segments = previous })
+}
+
+func TestStatusline_ShowsRepoInfoSegments(t *testing.T) {
+	withSegments(t,
+		config.StatuslineSegment{Name: config.SegmentOpId},
+		config.StatuslineSegment{Name: config.SegmentWorkingCopy},
+		config.StatuslineSegment{Name: config.SegmentConflicts, Align: "right"},
+		config.StatuslineSegment{Name: config.SegmentUnpushedBookmarks, Align: "right"},
+		config.StatuslineSegment{Name: config.SegmentRevset, Align: "right"},
+	)
+	commandRunner := test.NewTestCommandRunner(t)
+	commandRunner.Expect(jj.OpLogId(false)).SetOutput([]byte("0123456789abcdef0123"))
+	commandRunner.Expect(jj.WorkingCopyAndConflicts()).SetOutput([]byte("@kxyz\n!\n!\n"))
+	commandRunner.Expect(jj.BookmarkListAll()).SetOutput([]byte("main;.;false;false;false;b\nmain;origin;true;false;false;a\n"))
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.CurrentRevset = "trunk()..@"
+	m := New(ctx)
+	test.SimulateModel(m, func() tea.Msg { return common.UpdateRevisionsSuccessMsg{} })
+
+	rendered := test.RenderImmediate(m, 150, 1)
+	assert.Contains(t, rendered, "op 0123456789ab ")
+	assert.Contains(t, rendered, "@ kxyz")
+	assert.Contains(t, rendered, "2 conflicts")
+	assert.Contains(t, rendered, "1 unpushed bookmark")
+	assert.Contains(t, rendered, "trunk()..@")
+}
+
+func TestStatusline_SpinnerShowsWhileCommandRuns(t *testing.T) {
+	withSegments(t, config.StatuslineSegment{Name: config.SegmentSpinner})
+	s := newStatusline(nil)
+	spinner := config.StatuslineSegment{Name: config.SegmentSpinner}
+
+	assert.NotNil(t, s.update(common.CommandRunningMsg{ID: 1, Command: "jj git fetch"}))
+	assert.NotEmpty(t, s.text(spinner))
+	s.update(common.CommandCompletedMsg{ID: 1})
+	assert.Empty(t, s.text(spinner))
+
+	// completion reported before the command is marked as running
+	s.update(common.CommandCompletedMsg{ID: 2})
+	s.update(common.CommandRunningMsg{ID: 2, Command: "jj git push"})
+	assert.Empty(t, s.text(spinner))
+}
+
+func TestStatusline_LuaSegmentsAreCachedUntilRefreshed(t *testing.T) {
+	segment := config.StatuslineSegment{Name: "count", Lua: "tostring(n)"}
+	withSegments(t, segment)
+	ctx := test.NewTestContext(test.NewTestCommandRunner(t))
+	ctx.ScriptVM = lua.NewState()
+	defer ctx.ScriptVM.Close()
+	ctx.ScriptVM.SetGlobal("n", lua.LNumber(1))
+	s := newStatusline(ctx)
+
+	assert.Empty(t, s.text(segment))
+	assert.NotNil(t, s.update(common.UpdateRevisionsSuccessMsg{}))
+	assert.Equal(t, "1", s.text(segment))
+
+	ctx.ScriptVM.SetGlobal("n", lua.LNumber(2))
+	assert.Equal(t, "1", s.text(segment))
+	assert.NotNil(t, s.update(luaTickMsg{}))
+	assert.Equal(t, "2", s.text(segment))
+}
+
+func TestFitSegments_DropsLowestPriorityFirst(t *testing.T) {
+	segments := []renderedSegment{
+		{text: "aaaa", priority: 1},
+		{text: "bbbb", priority: 3, right: true},
+		{text: "cccc", priority: 2},
+		{text: "dddd", priority: 2, right: true},
+	}
+
+	left, right := fitSegments(segments, 12)
+	assert.Equal(t, "cccc", left)
+	assert.Equal(t, "bbbbdddd", right)
+
+	left, right = fitSegments(segments, 9)
+	assert.Equal(t, "cccc", left)
+	assert.Equal(t, "bbbb", right)
+
+	left, right = fitSegments(segments, 3)
+	assert.Empty(t, left)
+	assert.Empty(t, right)
+}
@@ -9,11 +9,13 @@ import (
 	"github.com/idursun/jjui/internal/config"
 	"github.com/idursun/jjui/internal/ui/actions"
 	keybindings "github.com/idursun/jjui/internal/ui/bindings"
+	"github.com/idursun/jjui/internal/ui/command_palette"
 	"github.com/idursun/jjui/internal/ui/common"
 	"github.com/idursun/jjui/internal/ui/context"
 	"github.com/idursun/jjui/internal/ui/exec_process"
 	"github.com/idursun/jjui/internal/ui/fuzzy_files"
 	"github.com/idursun/jjui/internal/ui/fuzzy_input"
+	"github.com/idursun/jjui/internal/ui/fuzzy_revisions"
 	"github.com/idursun/jjui/internal/ui/fuzzy_search"
 	"github.com/idursun/jjui/internal/ui/help"
 	"github.com/idursun/jjui/internal/ui/intents"
@@ -30,6 +32,8 @@ const (
 	FocusInput
 	FocusFileSearch
 	FocusQuickSearch
+	FocusRevisionFinder
+	FocusCommandPalette
 )
 
 var _ common.ImmediateModel = (*Model)(nil)
@@ -43,6 +47,7 @@ type Model struct {
 	fuzzy           fuzzy_search.Model
 	statusExpanded  bool
 	statusTruncated bool
+	statusline      statusline
 }
 
 func (m *Model) IsFocused() bool {
@@ -61,7 +66,7 @@ func (m *Model) Scopes() []common.Scope {
 	switch m.focusKind {
 	case FocusFileSearch:
 		scope = actions.ScopeFileSearch
-	case FocusInput:
+	case FocusInput, FocusRevisionFinder, FocusCommandPalette:
 		scope = actions.ScopeStatusInput
 	case FocusQuickSearch:
 		scope = actions.ScopeQuickSearchInput
@@ -69,6 +74,9 @@ func (m *Model) Scopes() []common.Scope {
 		return nil
 	}
 	return []common.Scope{
+		//jjui:layer scope=file_search
+		//jjui:layer scope=status.input
+		//jjui:layer scope=revisions.quick_search.input
 		{
 			Name:    scope,
 			Leak:    common.LeakNone,
@@ -97,7 +105,7 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 			input := m.input.Value()
 			prompt := m.input.Prompt
 			fuzzy := m.fuzzy
-			if fuzzy != nil {
+			if fuzzy != nil && m.focusKind != FocusRevisionFinder && m.focusKind != FocusCommandPalette {
 				if selected := fuzzy_search.SelectedMatch(fuzzy); selected != "" {
 					input = strings.Trim(selected, "'")
 					m.input.SetValue(input)
@@ -111,6 +119,16 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 			m.input.Reset()
 
 			switch {
+			case editMode == "rev find":
+				if fuzzy != nil {
+					return fuzzy.Update(intents.RevisionFinderAccept{}), true
+				}
+				return nil, true
+			case editMode == "palette":
+				if fuzzy != nil {
+					return fuzzy.Update(intents.CommandPaletteAccept{Input: input}), true
+				}
+				return nil, true
 			case strings.HasSuffix(editMode, "file"):
 				if fuzzy != nil {
 					return fuzzy.Update(intents.FileSearchAccept{}), true
@@ -118,6 +136,11 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 				return nil, true
 			case strings.HasPrefix(editMode, "exec"):
 				return func() tea.Msg { return exec_process.ExecMsgFromLine(prompt, input) }, true
+			case editMode == "select":
+				if strings.TrimSpace(input) == "" {
+					return nil, true
+				}
+				return intents.Invoke(intents.RevisionsSelectRevset{Revset: input}), true
 			}
 			return func() tea.Msg { return common.QuickSearchMsg(input) }, true
 		}
@@ -133,6 +156,10 @@ func (m *Model) Init() tea.Cmd {
 }
 
 func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	return tea.Batch(m.statusline.update(msg), m.update(msg))
+}
+
+func (m *Model) update(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
 	case common.FileSearchMsg:
 		m.mode = "rev file"
@@ -141,6 +168,22 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		m.focusKind = FocusFileSearch
 		m.fuzzy = fuzzy_files.NewModel(msg)
 		return tea.Batch(m.fuzzy.Init(), m.input.Focus())
+	case common.RevisionFinderMsg:
+		m.mode = "rev find"
+		m.input.Prompt = "> "
+		m.loadEditingSuggestions()
+		m.focusKind = FocusRevisionFinder
+		m.fuzzy = fuzzy_revisions.NewModel(m.context)
+		return tea.Batch(m.fuzzy.Init(), m.input.Focus())
+	case common.CommandPaletteMsg:
+		palette := command_palette.NewModel(msg)
+		m.mode = "palette"
+		m.input.Prompt = palette.Prompt()
+		m.input.Reset()
+		m.input.ShowSuggestions = false
+		m.focusKind = FocusCommandPalette
+		m.fuzzy = palette
+		return tea.Batch(m.fuzzy.Init(), m.input.Focus())
 	case common.ExecProcessCompletedMsg:
 		if msg.Err != nil {
 			m.mode = "exec " + msg.Msg.Mode.Mode
@@ -194,6 +237,14 @@ func (m *Model) StartQuickSearch() tea.Cmd {
 	return m.input.Focus()
 }
 
+func (m *Model) StartSelectRevset() tea.Cmd {
+	m.focusKind = FocusInput
+	m.mode = "select"
+	m.input.Prompt = "revset> "
+	m.loadEditingSuggestions()
+	return m.input.Focus()
+}
+
 func (m *Model) saveEditingSuggestions() {
 	input := m.input.Value()
 	if len(strings.TrimSpace(input)) == 0 {
@@ -230,44 +281,47 @@ func (m *Model) ViewRect(dl *render.DisplayContext, box layout.Box) {
 	modeWidth := max(10, len(m.mode)+2)
 	mode := titleStyle.Width(modeWidth).Render(m.mode)
 
-	var statusLine string
+	left, right := fitSegments(m.statusline.render(), max(0, width-modeWidth-1-minContentWidth))
+	leftWidth := render.StringWidth(left)
+	contentWidth := max(0, width-modeWidth-1-leftWidth-render.StringWidth(right))
+
+	var content string
 	if m.IsFocused() {
-		content := m.renderContent(width, modeWidth, shortcutStyle, dimmedStyle)
-		statusLine = lipgloss.JoinHorizontal(lipgloss.Left, mode, textStyle.Render(" "), content)
+		content = m.renderContent(contentWidth, shortcutStyle, dimmedStyle)
 	} else {
-		helpBar := m.renderHelpBar(width, modeWidth, textStyle, shortcutStyle, dimmedStyle)
-		statusLine = lipgloss.JoinHorizontal(lipgloss.Left, mode, textStyle.Render(" "), helpBar)
+		content = m.renderHelpBar(contentWidth, textStyle, shortcutStyle, dimmedStyle)
 	}
+	content = lipgloss.PlaceHorizontal(contentWidth, 0, content, lipgloss.WithWhitespaceStyle(textStyle))
+	statusLine := lipgloss.JoinHorizontal(lipgloss.Left, mode, textStyle.Render(" "), left, content, right)
 
 	dl.AddDraw(box.R, statusLine, 0)
 	if m.IsFocused() {
-		dl.SetCursorInRect(m.input.Cursor(), box.R, modeWidth+1, 0)
+		dl.SetCursorInRect(m.input.Cursor(), box.R, modeWidth+1+leftWidth, 0)
 	}
 	m.renderExpandedStatus(dl, box, width, textStyle, titleStyle, shortcutStyle, dimmedStyle)
 	m.renderFuzzyOverlay(dl, box)
 }
 
 // renderHelpBar renders the help keybindings bar when idle.
-func (m *Model) renderHelpBar(width, modeWidth int, textStyle, shortcutStyle, dimmedStyle lipgloss.Style) string {
+func (m *Model) renderHelpBar(width int, textStyle, shortcutStyle, dimmedStyle lipgloss.Style) string {
 	if len(m.groups) == 0 || m.statusExpanded {
 		return textStyle.Render(" ")
 	}
 
-	availableWidth := max(0, width-modeWidth-2)
-	helpContent, truncated := m.groupedHelpView(m.groups, availableWidth, shortcutStyle, dimmedStyle)
+	helpContent, truncated := m.groupedHelpView(m.groups, max(0, width-1), shortcutStyle, dimmedStyle)
 	m.statusTruncated = truncated
-	return lipgloss.PlaceHorizontal(width, 0, helpContent, lipgloss.WithWhitespaceStyle(textStyle))
+	return helpContent
 }
 
 // renderContent handles input display when focused
-func (m *Model) renderContent(width, modeWidth int, shortcutStyle, dimmedStyle lipgloss.Style) string {
+func (m *Model) renderContent(width int, shortcutStyle, dimmedStyle lipgloss.Style) string {
 	var editHelp string
 	if len(m.groups) > 0 {
 		editHelp, _ = m.groupedHelpView(m.groups, 0, shortcutStyle, dimmedStyle)
 	}
 
 	promptWidth := render.StringWidth(m.input.Prompt) + 2
-	m.input.SetWidth(width - modeWidth - promptWidth - render.StringWidth(editHelp))
+	m.input.SetWidth(width + 1 - promptWidth - render.StringWidth(editHelp))
 	return lipgloss.JoinHorizontal(0, m.input.View(), editHelp)
 }
 
@@ -601,7 +655,8 @@ func New(context *context.MainContext) *Model {
 	t.SetVirtualCursor(false)
 
 	return &Model{
-		context: context,
-		input:   t,
+		context:    context,
+		input:      t,
+		statusline: newStatusline(context),
 	}
 }
@@ -0,0 +1,175 @@
+package themes
+
+import (
+	"slices"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/ui/actions"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+// PreviewMsg asks for the named theme to be shown while browsing, without
+// making it the configured theme yet.
+type PreviewMsg struct {
+	Name string
+}
+
+// SelectedMsg keeps the named theme.
+type SelectedMsg struct {
+	Name string
+}
+
+// EditMsg opens the theme editor on the named theme.
+type EditMsg struct {
+	Name string
+}
+
+// CancelledMsg restores the theme that was active before browsing or editing.
+type CancelledMsg struct{}
+
+type itemClickMsg struct {
+	Index int
+}
+
+type itemScrollMsg struct {
+	Delta      int
+	Horizontal bool
+}
+
+func (m itemScrollMsg) SetDelta(delta int, horizontal bool) tea.Msg {
+	m.Delta = delta
+	m.Horizontal = horizontal
+	return m
+}
+
+const browserTitle = "Themes"
+
+var _ common.ImmediateModel = (*Browser)(nil)
+
+// Browser lists the available themes and previews the highlighted one.
+type Browser struct {
+	themes              []string
+	selected            int
+	listRenderer        *render.ListRenderer
+	ensureCursorVisible bool
+}
+
+func NewBrowser(themes []string, current string) *Browser {
+	b := &Browser{
+		themes:       themes,
+		selected:     max(slices.Index(themes, current), 0),
+		listRenderer: render.NewListRenderer(itemScrollMsg{}),
+	}
+	b.listRenderer.Z = render.ZMenuContent
+	return b
+}
+
+func (b *Browser) Init() tea.Cmd {
+	return nil
+}
+
+func (b *Browser) Scopes() []common.Scope {
+	return []common.Scope{
+		{
+			Name:    actions.ScopeThemeBrowser,
+			Leak:    common.LeakAll,
+			Handler: b,
+		},
+	}
+}
+
+func (b *Browser) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
+	switch intent := intent.(type) {
+	case intents.ThemeBrowserNavigate:
+		return b.move(b.selected + intent.Delta), true
+	case intents.ThemeBrowserEdit:
+		if name, ok := b.current(); ok {
+			return newCmd(EditMsg{Name: name}), true
+		}
+		return nil, true
+	case intents.Apply:
+		if name, ok := b.current(); ok {
+			return newCmd(SelectedMsg{Name: name}), true
+		}
+		return newCmd(CancelledMsg{}), true
+	case intents.Cancel:
+		return newCmd(CancelledMsg{}), true
+	}
+	return nil, false
+}
+
+func (b *Browser) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case intents.Intent:
+		cmd, _ := b.HandleIntent(msg)
+		return cmd
+	case common.CloseViewMsg:
+		return newCmd(CancelledMsg{})
+	case itemScrollMsg:
+		if !msg.Horizontal {
+			b.listRenderer.StartLine = max(b.listRenderer.StartLine+msg.Delta, 0)
+		}
+	case itemClickMsg:
+		return b.move(msg.Index)
+	}
+	return nil
+}
+
+func (b *Browser) current() (string, bool) {
+	if b.selected < 0 || b.selected >= len(b.themes) {
+		return "", false
+	}
+	return b.themes[b.selected], true
+}
+
+// move highlights the theme at index and previews it.
+func (b *Browser) move(index int) tea.Cmd {
+	if len(b.themes) == 0 {
+		return nil
+	}
+	index = max(min(index, len(b.themes)-1), 0)
+	if index == b.selected {
+		return nil
+	}
+	b.selected = index
+	b.ensureCursorVisible = true
+	return newCmd(PreviewMsg{Name: b.themes[index]})
+}
+
+func (b *Browser) ViewRect(dl *render.DisplayContext, box layout.Box) {
+	width := render.StringWidth(browserTitle)
+	for _, name := range b.themes {
+		width = max(width, render.StringWidth(name)+2)
+	}
+	listBox, ok := drawFrame(dl, box, browserTitle, width, min(len(b.themes), maxVisibleItems))
+	if !ok {
+		return
+	}
+
+	textStyle := common.DefaultPalette.Get("choose", "", "text", false)
+	selectedStyle := common.DefaultPalette.GetBlended("choose", "", "", true)
+	itemCount := len(b.themes)
+	b.listRenderer.StartLine = render.ClampStartLine(b.listRenderer.StartLine, listBox.R.Dy(), itemCount)
+	b.listRenderer.Render(
+		dl,
+		listBox,
+		itemCount,
+		b.selected,
+		b.ensureCursorVisible,
+		func(_ int) int { return 1 },
+		func(dl *render.DisplayContext, index int, rect layout.Rectangle) {
+			style := textStyle
+			if index == b.selected {
+				style = selectedStyle
+			}
+			dl.AddDraw(rect, style.Padding(0, 1).Width(rect.Dx()).Render(b.themes[index]), render.ZMenuContent)
+			dl.AddPaint(rect, style, render.ZMenuContent)
+		},
+		func(index int, _ tea.Mouse) tea.Msg { return itemClickMsg{Index: index} },
+	)
+	b.listRenderer.RegisterScroll(dl, listBox)
+	b.ensureCursorVisible = false
+}
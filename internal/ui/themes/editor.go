@@ -0,0 +1,309 @@
+package themes
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/actions"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+// SavedMsg is sent once the edited theme is written to Path.
+type SavedMsg struct {
+	Name string
+	Path string
+}
+
+type editorField int
+
+const (
+	fieldNone editorField = iota
+	fieldFg
+	fieldBg
+	fieldName
+)
+
+const (
+	sample     = "Sample"
+	colorWidth = 14
+)
+
+var _ common.ImmediateModel = (*Editor)(nil)
+
+// Editor lists every palette key with the style it resolves to, and edits the
+// colours of the active theme in place so that changes show up immediately.
+// Saving writes the colours of the base theme with the edited keys on top,
+// leaving out the jj and [ui.colors] overrides the palette is merged with.
+type Editor struct {
+	name                string
+	keys                []string
+	base                map[string]config.Color
+	colors              map[string]config.Color
+	edited              map[string]bool
+	selected            int
+	editing             editorField
+	input               textinput.Model
+	listRenderer        *render.ListRenderer
+	ensureCursorVisible bool
+}
+
+func NewEditor(name string, base map[string]config.Color) *Editor {
+	ti := textinput.New()
+	ti.CharLimit = 100
+	ti.SetWidth(30)
+	ti.SetVirtualCursor(false)
+
+	colors := common.DefaultPalette.Colors()
+	if colors == nil {
+		colors = make(map[string]config.Color)
+	}
+	e := &Editor{
+		name:         name,
+		keys:         config.PaletteKeys(),
+		base:         base,
+		colors:       colors,
+		edited:       make(map[string]bool),
+		input:        ti,
+		listRenderer: render.NewListRenderer(itemScrollMsg{}),
+	}
+	e.listRenderer.Z = render.ZMenuContent
+	return e
+}
+
+func (e *Editor) Init() tea.Cmd {
+	return nil
+}
+
+func (e *Editor) IsEditing() bool {
+	return e.editing != fieldNone
+}
+
+func (e *Editor) Scopes() []common.Scope {
+	if e.IsEditing() {
+		return []common.Scope{
+			//jjui:layer scope=theme_editor.input
+			{
+				Name:    actions.ScopeThemeEditor + ".input",
+				Leak:    common.LeakNone,
+				Handler: e,
+			},
+		}
+	}
+	return []common.Scope{
+		{
+			Name:    actions.ScopeThemeEditor,
+			Leak:    common.LeakAll,
+			Handler: e,
+		},
+	}
+}
+
+func (e *Editor) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
+	switch intent := intent.(type) {
+	case intents.ThemeEditorNavigate:
+		e.move(e.selected + intent.Delta)
+		return nil, true
+	case intents.ThemeEditorEdit:
+		key, ok := e.current()
+		if !ok {
+			return nil, true
+		}
+		if intent.Background {
+			return e.startEditing(fieldBg, e.colors[key].Bg), true
+		}
+		return e.startEditing(fieldFg, e.colors[key].Fg), true
+	case intents.ThemeEditorToggleBold:
+		key, ok := e.current()
+		if !ok {
+			return nil, true
+		}
+		bold := !common.DefaultPalette.Resolve(key).GetBold()
+		color := e.colors[key]
+		color.Bold = &bold
+		return e.set(key, color), true
+	case intents.ThemeEditorSave:
+		return e.startEditing(fieldName, e.name), true
+	case intents.Apply:
+		if e.IsEditing() {
+			return e.finishEditing(), true
+		}
+		return nil, true
+	case intents.Cancel:
+		if e.IsEditing() {
+			e.stopEditing()
+			return nil, true
+		}
+		return newCmd(CancelledMsg{}), true
+	}
+	return nil, false
+}
+
+func (e *Editor) Update(msg tea.Msg) tea.Cmd {
+	switch msg := msg.(type) {
+	case intents.Intent:
+		cmd, _ := e.HandleIntent(msg)
+		return cmd
+	case tea.KeyMsg, tea.PasteMsg:
+		if e.IsEditing() {
+			var cmd tea.Cmd
+			e.input, cmd = e.input.Update(msg)
+			return cmd
+		}
+	case common.CloseViewMsg:
+		return newCmd(CancelledMsg{})
+	case itemScrollMsg:
+		if !msg.Horizontal {
+			e.listRenderer.StartLine = max(e.listRenderer.StartLine+msg.Delta, 0)
+		}
+	case itemClickMsg:
+		if !e.IsEditing() {
+			e.move(msg.Index)
+		}
+	}
+	return nil
+}
+
+func (e *Editor) current() (string, bool) {
+	if e.selected < 0 || e.selected >= len(e.keys) {
+		return "", false
+	}
+	return e.keys[e.selected], true
+}
+
+func (e *Editor) move(index int) {
+	if len(e.keys) == 0 {
+		return
+	}
+	e.selected = max(min(index, len(e.keys)-1), 0)
+	e.ensureCursorVisible = true
+}
+
+func (e *Editor) startEditing(field editorField, value string) tea.Cmd {
+	e.editing = field
+	e.input.Prompt = map[editorField]string{fieldFg: "fg: ", fieldBg: "bg: ", fieldName: "save as: "}[field]
+	e.input.SetValue(value)
+	e.input.CursorEnd()
+	return e.input.Focus()
+}
+
+func (e *Editor) stopEditing() {
+	e.editing = fieldNone
+	e.input.Blur()
+	e.input.Reset()
+}
+
+func (e *Editor) finishEditing() tea.Cmd {
+	field, value := e.editing, strings.TrimSpace(e.input.Value())
+	e.stopEditing()
+	if field == fieldName {
+		return e.save(value)
+	}
+	key, ok := e.current()
+	if !ok {
+		return nil
+	}
+	color := e.colors[key]
+	if field == fieldBg {
+		color.Bg = value
+	} else {
+		color.Fg = value
+	}
+	return e.set(key, color)
+}
+
+// set changes the colour of key and redraws the UI with it.
+func (e *Editor) set(key string, color config.Color) tea.Cmd {
+	e.colors[key] = color
+	e.edited[key] = true
+	common.DefaultPalette.Update(e.colors)
+	return newCmd(common.ThemeChangedMsg{})
+}
+
+func (e *Editor) save(name string) tea.Cmd {
+	colors := maps.Clone(e.base)
+	if colors == nil {
+		colors = make(map[string]config.Color)
+	}
+	for key := range e.edited {
+		colors[key] = e.colors[key]
+	}
+	path, err := config.SaveTheme(name, colors)
+	if err != nil {
+		return intents.Invoke(intents.AddMessage{Text: err.Error(), Err: err})
+	}
+	e.name = name
+	return newCmd(SavedMsg{Name: name, Path: path})
+}
+
+func (e *Editor) ViewRect(dl *render.DisplayContext, box layout.Box) {
+	keyWidth := 0
+	for _, key := range e.keys {
+		keyWidth = max(keyWidth, render.StringWidth(key))
+	}
+	// key, sample and the fg/bg/bold columns
+	width := 1 + keyWidth + 2 + len(sample) + 2 + colorWidth + 2 + colorWidth + 2 + 5
+	height := min(len(e.keys), maxVisibleItems) + 1
+	listBox, ok := drawFrame(dl, box, fmt.Sprintf("Edit theme %q", e.name), width, height)
+	if !ok {
+		return
+	}
+
+	inputStyle := common.DefaultPalette.Get("choose", "", "input", false)
+	listBox, inputBox := listBox.CutBottom(1)
+	if e.IsEditing() {
+		dl.AddDraw(inputBox.R, inputStyle.Render(e.input.View()), render.ZMenuContent)
+		dl.AddPaint(inputBox.R, inputStyle, render.ZMenuContent)
+		dl.SetCursorInRect(e.input.Cursor(), inputBox.R, 0, 0)
+	}
+	if listBox.R.Dy() <= 0 {
+		return
+	}
+
+	textStyle := common.DefaultPalette.Get("choose", "", "text", false)
+	dimmedStyle := common.DefaultPalette.Get("choose", "", "dimmed", false)
+	selectedStyle := common.DefaultPalette.GetBlended("choose", "", "", true)
+	itemCount := len(e.keys)
+	e.listRenderer.StartLine = render.ClampStartLine(e.listRenderer.StartLine, listBox.R.Dy(), itemCount)
+	e.listRenderer.Render(
+		dl,
+		listBox,
+		itemCount,
+		e.selected,
+		e.ensureCursorVisible,
+		func(_ int) int { return 1 },
+		func(dl *render.DisplayContext, index int, rect layout.Rectangle) {
+			key := e.keys[index]
+			style := textStyle
+			if index == e.selected {
+				style = selectedStyle
+			}
+			color := e.colors[key]
+			value := func(v string, width int) string {
+				if v == "" {
+					return dimmedStyle.Inherit(style).Render(fmt.Sprintf("%-*s", width, "-"))
+				}
+				return style.Render(fmt.Sprintf("%-*.*s", width, width, v))
+			}
+			bold := ""
+			if color.Bold != nil {
+				bold = fmt.Sprint(*color.Bold)
+			}
+			gap := style.Render("  ")
+			line := style.Render(fmt.Sprintf(" %-*s", keyWidth, key)) + gap +
+				common.DefaultPalette.Resolve(key).Render(sample) + gap +
+				value(color.Fg, colorWidth) + gap + value(color.Bg, colorWidth) + gap + value(bold, 5)
+			dl.AddFill(rect, ' ', style, render.ZMenuContent)
+			dl.AddDraw(rect, line, render.ZMenuContent)
+		},
+		func(index int, _ tea.Mouse) tea.Msg { return itemClickMsg{Index: index} },
+	)
+	e.listRenderer.RegisterScroll(dl, listBox)
+	e.ensureCursorVisible = false
+}
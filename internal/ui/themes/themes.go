@@ -0,0 +1,41 @@
+package themes
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+const maxVisibleItems = 20
+
+// drawFrame draws a centred menu with a title line above height lines of the
+// given width, and returns the box below the title.
+func drawFrame(dl *render.DisplayContext, box layout.Box, title string, width, height int) (layout.Box, bool) {
+	borderStyle := common.DefaultPalette.GetBorder("choose", "", "border", false, lipgloss.RoundedBorder())
+	surfaceStyle := common.DefaultPalette.Get("choose", "", "", false)
+	titleStyle := common.DefaultPalette.Get("choose", "", "title", false)
+
+	width = min(width, box.R.Dx()-2)
+	height = min(height+1, box.R.Dy()-2)
+	if width <= 0 || height <= 1 {
+		return layout.Box{}, false
+	}
+
+	frame := box.Center(width+2, height+2)
+	dl.AddBackdrop(box.R, render.ZMenuBorder-1)
+	contentBox := frame.Inset(1)
+	dl.AddFill(contentBox.R, ' ', surfaceStyle, render.ZMenuContent)
+	borderBase := lipgloss.NewStyle().Width(contentBox.R.Dx()).Height(contentBox.R.Dy()).Render("")
+	dl.AddDraw(frame.R, borderStyle.Render(borderBase), render.ZMenuBorder)
+
+	titleBox, rest := contentBox.CutTop(1)
+	dl.AddDraw(titleBox.R, titleStyle.Render(title), render.ZMenuContent)
+	dl.AddPaint(titleBox.R, titleStyle, render.ZMenuContent)
+	return rest, rest.R.Dx() > 0 && rest.R.Dy() > 0
+}
+
+func newCmd(msg tea.Msg) tea.Cmd {
+	return func() tea.Msg { return msg }
+}
@@ -0,0 +1,104 @@
+package themes
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/intents"
+	"github.com/idursun/jjui/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func usePalette(t *testing.T, colors map[string]config.Color) {
+	orig := common.DefaultPalette
+	t.Cleanup(func() { common.DefaultPalette = orig })
+	common.DefaultPalette = common.NewPalette()
+	common.DefaultPalette.Update(colors)
+}
+
+func TestBrowser_MovingPreviewsTheHighlightedTheme(t *testing.T) {
+	b := NewBrowser([]string{"default", "dracula", "solarized"}, "dracula")
+
+	cmd, handled := b.HandleIntent(intents.ThemeBrowserNavigate{Delta: 1})
+	require.True(t, handled)
+	assert.Equal(t, PreviewMsg{Name: "solarized"}, cmd())
+
+	cmd, _ = b.HandleIntent(intents.ThemeBrowserNavigate{Delta: 1})
+	assert.Nil(t, cmd, "the last theme stays highlighted")
+
+	cmd, _ = b.HandleIntent(intents.Apply{})
+	assert.Equal(t, SelectedMsg{Name: "solarized"}, cmd())
+}
+
+func TestBrowser_ClickPreviewsAndCancelRestores(t *testing.T) {
+	b := NewBrowser([]string{"default", "dracula"}, "default")
+
+	assert.Equal(t, PreviewMsg{Name: "dracula"}, b.Update(itemClickMsg{Index: 1})())
+	assert.Equal(t, CancelledMsg{}, b.Update(common.CloseViewMsg{})())
+}
+
+func TestBrowser_View(t *testing.T) {
+	usePalette(t, nil)
+	b := NewBrowser([]string{"default", "dracula"}, "default")
+
+	output := test.RenderImmediate(b, 80, 20)
+	assert.Contains(t, output, "Themes")
+	assert.Contains(t, output, "dracula")
+}
+
+func TestEditor_ListsEveryPaletteKey(t *testing.T) {
+	usePalette(t, map[string]config.Color{"title": {Fg: "magenta"}})
+
+	e := NewEditor("default", nil)
+	assert.Equal(t, config.PaletteKeys(), e.keys)
+	assert.Contains(t, e.keys, "revisions text", "listed before anything looks it up")
+
+	e.HandleIntent(intents.ThemeEditorNavigate{Delta: slices.Index(e.keys, "title")})
+	output := test.RenderImmediate(e, 100, 20)
+	assert.Contains(t, output, "title")
+	assert.Contains(t, output, "magenta")
+}
+
+func TestEditor_EditsApplyToThePalette(t *testing.T) {
+	usePalette(t, map[string]config.Color{"title": {Fg: "magenta"}})
+	e := NewEditor("default", nil)
+	e.HandleIntent(intents.ThemeEditorNavigate{Delta: slices.Index(e.keys, "title")})
+
+	e.HandleIntent(intents.ThemeEditorToggleBold{})
+	assert.True(t, common.DefaultPalette.Get("", "", "title", false).GetBold())
+
+	e.HandleIntent(intents.ThemeEditorEdit{Background: true})
+	assert.True(t, e.IsEditing())
+	e.Update(tea.KeyPressMsg{Text: "1", Code: '1'})
+	e.HandleIntent(intents.Apply{})
+
+	assert.False(t, e.IsEditing())
+	assert.Equal(t, "1", common.DefaultPalette.Colors()["title"].Bg)
+	assert.Equal(t, "magenta", common.DefaultPalette.Colors()["title"].Fg)
+}
+
+func TestEditor_SaveWritesTheThemeFile(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("JJUI_CONFIG_DIR", configDir)
+	// "diff added" and the blue border come from overrides, not from the theme
+	usePalette(t, map[string]config.Color{"title": {Fg: "magenta"}, "diff added": {Fg: "green"}, "border": {Fg: "blue"}})
+	e := NewEditor("default", map[string]config.Color{"title": {Fg: "magenta"}, "border": {Fg: "cyan"}})
+
+	e.HandleIntent(intents.ThemeEditorNavigate{Delta: slices.Index(e.keys, "title")})
+	e.HandleIntent(intents.ThemeEditorToggleBold{})
+	e.HandleIntent(intents.ThemeEditorSave{})
+	e.input.SetValue("mine")
+	cmd, _ := e.HandleIntent(intents.Apply{})
+
+	path := filepath.Join(configDir, "themes", "mine.toml")
+	assert.Equal(t, SavedMsg{Name: "mine", Path: path}, cmd())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "[colors]\nborder = \"cyan\"\ntitle = { fg = \"magenta\", bold = true }\n", string(data))
+}
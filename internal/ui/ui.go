@@ -26,6 +26,7 @@ import (
 	"github.com/idursun/jjui/internal/ui/bookmarks"
 	"github.com/idursun/jjui/internal/ui/choose"
 	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/content_search"
 	"github.com/idursun/jjui/internal/ui/context"
 	"github.com/idursun/jjui/internal/ui/diff"
 	"github.com/idursun/jjui/internal/ui/exec_process"
@@ -33,6 +34,7 @@ import (
 	"github.com/idursun/jjui/internal/ui/help"
 
 	"github.com/idursun/jjui/internal/ui/input"
+	"github.com/idursun/jjui/internal/ui/notifications"
 	"github.com/idursun/jjui/internal/ui/operations/target_picker"
 	"github.com/idursun/jjui/internal/ui/oplog"
 	"github.com/idursun/jjui/internal/ui/redo"
@@ -40,7 +42,9 @@ import (
 	"github.com/idursun/jjui/internal/ui/revset"
 	"github.com/idursun/jjui/internal/ui/split"
 	"github.com/idursun/jjui/internal/ui/status"
+	"github.com/idursun/jjui/internal/ui/themes"
 	"github.com/idursun/jjui/internal/ui/undo"
+	"github.com/idursun/jjui/internal/ui/which_key"
 )
 
 type Model struct {
@@ -49,6 +53,7 @@ type Model struct {
 	revsetModel      *revset.Model
 	diff             *diff.Model
 	flash            *flash.Model
+	whichKey         *which_key.Model
 	state            common.State
 	status           *status.Model
 	password         *password.Model
@@ -63,6 +68,9 @@ type Model struct {
 	width            int
 	height           int
 	splitContainer   *split.SplitContainer
+	// themeRestore is the theme configuration to go back to when the theme
+	// browser or editor is cancelled.
+	themeRestore *config.ThemeConfig
 
 	// mode2031Supported is set when the terminal confirms it supports
 	// mode 2031 push. Once true, the OSC 11 polling loop stops.
@@ -98,6 +106,37 @@ func (m *Model) selectionSnapshot() common.SelectionSnapshot {
 	return snapshot
 }
 
+// conditionValue returns the value of a variable used in the `when` condition
+// of a binding.
+func (m *Model) conditionValue(name string) any {
+	switch name {
+	case "checked.count":
+		return len(m.selectionSnapshot().Checked)
+	case "preview.visible":
+		return slices.ContainsFunc(m.splitScopes(), func(scope common.Scope) bool {
+			return scope.Name == actions.ScopeUiPreview
+		})
+	}
+	// the selection variables describe the revision under the cursor, which
+	// the oplog does not have
+	if m.oplog != nil || m.revisions == nil {
+		return false
+	}
+	commit := m.revisions.SelectedRevision()
+	if commit == nil {
+		return false
+	}
+	switch name {
+	case "selection.is_working_copy":
+		return commit.IsWorkingCopy
+	case "selection.has_conflict":
+		return commit.Conflicted
+	case "selection.is_root":
+		return commit.IsRoot()
+	}
+	return nil
+}
+
 func (m *Model) selectionProviders() []common.SelectionProvider {
 	var providers []common.SelectionProvider
 	if provider, ok := m.stacked.(common.SelectionProvider); ok {
@@ -202,6 +241,9 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		if cmd, handled := m.handleSplitMouseMsg(msg); handled {
 			return cmd
 		}
+		if m.revisions.IsDragging() {
+			return m.revisions.Update(msg)
+		}
 	case tea.MouseClickMsg, tea.MouseWheelMsg:
 		if m.displayContext != nil {
 			if interactionMsg, handled := m.displayContext.ProcessMouseEvent(msg.(tea.MouseMsg)); handled {
@@ -215,42 +257,20 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	case tea.KeyMsg:
 		if m.resolver != nil {
 			scopes := m.dispatchScopes()
-			result := m.resolver.ResolveKey(msg, scopes)
-			if result.Pending {
-				m.setSequenceStatusHelp(result.Continuations)
-				return nil
-			}
-			m.clearSequenceStatusHelp()
-			if result.LuaScript != "" {
-				return luaCmd(result.LuaScript)
-			}
-			if result.Intent != nil {
-				start := slices.IndexFunc(scopes, func(scope common.Scope) bool {
-					return string(scope.Name) == result.Scope
-				})
-				if start < 0 {
-					return nil
-				}
-				if cmd, handled := common.RouteIntent(scopes[start:], result.Intent); handled {
-					return m.withSelectionSync(cmd)
-				}
-				if scopes[start].Leak != common.LeakAll {
-					return m.updateBlockingScope(scopes[start], msg)
-				}
-				return nil
-			}
-			if result.Consumed {
-				return nil
-			}
-
-			for _, scope := range scopes {
-				if scope.Leak != common.LeakAll {
-					return m.updateBlockingScope(scope, msg)
-				}
-			}
-			return nil
+			return m.applyResolved(m.resolver.ResolveKey(msg, scopes), scopes, msg)
 		}
 		return nil
+	case which_key.ContinueMsg:
+		if m.resolver == nil {
+			return nil
+		}
+		var result dispatch.Result
+		for _, key := range msg.Keys {
+			if result = m.resolver.ContinueSequence(key); !result.Pending {
+				break
+			}
+		}
+		return m.applyResolved(result, m.dispatchScopes(), nil)
 	case intents.Intent:
 		if cmd, handled := m.HandleIntent(msg); handled {
 			return m.withSelectionSync(cmd)
@@ -273,6 +293,17 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		m.revsetModel.AddToHistory(m.context.CurrentRevset)
 		m.revsetModel.Update(msg)
 		return common.Refresh
+	case common.RevealRevisionMsg:
+		m.context.CurrentRevset = msg.Revset
+		m.revsetModel.AddToHistory(msg.Revset)
+		m.revsetModel.Update(common.UpdateRevSetMsg(msg.Revset))
+		return common.RefreshAndSelect(msg.Revision)
+	case common.PreviewRevSetMsg:
+		m.context.CurrentRevset = string(msg)
+		if m.context.CurrentRevset == "" {
+			m.context.CurrentRevset = m.context.DefaultRevset
+		}
+		return common.Refresh
 	case common.RunLuaScriptMsg:
 		if msg.CompletionID == "" && m.scriptRunning() {
 			err := fmt.Errorf("lua script is already running")
@@ -325,12 +356,34 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 		m.stacked = nil
 	case choose.CancelledMsg:
 		m.stacked = nil
+	case common.PromptSelectRevsetMsg:
+		return m.status.StartSelectRevset()
 	case common.ShowInputMsg:
 		model := input.NewWithTitle(msg.Title, msg.Prompt, msg.Value)
 		m.stacked = model
 		return m.stacked.Init()
 	case input.SelectedMsg, input.CancelledMsg:
 		m.stacked = nil
+	case notifications.RerunMsg:
+		return m.context.RunCommand(msg.Args, common.Refresh)
+	case themes.PreviewMsg:
+		return m.changeTheme(intents.ChangeTheme{Name: msg.Name})
+	case themes.EditMsg:
+		return m.openThemeEditor(msg.Name)
+	case themes.SelectedMsg:
+		m.stacked = nil
+		m.themeRestore = nil
+		return m.changeTheme(intents.ChangeTheme{Name: msg.Name})
+	case themes.SavedMsg:
+		m.stacked = nil
+		m.themeRestore = nil
+		return tea.Batch(
+			m.changeTheme(intents.ChangeTheme{Name: msg.Name}),
+			intents.Invoke(intents.AddMessage{Text: fmt.Sprintf("theme saved to %s", msg.Path)}),
+		)
+	case themes.CancelledMsg:
+		m.stacked = nil
+		return m.restoreTheme()
 	case common.ShowPreview:
 		if cmd, handled := m.handleSplitMsg(msg); handled {
 			cmds = append(cmds, cmd)
@@ -389,6 +442,7 @@ func (m *Model) Update(msg tea.Msg) tea.Cmd {
 	cmds = append(cmds, m.revsetModel.Update(msg))
 	cmds = append(cmds, m.status.Update(msg))
 	cmds = append(cmds, m.flash.Update(msg))
+	cmds = append(cmds, m.whichKey.Update(msg))
 	if m.diff != nil {
 		cmds = append(cmds, m.diff.Update(msg))
 	}
@@ -445,10 +499,11 @@ func (m *Model) View() string {
 		m.stacked.ViewRect(m.displayContext, box)
 	}
 
-	if scope, ok := m.stackedScope(); !ok || scope != actions.ScopeCommandHistory {
+	if scope, ok := m.stackedScope(); !ok || (scope != actions.ScopeCommandHistory && scope != actions.ScopeNotifications) {
 		flashBox, _ := box.CutBottom(1)
 		m.flash.ViewRect(m.displayContext, flashBox)
 	}
+	m.whichKey.ViewRect(m.displayContext, box)
 
 	if m.password != nil {
 		m.password.ViewRect(m.displayContext, box)
@@ -533,6 +588,7 @@ func (m *Model) dispatchScopes() []common.Scope {
 	if !m.revsetModel.IsEditing() {
 		scopes = append(scopes, m.revsetModel.Scopes()...)
 	}
+	//jjui:layer scope=ui
 	scopes = append(scopes, common.Scope{
 		Name:    scopeUi,
 		Leak:    common.LeakNone,
@@ -574,6 +630,9 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		model := git.NewModel(m.context, m.revisions.SelectedRevisions())
 		m.stacked = model
 		return m.stacked.Init(), true
+	case intents.OpenContentSearch:
+		m.stacked = content_search.NewModel(m.context)
+		return m.stacked.Init(), true
 	case intents.OpenBookmarks:
 		current := m.revisions.SelectedRevision()
 		if current == nil {
@@ -601,6 +660,17 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		model := help.New()
 		m.stacked = model
 		return m.stacked.Init(), true
+	case intents.OpenThemeBrowser:
+		names, err := config.ListThemes()
+		if err != nil {
+			return intents.Invoke(intents.AddMessage{Text: err.Error(), Err: err}), true
+		}
+		m.rememberTheme()
+		m.stacked = themes.NewBrowser(names, m.activeThemeName())
+		return m.stacked.Init(), true
+	case intents.OpenThemeEditor:
+		m.rememberTheme()
+		return m.openThemeEditor(m.activeThemeName()), true
 	case intents.CommandHistoryToggle:
 		if scope, ok := m.stackedScope(); ok && scope == actions.ScopeCommandHistory {
 			m.stacked = nil
@@ -608,6 +678,9 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		}
 		m.stacked = m.flash.NewHistory()
 		return m.stacked.Init(), true
+	case intents.OpenNotifications:
+		m.stacked = notifications.New(m.context.MessageLog, m.context.Location)
+		return m.stacked.Init(), true
 
 	// --- Activate input modes ---
 	case intents.Edit:
@@ -625,6 +698,14 @@ func (m *Model) HandleIntent(intent intents.Intent) (tea.Cmd, bool) {
 		}
 		out, _ := m.context.RunCommandImmediate(jj.FilesInRevision(rev))
 		return common.FileSearch(m.context.CurrentRevset, rev, out), true
+	case intents.CommandPaletteToggle:
+		var scopes []string
+		for _, scope := range common.VisibleScopes(m.dispatchScopes()) {
+			scopes = append(scopes, string(scope.Name))
+		}
+		return common.CommandPalette(scopes), true
+	case intents.RevisionFinderToggle:
+		return common.RevisionFinder, true
 
 	// --- Split controls ---
 	case intents.PreviewToggle, intents.PreviewToggleBottom, intents.PreviewExpand, intents.PreviewShrink, intents.PreviewShow:
@@ -709,6 +790,45 @@ func (m *Model) changeTheme(intent intents.ChangeTheme) tea.Cmd {
 	return cmd
 }
 
+// activeThemeName returns the name of the theme in use for the current
+// background.
+func (m *Model) activeThemeName() string {
+	name := config.Current.UI.Theme.Light
+	if m.context.TerminalHasDarkBackground {
+		name = config.Current.UI.Theme.Dark
+	}
+	if name == "" {
+		return "default"
+	}
+	return name
+}
+
+// openThemeEditor edits the named theme, which is the one the palette shows.
+func (m *Model) openThemeEditor(name string) tea.Cmd {
+	theme, err := config.LoadTheme(name, m.context.TerminalHasDarkBackground)
+	if err != nil {
+		return intents.Invoke(intents.AddMessage{Text: err.Error(), Err: err})
+	}
+	m.stacked = themes.NewEditor(name, theme.Colors)
+	return m.stacked.Init()
+}
+
+func (m *Model) rememberTheme() {
+	theme := config.Current.UI.Theme
+	m.themeRestore = &theme
+}
+
+// restoreTheme undoes the previews and edits made since the theme browser or
+// editor was opened.
+func (m *Model) restoreTheme() tea.Cmd {
+	if m.themeRestore == nil {
+		return nil
+	}
+	config.Current.UI.Theme = *m.themeRestore
+	m.themeRestore = nil
+	return m.reloadActiveTheme()
+}
+
 func (m *Model) validateRuntimeThemeChange() error {
 	_, err := m.resolveActiveTheme()
 	return err
@@ -725,6 +845,43 @@ func (m *Model) stackedScope() (keybindings.ScopeName, bool) {
 	return scopes[0].Name, true
 }
 
+// applyResolved runs the outcome of resolving a key. msg is the key press it
+// came from, or nil when a which-key continuation was clicked.
+func (m *Model) applyResolved(result dispatch.Result, scopes []common.Scope, msg tea.KeyMsg) tea.Cmd {
+	if result.Pending {
+		return m.setSequenceStatusHelp(result.Continuations)
+	}
+	m.clearSequenceStatusHelp()
+	if result.LuaScript != "" {
+		return luaCmd(result.LuaScript)
+	}
+	if result.Intent != nil {
+		start := slices.IndexFunc(scopes, func(scope common.Scope) bool {
+			return string(scope.Name) == result.Scope
+		})
+		if start < 0 {
+			return nil
+		}
+		if cmd, handled := common.RouteIntent(scopes[start:], result.Intent); handled {
+			return m.withSelectionSync(cmd)
+		}
+		if scopes[start].Leak != common.LeakAll && msg != nil {
+			return m.updateBlockingScope(scopes[start], msg)
+		}
+		return nil
+	}
+	if result.Consumed || msg == nil {
+		return nil
+	}
+
+	for _, scope := range scopes {
+		if scope.Leak != common.LeakAll {
+			return m.updateBlockingScope(scope, msg)
+		}
+	}
+	return nil
+}
+
 func (m *Model) updateBlockingScope(scope common.Scope, msg tea.KeyMsg) tea.Cmd {
 	if scope.Handler == m {
 		return nil
@@ -821,6 +978,7 @@ func NewUI(c *context.MainContext) *Model {
 	revisionsModel := revisions.New(c)
 	statusModel := status.New(c)
 	flashView := flash.New()
+	flashView.SetMessageLog(c.MessageLog)
 	revsetModel := revset.New(c)
 
 	ui := &Model{
@@ -830,19 +988,21 @@ func NewUI(c *context.MainContext) *Model {
 		status:      statusModel,
 		revsetModel: revsetModel,
 		flash:       flashView,
+		whichKey:    which_key.New(),
 	}
 	ui.initSplitContainer()
 	ui.initResolver()
 	return ui
 }
 
-func (m *Model) setSequenceStatusHelp(continuations []dispatch.Continuation) {
+func (m *Model) setSequenceStatusHelp(continuations []dispatch.Continuation) tea.Cmd {
 	entries := help.BuildFromContinuations(continuations)
 	if len(entries) == 0 {
-		return
+		return nil
 	}
 
-	if m.sequenceHelp == nil {
+	// the which-key popup lists the continuations instead of the expanded status
+	if m.sequenceHelp == nil && !config.Current.UI.WhichKey.Enabled {
 		if !m.status.StatusExpanded() {
 			m.status.SetStatusExpanded(true)
 			m.sequenceAutoOpen = true
@@ -851,9 +1011,11 @@ func (m *Model) setSequenceStatusHelp(continuations []dispatch.Continuation) {
 		}
 	}
 	m.sequenceHelp = entries
+	return m.whichKey.Set(continuations)
 }
 
 func (m *Model) clearSequenceStatusHelp() {
+	m.whichKey.Clear()
 	if m.sequenceHelp == nil {
 		return
 	}
@@ -871,6 +1033,7 @@ func (m *Model) initResolver() {
 		return
 	}
 	m.resolver = dispatch.NewResolver(dispatcher)
+	m.resolver.SetConditionValues(m.conditionValue)
 }
 
 // applyColorScheme reloads the palette when the terminal's color scheme
@@ -917,6 +1080,9 @@ func (m *Model) reloadActiveTheme() tea.Cmd {
 		log.Printf("failed to resolve theme: %v", err)
 		return nil
 	}
+	for _, warning := range theme.Warnings {
+		log.Printf("warning: %s", warning)
+	}
 	m.context.ThemeBackgroundBlend = theme.BackgroundBlend
 	common.DefaultPalette.Update(theme.Colors)
 	common.DefaultPalette.ConfigureBackgroundBlend(
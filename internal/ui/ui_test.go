@@ -688,6 +688,8 @@ func Test_Update_RevsetEditingInterceptsQuitKey(t *testing.T) {
 	commandRunner := test.NewTestCommandRunner(t)
 	commandRunner.Expect(jj.BookmarkListAll())
 	commandRunner.Expect(jj.TagList())
+	// typing schedules the live revision count of the revset being edited
+	commandRunner.Expect(jj.RevsetCount("q", 1000))
 	defer commandRunner.Verify()
 
 	ctx := test.NewTestContext(commandRunner)
@@ -1056,6 +1058,36 @@ func Test_Update_LuaRevsetSetWorksOutsideRevsetScope(t *testing.T) {
 	assert.Equal(t, "new", ctx.CurrentRevset)
 }
 
+func Test_Update_PreviewRevSetDoesNotTouchHistory(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.CurrentRevset = "old"
+	model := NewUI(ctx)
+
+	cmd := model.Update(common.PreviewRevSetMsg("preview"))
+	require.NotNil(t, cmd)
+	assert.Equal(t, common.RefreshMsg{}, cmd())
+	assert.Equal(t, "preview", ctx.CurrentRevset)
+	assert.Empty(t, model.revsetModel.History)
+}
+
+func Test_Update_RevealRevisionSwitchesRevsetAndSelects(t *testing.T) {
+	commandRunner := test.NewTestCommandRunner(t)
+	defer commandRunner.Verify()
+
+	ctx := test.NewTestContext(commandRunner)
+	ctx.CurrentRevset = "old"
+	model := NewUI(ctx)
+
+	cmd := model.Update(common.RevealRevisionMsg{Revset: "(old) | abc", Revision: "abc"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, common.RefreshMsg{SelectedRevision: "abc"}, cmd())
+	assert.Equal(t, "(old) | abc", ctx.CurrentRevset)
+	assert.Equal(t, []string{"(old) | abc"}, model.revsetModel.History)
+}
+
 func Test_Update_LuaBuiltinActionBypassesConfiguredOverride(t *testing.T) {
 	origActions := config.Current.Actions
 	defer func() {
@@ -0,0 +1,166 @@
+package which_key
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/common"
+	"github.com/idursun/jjui/internal/ui/dispatch"
+	"github.com/idursun/jjui/internal/ui/help"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/render"
+)
+
+// ContinueMsg is sent when a row of the popup is clicked, with the keys that
+// continue the pending sequence up to that row.
+type ContinueMsg struct {
+	Keys []string
+}
+
+type showMsg struct {
+	generation uint64
+}
+
+// Model is a popup listing the continuations of a pending key sequence. It
+// shows after a delay, so that sequences typed without hesitation never bring
+// it up.
+type Model struct {
+	continuations []dispatch.Continuation
+	visible       bool
+	generation    uint64
+}
+
+type row struct {
+	keys   []string
+	label  string
+	desc   string
+	indent bool
+}
+
+func New() *Model {
+	return &Model{}
+}
+
+// Set updates the continuations of the pending sequence and schedules the
+// popup when it is not showing yet.
+func (m *Model) Set(continuations []dispatch.Continuation) tea.Cmd {
+	if !config.Current.UI.WhichKey.Enabled {
+		return nil
+	}
+	m.continuations = continuations
+	if m.visible {
+		return nil
+	}
+	m.generation++
+	delay := time.Duration(config.Current.UI.WhichKey.DelayMilliseconds) * time.Millisecond
+	if delay <= 0 {
+		m.visible = true
+		return nil
+	}
+	generation := m.generation
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return showMsg{generation: generation}
+	})
+}
+
+// Clear hides the popup once the sequence is resolved or dropped.
+func (m *Model) Clear() {
+	m.continuations = nil
+	m.visible = false
+	m.generation++
+}
+
+func (m *Model) Visible() bool {
+	return m.visible && len(m.continuations) > 0
+}
+
+func (m *Model) Update(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(showMsg); ok && msg.generation == m.generation && len(m.continuations) > 0 {
+		m.visible = true
+	}
+	return nil
+}
+
+// rows lists a row per next key, followed by the sequences reached through it
+// when it does not complete a binding by itself.
+func (m *Model) rows() []row {
+	continuations := slices.Clone(m.continuations)
+	slices.SortStableFunc(continuations, func(a, b dispatch.Continuation) int {
+		return strings.Compare(help.NormalizeDisplayKey(a.Key), help.NormalizeDisplayKey(b.Key))
+	})
+
+	var rows []row
+	for _, continuation := range continuations {
+		label := help.NormalizeDisplayKey(continuation.Key)
+		if len(continuation.Sequences) == 1 && len(continuation.Sequences[0].Keys) == 0 {
+			rows = append(rows, row{keys: []string{continuation.Key}, label: label, desc: continuation.Sequences[0].Desc})
+			continue
+		}
+		rows = append(rows, row{keys: []string{continuation.Key}, label: label, desc: fmt.Sprintf("+%d", len(continuation.Sequences))})
+		for _, sequence := range continuation.Sequences {
+			keys := append([]string{continuation.Key}, sequence.Keys...)
+			labels := make([]string, len(keys))
+			for i, key := range keys {
+				labels[i] = help.NormalizeDisplayKey(key)
+			}
+			rows = append(rows, row{keys: keys, label: strings.Join(labels, " "), desc: sequence.Desc, indent: true})
+		}
+	}
+	return rows
+}
+
+// ViewRect draws the popup in the bottom right corner of box, above the
+// status line.
+func (m *Model) ViewRect(dl *render.DisplayContext, box layout.Box) {
+	if !m.Visible() {
+		return
+	}
+	borderStyle := common.DefaultPalette.GetBorder("help", "", "border", false, lipgloss.RoundedBorder()).Padding(0, 1)
+	shortcutStyle := common.DefaultPalette.Get("help", "", "shortcut", false)
+	dimmedStyle := common.DefaultPalette.Get("help", "", "dimmed", false)
+	descStyle := common.DefaultPalette.Get("help", "", "desc", false).Inherit(dimmedStyle)
+
+	rows := m.rows()
+	labelWidth := 0
+	for _, row := range rows {
+		width := render.StringWidth(row.label)
+		if row.indent {
+			width += 2
+		}
+		labelWidth = max(labelWidth, width)
+	}
+
+	area := box.R
+	area.Max.Y--
+	// border and padding take 4 columns and 2 rows
+	maxWidth := area.Dx() - 4
+	visible := min(len(rows), area.Dy()-2)
+	if maxWidth <= labelWidth || visible <= 0 {
+		return
+	}
+
+	lines := make([]string, visible)
+	for i, row := range rows[:visible] {
+		label := row.label
+		if row.indent {
+			label = "  " + label
+		}
+		label += strings.Repeat(" ", labelWidth-render.StringWidth(label))
+		line := shortcutStyle.Render(label) + dimmedStyle.Render("  ") + descStyle.Render(row.desc)
+		lines[i] = lipgloss.NewStyle().MaxWidth(maxWidth).Render(line)
+	}
+	content := borderStyle.Render(strings.Join(lines, "\n"))
+	w, h := lipgloss.Size(content)
+	rect := layout.Rect(area.Max.X-w, area.Max.Y-h, w, h)
+	dl.AddFill(rect, ' ', dimmedStyle, render.ZOverlay)
+	dl.AddDraw(rect, content, render.ZOverlay)
+	for i, row := range rows[:visible] {
+		rowRect := layout.Rect(rect.Min.X+1, rect.Min.Y+1+i, w-2, 1)
+		dl.AddInteraction(rowRect, ContinueMsg{Keys: row.keys}, render.InteractionClick, render.ZOverlay)
+	}
+}
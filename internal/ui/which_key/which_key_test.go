@@ -0,0 +1,84 @@
+package which_key
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/idursun/jjui/internal/config"
+	"github.com/idursun/jjui/internal/ui/dispatch"
+	"github.com/idursun/jjui/internal/ui/layout"
+	"github.com/idursun/jjui/internal/ui/render"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var continuations = []dispatch.Continuation{
+	{Key: "p", Sequences: []dispatch.SequenceHint{{Keys: []string{"p"}, Desc: "push"}, {Keys: []string{"f"}, Desc: "fetch"}}},
+	{Key: "g", IsLeaf: true, Sequences: []dispatch.SequenceHint{{Desc: "top"}}},
+}
+
+func enable(t *testing.T, delay int) {
+	orig := config.Current.UI.WhichKey
+	t.Cleanup(func() { config.Current.UI.WhichKey = orig })
+	config.Current.UI.WhichKey = config.WhichKeyConfig{Enabled: true, DelayMilliseconds: delay}
+}
+
+func TestSet_ShowsAfterTheDelay(t *testing.T) {
+	enable(t, 100)
+	m := New()
+
+	cmd := m.Set(continuations)
+	require.NotNil(t, cmd)
+	assert.False(t, m.Visible())
+
+	m.Update(cmd())
+	assert.True(t, m.Visible())
+}
+
+func TestSet_ResolvedSequenceCancelsThePendingPopup(t *testing.T) {
+	enable(t, 100)
+	m := New()
+
+	cmd := m.Set(continuations)
+	m.Clear()
+	m.Update(cmd())
+
+	assert.False(t, m.Visible())
+}
+
+func TestSet_DisabledByDefault(t *testing.T) {
+	orig := config.Current.UI.WhichKey
+	t.Cleanup(func() { config.Current.UI.WhichKey = orig })
+	config.Current.UI.WhichKey.Enabled = false
+	m := New()
+
+	assert.Nil(t, m.Set(continuations))
+	assert.False(t, m.Visible())
+}
+
+func TestRows_GroupSequencesUnderTheirNextKey(t *testing.T) {
+	enable(t, 0)
+	m := New()
+	m.Set(continuations)
+
+	assert.Equal(t, []row{
+		{keys: []string{"g"}, label: "g", desc: "top"},
+		{keys: []string{"p"}, label: "p", desc: "+2"},
+		{keys: []string{"p", "p"}, label: "p p", desc: "push", indent: true},
+		{keys: []string{"p", "f"}, label: "p f", desc: "fetch", indent: true},
+	}, m.rows())
+}
+
+func TestViewRect_ClickingARowContinuesTheSequence(t *testing.T) {
+	enable(t, 0)
+	m := New()
+	m.Set(continuations)
+
+	dl := render.NewDisplayContext()
+	m.ViewRect(dl, layout.NewBox(layout.Rect(0, 0, 80, 20)))
+
+	// four rows and a border above the status line at the bottom right
+	msg, handled := dl.ProcessMouseEvent(tea.MouseClickMsg{X: 75, Y: 17, Button: tea.MouseLeft})
+	assert.True(t, handled)
+	assert.Equal(t, ContinueMsg{Keys: []string{"p", "f"}}, msg)
+}
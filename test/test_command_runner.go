@@ -91,6 +91,17 @@ func (t *CommandRunner) RunCommand(args []string, continuations ...tea.Cmd) tea.
 	return tea.Batch(cmds...)
 }
 
+func (t *CommandRunner) RunCommandOnSuccess(args []string, continuations ...tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		output, err := t.RunCommandImmediate(args)
+		completed := common.CommandCompletedMsg{Output: string(output), Err: err}
+		if err != nil {
+			return completed
+		}
+		return tea.BatchMsg(append([]tea.Cmd{func() tea.Msg { return completed }}, continuations...))
+	}
+}
+
 func (t *CommandRunner) RunInteractiveCommand(args []string, continuation tea.Cmd) tea.Cmd {
 	return func() tea.Msg {
 		_, err := t.RunCommandImmediate(args)